@@ -1,15 +1,20 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"encoding/json"
+	httpadapter "hh-resume-parser/internal/adapters/http"
 	"hh-resume-parser/internal/app"
 	"hh-resume-parser/internal/config"
+	"hh-resume-parser/internal/infrastructure/auth"
 	"hh-resume-parser/internal/infrastructure/logger"
 )
 
@@ -17,7 +22,23 @@ import (
 // Инициализирует конфигурацию, зависимости и запускает парсер резюме
 func main() {
 	// Инициализация конфигурации
-	cfg := parseFlags()
+	cfg, opts := parseFlags()
+
+	// Авторизация OAuth2 выполняется отдельно от парсинга
+	if opts.AuthCode != "" || opts.PrintAuthURL {
+		if err := runAuthorization(cfg, opts); err != nil {
+			log.Fatalf("Ошибка авторизации: %v", err)
+		}
+		return
+	}
+
+	// Подкоманды, не требующие полной конфигурации парсинга
+	if len(opts.Args) > 0 {
+		if err := runCommand(cfg, opts.Args); err != nil {
+			log.Fatalf("Ошибка: %v", err)
+		}
+		return
+	}
 
 	// Валидация конфигурации
 	if err := validateConfig(cfg); err != nil {
@@ -41,17 +62,78 @@ func main() {
 	fmt.Println("✅ Парсинг резюме завершен успешно!")
 }
 
+// cliOptions - параметры командной строки, не относящиеся к конфигурации парсинга
+type cliOptions struct {
+	AuthCode     string   // Код авторизации OAuth2 для обмена на токены
+	PrintAuthURL bool     // Вывести адрес страницы авторизации
+	Args         []string // Подкоманда и ее аргументы (например, dictionaries experience)
+}
+
 // parseFlags - парсинг аргументов командной строки
-func parseFlags() *config.Config {
+func parseFlags() (*config.Config, *cliOptions) {
 	cfg := config.GetDefaultConfig()
+	opts := &cliOptions{}
 
 	flag.StringVar(&cfg.API.Token, "token", os.Getenv("HH_API_TOKEN"), "API токен hh.ru")
+
+	var tokens string
+	flag.StringVar(&tokens, "tokens", os.Getenv("HH_API_TOKENS"), "Пул токенов нескольких аккаунтов через запятую: имя=токен:интервал:квота")
+	flag.DurationVar(&cfg.API.RateLimit, "rate", cfg.API.RateLimit, "Интервал между запросами к API")
+	flag.IntVar(&cfg.API.RateBurst, "burst", cfg.API.RateBurst, "Количество запросов подряд без ожидания")
+	flag.IntVar(&cfg.API.DailyQuota, "daily-quota", cfg.API.DailyQuota, "Суточная квота запросов к API (0 - без ограничения)")
+	flag.StringVar(&cfg.API.BaseURL, "api-url", cfg.API.BaseURL, "Базовый адрес API hh.ru")
+	flag.StringVar(&cfg.API.ProxyURL, "proxy", cfg.API.ProxyURL, "Адрес HTTP(S) прокси")
+	flag.StringVar(&cfg.API.CACertFile, "ca-cert", cfg.API.CACertFile, "Дополнительный корневой сертификат (PEM)")
+	flag.BoolVar(&cfg.API.InsecureSkipVerify, "insecure", cfg.API.InsecureSkipVerify, "Не проверять TLS сертификат сервера")
+	flag.StringVar(&cfg.API.OAuth.ClientID, "client-id", os.Getenv("HH_CLIENT_ID"), "Client ID приложения hh.ru (OAuth2)")
+	flag.StringVar(&cfg.API.OAuth.ClientSecret, "client-secret", os.Getenv("HH_CLIENT_SECRET"), "Client Secret приложения hh.ru (OAuth2)")
+	flag.StringVar(&cfg.API.OAuth.RedirectURI, "redirect-uri", cfg.API.OAuth.RedirectURI, "Redirect URI приложения hh.ru (OAuth2)")
+	flag.StringVar(&cfg.API.OAuth.TokenURL, "token-url", cfg.API.OAuth.TokenURL, "Адрес получения OAuth2 токенов")
+	flag.StringVar(&cfg.API.OAuth.TokenFile, "token-file", cfg.API.OAuth.TokenFile, "Файл хранения OAuth2 токенов")
+	flag.StringVar(&opts.AuthCode, "auth-code", "", "Код авторизации OAuth2 для получения токенов")
+	flag.BoolVar(&opts.PrintAuthURL, "auth-url", false, "Вывести адрес страницы авторизации OAuth2")
 	flag.StringVar(&cfg.Search.City, "city", cfg.Search.City, "Город для поиска")
 	flag.StringVar(&cfg.Search.Experience, "experience", cfg.Search.Experience, "Уровень опыта")
 	flag.IntVar(&cfg.Search.UpdateDays, "update-days", cfg.Search.UpdateDays, "Дни обновления")
+	flag.BoolVar(&cfg.Search.SplitQueries, "split", cfg.Search.SplitQueries, "Разбивать запросы, превышающие лимит выдачи hh.ru")
+	flag.IntVar(&cfg.Search.SalaryFrom, "salary-from", cfg.Search.SalaryFrom, "Нижняя граница зарплаты")
+	flag.IntVar(&cfg.Search.SalaryTo, "salary-to", cfg.Search.SalaryTo, "Верхняя граница зарплаты")
+	flag.StringVar(&cfg.Search.Currency, "currency", cfg.Search.Currency, "Валюта зарплаты (RUR, USD, EUR, ...)")
+	flag.IntVar(&cfg.Search.AgeFrom, "age-from", cfg.Search.AgeFrom, "Минимальный возраст")
+	flag.IntVar(&cfg.Search.AgeTo, "age-to", cfg.Search.AgeTo, "Максимальный возраст")
+	flag.StringVar(&cfg.Search.Gender, "gender", cfg.Search.Gender, "Пол (male, female)")
+	flag.StringVar(&cfg.Search.Relocation, "relocation", cfg.Search.Relocation, "Готовность к переезду (living_or_relocation, relocation, ...)")
+	flag.StringVar(&cfg.Search.OrderBy, "order-by", cfg.Search.OrderBy, "Сортировка выдачи (publication_time, salary_desc, relevance, ...)")
+	flag.StringVar(&cfg.Search.KeywordLogic, "keyword-logic", cfg.Search.KeywordLogic, "Как объединять ключевые слова: all, any, phrase")
+	flag.StringVar(&cfg.Search.KeywordMode, "keyword-mode", cfg.Search.KeywordMode, "combined - один запрос по всем словам, each - отдельный поиск по каждому слову")
+	flag.StringVar(&cfg.Search.TextField, "text-field", cfg.Search.TextField, "Где искать ключевые слова: everywhere, title, skills, experience, ...")
+	flag.StringVar(&cfg.Search.TextPeriod, "text-period", cfg.Search.TextPeriod, "За какой период опыта искать ключевые слова: all_time, last_year, ...")
+	flag.StringVar(&cfg.Search.Mode, "mode", cfg.Search.Mode, "Что собирать: resumes, vacancies или both")
+	flag.BoolVar(&cfg.Search.FetchDetails, "details", cfg.Search.FetchDetails, "Загружать полные версии резюме и вакансий для результатов поиска")
+	flag.StringVar(&cfg.Search.HTMLDir, "html-dir", cfg.Search.HTMLDir, "Каталог сохраненных страниц резюме hh.ru (вместо API)")
+	var sources string
+	flag.StringVar(&sources, "sources", "", "Источники резюме через запятую: имя=тип:путь (типы: hh, html, json)")
+	flag.IntVar(&cfg.Search.DetailWorkers, "detail-workers", cfg.Search.DetailWorkers, "Количество параллельных загрузок полных версий")
 	flag.StringVar(&cfg.Output.Format, "format", cfg.Output.Format, "Формат вывода (json, csv, sql)")
 	flag.StringVar(&cfg.Output.File, "output", cfg.Output.File, "Файл вывода")
+	flag.StringVar(&cfg.Output.VacanciesFile, "vacancies-output", cfg.Output.VacanciesFile, "Файл вывода вакансий (по умолчанию vacancies.<формат> рядом с -output)")
+	flag.StringVar(&cfg.Output.CheckpointFile, "checkpoint", cfg.Output.CheckpointFile, "Файл контрольной точки (по умолчанию <output>.checkpoint.json)")
+	flag.BoolVar(&cfg.Output.Resume, "resume", cfg.Output.Resume, "Продолжить прерванный запуск из контрольной точки")
+	flag.BoolVar(&cfg.Output.TrackUpdates, "track-updates", cfg.Output.TrackUpdates, "Сохранять повторно резюме, обновленные с прошлого запуска, и вести историю версий")
+	flag.StringVar(&cfg.Output.HistoryFile, "history", cfg.Output.HistoryFile, "Файл истории версий резюме (по умолчанию <output>.history.jsonl)")
 	flag.StringVar(&cfg.LogFile, "log", cfg.LogFile, "Файл логов")
+	flag.BoolVar(&cfg.Contacts.Open, "contacts", cfg.Contacts.Open, "Открывать контакты при загрузке полных резюме (расходует платные просмотры)")
+	flag.IntVar(&cfg.Contacts.DailyLimit, "contacts-daily", cfg.Contacts.DailyLimit, "Бюджет открытий контактов в сутки (0 - без ограничения)")
+	flag.IntVar(&cfg.Contacts.MonthlyLimit, "contacts-monthly", cfg.Contacts.MonthlyLimit, "Бюджет открытий контактов в месяц (0 - без ограничения)")
+	flag.StringVar(&cfg.Contacts.OnExhausted, "contacts-exhausted", cfg.Contacts.OnExhausted, "Когда бюджет исчерпан: downgrade - резюме без контактов, block - не загружать полное резюме")
+	flag.StringVar(&cfg.Contacts.LedgerFile, "contacts-ledger", cfg.Contacts.LedgerFile, "Журнал открытий контактов")
+	flag.BoolVar(&cfg.Cache.HTTP, "http-cache", cfg.Cache.HTTP, "Кэшировать ответы API на диске")
+
+	cacheSizeMB := int(cfg.Cache.HTTPMaxSize >> 20)
+	flag.IntVar(&cacheSizeMB, "http-cache-size", cacheSizeMB, "Максимальный размер кэша ответов API в МБ (0 - без ограничения)")
+
+	var cacheTTLs string
+	flag.StringVar(&cacheTTLs, "http-cache-ttl", "", "Сроки свежести ответов по путям: /resumes/=6h,/vacancies/=1h")
 
 	// Парсинг ключевых слов
 	var keywords string
@@ -60,50 +142,348 @@ func parseFlags() *config.Config {
 	var keywordsFile string
 	flag.StringVar(&keywordsFile, "keywords-file", "", "Файл с ключевыми словами")
 
+	flag.BoolVar(&cfg.Skills.Normalize, "normalize-skills", cfg.Skills.Normalize, "Приводить навыки к каноническим названиям по словарю")
+	flag.BoolVar(&cfg.Skills.Builtin, "skills-builtin", cfg.Skills.Builtin, "Использовать встроенный словарь IT навыков")
+	flag.StringVar(&cfg.Skills.Dictionary, "skills-dict", cfg.Skills.Dictionary, "JSON файл словаря навыков: {\"Go\": [\"Golang\", \"go lang\"]}")
+
+	flag.BoolVar(&cfg.Skills.Extract, "extract-skills", cfg.Skills.Extract, "Искать навыки словаря в заголовке и опыте работы")
+	flag.Float64Var(&cfg.Skills.MinConfidence, "skills-min-confidence", cfg.Skills.MinConfidence, "Минимальная уверенность найденного навыка (0..1)")
+
+	flag.StringVar(&cfg.Scoring.Profile, "profile", cfg.Scoring.Profile, "JSON файл профиля вакансии для оценки резюме")
+	flag.Float64Var(&cfg.Scoring.MinScore, "min-score", cfg.Scoring.MinScore, "Пропускать резюме с оценкой ниже порога (0..100)")
+
+	var rulesFile string
+	flag.StringVar(&rulesFile, "rules", "", "JSON файл правил проверки резюме: [{\"name\": ..., \"condition\": ..., \"severity\": \"drop\"}]")
+
+	var exclude string
+	flag.StringVar(&exclude, "exclude", "", "Исключаемые слова (через запятую)")
+
+	// Фильтры со списком значений через запятую
+	var education, employment, schedule, languages, jobSearchStatus string
+	flag.StringVar(&education, "education", "", "Уровни образования через запятую (higher, bachelor, ...)")
+	flag.StringVar(&employment, "employment", "", "Типы занятости через запятую (full, part, project, ...)")
+	flag.StringVar(&schedule, "schedule", "", "Графики работы через запятую (fullDay, remote, ...)")
+	flag.StringVar(&languages, "language", "", "Знание языков через запятую в формате eng или eng.b2")
+	flag.StringVar(&jobSearchStatus, "job-search-status", "", "Статусы поиска работы через запятую (active_search, looking_for_offers, ...)")
+
 	flag.Parse()
 
 	// Загрузка ключевых слов
 	if keywordsFile != "" {
-		if keywords, err := loadKeywordsFromFile(keywordsFile); err == nil {
+		if keywords, query, err := loadKeywordsFromFile(keywordsFile); err == nil {
 			cfg.Search.Keywords = keywords
+			cfg.Search.Query = query
 		}
 	} else if keywords != "" {
-		cfg.Search.Keywords = strings.Split(keywords, ",")
-		for i, kw := range cfg.Search.Keywords {
-			cfg.Search.Keywords[i] = strings.TrimSpace(kw)
+		cfg.Search.Keywords = splitList(keywords)
+	}
+
+	if exclude != "" {
+		cfg.Search.Exclude = splitList(exclude)
+	}
+
+	lists := []struct {
+		value  string
+		target *[]string
+	}{
+		{education, &cfg.Search.EducationLevel},
+		{employment, &cfg.Search.Employment},
+		{schedule, &cfg.Search.Schedule},
+		{languages, &cfg.Search.Languages},
+		{jobSearchStatus, &cfg.Search.JobSearchStatus},
+	}
+	for _, list := range lists {
+		if list.value != "" {
+			*list.target = splitList(list.value)
+		}
+	}
+
+	if rulesFile != "" {
+		rules, err := loadValidationRules(rulesFile)
+		if err != nil {
+			log.Fatalf("Ошибка загрузки правил проверки: %v", err)
+		}
+		cfg.Validation = rules
+	}
+
+	if tokens != "" {
+		pool, err := parseTokens(tokens)
+		if err != nil {
+			log.Fatalf("Ошибка конфигурации: %v", err)
+		}
+		cfg.API.Tokens = pool
+	}
+
+	if sources != "" {
+		cfg.Search.Sources = parseSources(sources)
+	}
+
+	cfg.Cache.HTTPMaxSize = int64(cacheSizeMB) << 20
+	if cacheTTLs != "" {
+		ttls, err := parseTTLs(cacheTTLs)
+		if err != nil {
+			log.Fatalf("Ошибка конфигурации: %v", err)
+		}
+		for path, ttl := range ttls {
+			cfg.Cache.HTTPTTLs[path] = ttl
+		}
+	}
+
+	opts.Args = flag.Args()
+
+	return cfg, opts
+}
+
+// runCommand - выполнение подкоманды
+//
+//	dictionaries [фильтр...] - вывести допустимые значения фильтров поиска
+//	invite [флаги] [ID резюме...] - пригласить соискателей на вакансию
+//	contacts [месяц] - вывести открытия контактов за месяц (2006-01, по умолчанию текущий)
+//	history ID [ID...] - вывести историю версий резюме с изменениями между версиями
+//	rank [флаги] - ранжировать сохраненные резюме по профилю вакансии
+func runCommand(cfg *config.Config, args []string) error {
+	switch args[0] {
+	case "dictionaries":
+		return app.New(cfg, logger.NewConsoleWithLevel(logger.WARN)).PrintDictionaries(os.Stdout, args[1:])
+	case "invite":
+		return runInvite(cfg, args[1:])
+	case "contacts":
+		month := ""
+		if len(args) > 1 {
+			month = args[1]
+		}
+		return app.New(cfg, logger.NewConsoleWithLevel(logger.WARN)).PrintContactReport(os.Stdout, month)
+	case "history":
+		var ids []string
+		for _, arg := range args[1:] {
+			ids = append(ids, splitList(arg)...)
 		}
+		return app.New(cfg, logger.NewConsoleWithLevel(logger.WARN)).PrintResumeHistory(os.Stdout, ids)
+	case "rank":
+		return runRank(cfg, args[1:])
+	default:
+		return fmt.Errorf("неизвестная команда %q (доступны: dictionaries, invite, contacts, history, rank)", args[0])
 	}
+}
+
+// runInvite - приглашение соискателей на вакансию
+// Идентификаторы резюме перечисляются после флагов
+func runInvite(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("invite", flag.ExitOnError)
+	flags.StringVar(&cfg.Invite.VacancyID, "vacancy", cfg.Invite.VacancyID, "ID вакансии, на которую приглашаются соискатели")
+	flags.StringVar(&cfg.Invite.Message, "message", cfg.Invite.Message, "Шаблон сообщения: {{.Name}}, {{.Title}}, {{.VacancyID}}, ...")
+	flags.StringVar(&cfg.Invite.FromFile, "from", cfg.Invite.FromFile, "JSON файл вывода, из которого берутся резюме")
+	flags.StringVar(&cfg.Invite.Filter, "filter", cfg.Invite.Filter, "Правило отбора резюме из файла: \"skills=Kafka; experience>=3\"")
+	flags.StringVar(&cfg.Invite.LedgerFile, "ledger", cfg.Invite.LedgerFile, "Журнал отправленных приглашений")
+	flags.BoolVar(&cfg.Invite.DryRun, "dry-run", cfg.Invite.DryRun, "Только показать, кто будет приглашен")
+
+	var messageFile string
+	flags.StringVar(&messageFile, "message-file", "", "Файл с шаблоном сообщения")
+
+	flags.Parse(args)
+
+	if messageFile != "" {
+		content, err := os.ReadFile(messageFile)
+		if err != nil {
+			return fmt.Errorf("ошибка чтения шаблона сообщения: %w", err)
+		}
+		cfg.Invite.Message = strings.TrimSpace(string(content))
+	}
+	for _, arg := range flags.Args() {
+		cfg.Invite.ResumeIDs = append(cfg.Invite.ResumeIDs, splitList(arg)...)
+	}
+
+	appLogger, err := logger.New(cfg.LogFile)
+	if err != nil {
+		return fmt.Errorf("не удалось создать логгер: %w", err)
+	}
+	defer appLogger.Close()
 
-	return cfg
+	return app.New(cfg, appLogger).Invite(os.Stdout)
+}
+
+// runRank - ранжирование сохраненных резюме по профилю вакансии
+func runRank(cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("rank", flag.ExitOnError)
+	flags.StringVar(&cfg.Scoring.Profile, "profile", cfg.Scoring.Profile, "JSON файл профиля вакансии")
+	flags.StringVar(&cfg.Scoring.From, "from", cfg.Scoring.From, "JSON файл вывода с резюме (по умолчанию -output)")
+	flags.Float64Var(&cfg.Scoring.MinScore, "min-score", cfg.Scoring.MinScore, "Не выводить резюме с оценкой ниже порога (0..100)")
+	flags.IntVar(&cfg.Scoring.Top, "top", cfg.Scoring.Top, "Сколько лучших резюме вывести (0 - все)")
+	flags.StringVar(&cfg.Scoring.Output, "output", cfg.Scoring.Output, "Файл для ранжированных резюме (json, csv, sql)")
+	flags.Parse(args)
+
+	return app.New(cfg, logger.NewConsoleWithLevel(logger.WARN)).RankResumes(os.Stdout)
 }
 
 // validateConfig - валидация конфигурации
 func validateConfig(cfg *config.Config) error {
-	if cfg.API.Token == "" {
-		return fmt.Errorf("не указан API токен")
+	return cfg.Validate()
+}
+
+// runAuthorization - получение OAuth2 токенов по коду авторизации
+func runAuthorization(cfg *config.Config, opts *cliOptions) error {
+	if cfg.API.OAuth.ClientID == "" {
+		return fmt.Errorf("не указан Client ID приложения (-client-id)")
+	}
+
+	client, err := httpadapter.NewHTTPClient(cfg.API)
+	if err != nil {
+		return err
+	}
+
+	tokens := auth.NewOAuthTokenSource(
+		cfg.API.OAuth,
+		auth.NewFileTokenStore(cfg.API.OAuth.TokenFile),
+		client,
+		logger.NewConsole(),
+	)
+
+	if opts.PrintAuthURL {
+		fmt.Println("Откройте страницу и разрешите доступ приложению:")
+		fmt.Println(tokens.AuthCodeURL(""))
+		fmt.Println("Затем запустите парсер с флагом -auth-code=<код из адреса перенаправления>")
+		return nil
 	}
 
-	if len(cfg.Search.Keywords) == 0 {
-		return fmt.Errorf("не указаны ключевые слова для поиска")
+	if cfg.API.OAuth.ClientSecret == "" {
+		return fmt.Errorf("не указан Client Secret приложения (-client-secret)")
 	}
 
+	if _, err := tokens.Exchange(context.Background(), opts.AuthCode); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Токены сохранены в %s\n", cfg.API.OAuth.TokenFile)
 	return nil
 }
 
-// loadKeywordsFromFile - загрузка ключевых слов из файла
-func loadKeywordsFromFile(filename string) ([]string, error) {
+// splitList - разбор списка через запятую
+func splitList(value string) []string {
+	items := strings.Split(value, ",")
+	for i, item := range items {
+		items[i] = strings.TrimSpace(item)
+	}
+	return items
+}
+
+// parseTTLs - разбор сроков свежести в формате "/путь=срок,..."
+func parseTTLs(value string) (map[string]time.Duration, error) {
+	ttls := make(map[string]time.Duration)
+	for _, item := range splitList(value) {
+		path, rawTTL, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("некорректный срок HTTP кэша %q (формат: /путь=срок)", item)
+		}
+		ttl, err := time.ParseDuration(rawTTL)
+		if err != nil {
+			return nil, fmt.Errorf("некорректный срок HTTP кэша %q: %w", item, err)
+		}
+		ttls[path] = ttl
+	}
+	return ttls, nil
+}
+
+// parseTokens - разбор пула токенов в формате "[имя=]токен[:интервал[:квота]],..."
+// Пустой интервал или квота означают отсутствие собственного ограничения токена
+func parseTokens(value string) ([]config.TokenConfig, error) {
+	var tokens []config.TokenConfig
+	for _, item := range splitList(value) {
+		if item == "" {
+			continue
+		}
+
+		var token config.TokenConfig
+		if name, rest, ok := strings.Cut(item, "="); ok {
+			token.Name, item = name, rest
+		}
+
+		parts := strings.Split(item, ":")
+		if len(parts) > 3 {
+			return nil, fmt.Errorf("некорректный токен в пуле %q (формат: имя=токен:интервал:квота)", token.Name)
+		}
+		token.Token = parts[0]
+
+		if len(parts) > 1 && parts[1] != "" {
+			interval, err := time.ParseDuration(parts[1])
+			if err != nil {
+				return nil, fmt.Errorf("некорректный интервал токена %q: %w", token.Name, err)
+			}
+			token.RateLimit = interval
+		}
+		if len(parts) > 2 && parts[2] != "" {
+			quota, err := strconv.Atoi(parts[2])
+			if err != nil {
+				return nil, fmt.Errorf("некорректная квота токена %q: %w", token.Name, err)
+			}
+			token.DailyQuota = quota
+		}
+
+		tokens = append(tokens, token)
+	}
+	return tokens, nil
+}
+
+// parseSources - разбор источников резюме в формате "[имя=]тип[:путь],..."
+// Имя по умолчанию совпадает с типом; путь нужен локальным источникам
+func parseSources(value string) []config.SourceConfig {
+	var sources []config.SourceConfig
+	for _, item := range splitList(value) {
+		if item == "" {
+			continue
+		}
+
+		var source config.SourceConfig
+		if name, rest, ok := strings.Cut(item, "="); ok {
+			source.Name, item = name, rest
+		}
+		source.Type, source.Path, _ = strings.Cut(item, ":")
+
+		sources = append(sources, source)
+	}
+	return sources
+}
+
+// queryFile - JSON файл ключевых слов с дополнительными условиями запроса
+type queryFile struct {
+	Keywords []string             `json:"keywords"`
+	Query    []config.QueryClause `json:"query"`
+}
+
+// loadKeywordsFromFile - загрузка ключевых слов и условий запроса из файла
+// Файл может быть JSON массивом, JSON объектом с полями keywords и query или текстом
+func loadKeywordsFromFile(filename string) ([]string, []config.QueryClause, error) {
 	content, err := os.ReadFile(filename)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	var query queryFile
+	if err := json.Unmarshal(content, &query); err == nil {
+		return query.Keywords, query.Query, nil
 	}
 
 	var keywords []string
 	if err := parseJSONKeywords(content, &keywords); err != nil {
 		// Если не JSON, читаем как текст построчно
-		return strings.Split(strings.TrimSpace(string(content)), "\n"), nil
+		return strings.Split(strings.TrimSpace(string(content)), "\n"), nil, nil
 	}
 
-	return keywords, nil
+	return keywords, nil, nil
+}
+
+// loadValidationRules - загрузка правил проверки резюме из JSON файла
+// Правила из файла заменяют правила по умолчанию
+func loadValidationRules(filename string) ([]config.ValidationRuleConfig, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []config.ValidationRuleConfig
+	if err := json.Unmarshal(content, &rules); err != nil {
+		return nil, fmt.Errorf("некорректный формат файла %s: %w", filename, err)
+	}
+	return rules, nil
 }
 
 // parseJSONKeywords - парсинг ключевых слов из JSON
@@ -0,0 +1,141 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"hh-resume-parser/internal/domain/repositories"
+	"hh-resume-parser/internal/infrastructure/logger"
+)
+
+// FileCache реализует интерфейс CacheRepository с хранением записей в файлах
+// Каждая запись хранится в отдельном файле каталога кэша вместе со сроком годности
+type FileCache struct {
+	dir    string
+	logger logger.Logger
+	mu     sync.Mutex
+}
+
+// cacheEntry - запись кэша на диске
+type cacheEntry struct {
+	Key       string    `json:"key"`                  // Исходный ключ
+	ExpiresAt time.Time `json:"expires_at,omitempty"` // Срок годности (нулевое значение - бессрочно)
+	Value     []byte    `json:"value"`                // Данные
+}
+
+// NewFileCache создает новый файловый кэш в указанном каталоге
+func NewFileCache(dir string, logger logger.Logger) repositories.CacheRepository {
+	return &FileCache{
+		dir:    dir,
+		logger: logger,
+	}
+}
+
+// Get возвращает данные по ключу или ErrCacheMiss, если их нет или срок хранения истек
+func (c *FileCache) Get(ctx context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, err := c.readEntry(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if !entry.ExpiresAt.IsZero() && time.Now().After(entry.ExpiresAt) {
+		return nil, repositories.ErrCacheMiss
+	}
+
+	return entry.Value, nil
+}
+
+// Set сохраняет данные по ключу; ttl задается в секундах, 0 - без ограничения срока
+func (c *FileCache) Set(ctx context.Context, key string, value []byte, ttl int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return fmt.Errorf("ошибка создания каталога кэша: %w", err)
+	}
+
+	entry := cacheEntry{Key: key, Value: value}
+	if ttl > 0 {
+		entry.ExpiresAt = time.Now().Add(time.Duration(ttl) * time.Second)
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("ошибка сериализации записи кэша: %w", err)
+	}
+
+	// Запись через временный файл, чтобы не оставить поврежденную запись при сбое
+	// и не мешать другим процессам, использующим тот же каталог
+	tmp, err := os.CreateTemp(c.dir, ".entry-*")
+	if err != nil {
+		return fmt.Errorf("ошибка записи кэша: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("ошибка записи кэша: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("ошибка записи кэша: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path(key)); err != nil {
+		return fmt.Errorf("ошибка записи кэша: %w", err)
+	}
+
+	return nil
+}
+
+// Delete удаляет данные по ключу
+func (c *FileCache) Delete(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := os.Remove(c.path(key)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Exists проверяет наличие актуальных данных по ключу
+func (c *FileCache) Exists(ctx context.Context, key string) bool {
+	_, err := c.Get(ctx, key)
+	return err == nil
+}
+
+// readEntry читает запись кэша с диска
+func (c *FileCache) readEntry(key string) (*cacheEntry, error) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, repositories.ErrCacheMiss
+		}
+		return nil, err
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Key != key {
+		c.logger.Warn("Поврежденная запись кэша, игнорируем", map[string]interface{}{
+			"key": key,
+		})
+		return nil, repositories.ErrCacheMiss
+	}
+
+	return &entry, nil
+}
+
+// path возвращает путь к файлу записи по ключу
+func (c *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:16])+".json")
+}
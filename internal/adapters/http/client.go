@@ -5,10 +5,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"time"
 
 	"hh-resume-parser/internal/config"
 	"hh-resume-parser/internal/infrastructure/logger"
+	"hh-resume-parser/internal/infrastructure/ratelimit"
 )
 
 // Client представляет HTTP клиент для работы с API
@@ -16,33 +16,43 @@ type Client struct {
 	httpClient *http.Client
 	config     *config.Config
 	logger     logger.Logger
-	lastCall   time.Time
+	limiter    *ratelimit.Limiter
 }
 
 // NewClient создает новый HTTP клиент
-func NewClient(cfg *config.Config, logger logger.Logger) *Client {
+// limiter - общий ограничитель скорости; если nil, создается собственный по конфигурации
+func NewClient(cfg *config.Config, logger logger.Logger, limiter *ratelimit.Limiter) *Client {
+	httpClient, err := NewHTTPClient(cfg.API)
+	if err != nil {
+		logger.Error("Ошибка настройки транспорта, используем транспорт по умолчанию", err)
+		httpClient = &http.Client{Timeout: cfg.API.Timeout}
+	}
+
+	if limiter == nil {
+		limiter = ratelimit.New(cfg.API)
+	}
+
 	return &Client{
-		httpClient: &http.Client{
-			Timeout: cfg.API.Timeout,
-		},
-		config:   cfg,
-		logger:   logger,
-		lastCall: time.Time{},
+		httpClient: httpClient,
+		config:     cfg,
+		logger:     logger,
+		limiter:    limiter,
 	}
 }
 
 // Do выполняет HTTP запрос с учетом ограничения скорости
 func (c *Client) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
 	// Применяем ограничение скорости
-	if !c.lastCall.IsZero() {
-		elapsed := time.Since(c.lastCall)
-		if elapsed < c.config.API.RateLimit {
-			sleepTime := c.config.API.RateLimit - elapsed
-			c.logger.Debug("Применяем ограничение скорости", map[string]interface{}{
-				"sleep_duration": sleepTime.String(),
-			})
-			time.Sleep(sleepTime)
-		}
+	endpoint := ratelimit.Endpoint(req.URL.Path)
+	waited, err := c.limiter.Wait(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if waited > 0 {
+		c.logger.Debug("Применяем ограничение скорости", map[string]interface{}{
+			"endpoint":       endpoint,
+			"sleep_duration": waited.String(),
+		})
 	}
 
 	// Добавляем заголовки авторизации
@@ -51,8 +61,7 @@ func (c *Client) Do(ctx context.Context, req *http.Request) (*http.Response, err
 	req.Header.Set("Accept", "application/json")
 
 	// Выполняем запрос
-	resp, err := c.httpClient.Do(req)
-	c.lastCall = time.Now()
+	resp, err := c.httpClient.Do(req.WithContext(ctx))
 
 	if err != nil {
 		return nil, fmt.Errorf("ошибка выполнения запроса: %w", err)
@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"hh-resume-parser/internal/domain/repositories"
+	"hh-resume-parser/internal/infrastructure/logger"
+)
+
+// CheckpointStore реализует хранение контрольной точки запуска в JSON файле
+// Файл перезаписывается через временный, поэтому сбой во время записи
+// оставляет предыдущую контрольную точку целой
+type CheckpointStore struct {
+	file   string
+	logger logger.Logger
+	mu     sync.Mutex
+}
+
+// NewCheckpointStore создает хранилище контрольной точки в указанном файле
+func NewCheckpointStore(file string, logger logger.Logger) repositories.CheckpointStore {
+	return &CheckpointStore{
+		file:   file,
+		logger: logger,
+	}
+}
+
+// Load читает контрольную точку; отсутствующий файл означает, что ее нет
+func (s *CheckpointStore) Load(ctx context.Context) (*repositories.Checkpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("ошибка чтения %s: %w", s.file, err)
+	}
+
+	var checkpoint repositories.Checkpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, fmt.Errorf("некорректный JSON в %s: %w", s.file, err)
+	}
+
+	return &checkpoint, nil
+}
+
+// Save записывает контрольную точку
+func (s *CheckpointStore) Save(ctx context.Context, checkpoint *repositories.Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := writeJSONFile(s.file, checkpoint); err != nil {
+		return err
+	}
+
+	s.logger.Debug("Контрольная точка сохранена", map[string]interface{}{
+		"file":      s.file,
+		"processed": len(checkpoint.ProcessedIDs),
+	})
+	return nil
+}
+
+// Clear удаляет контрольную точку
+func (s *CheckpointStore) Clear(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Remove(s.file); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("ошибка удаления %s: %w", s.file, err)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"context"
+	"sync"
+
+	"hh-resume-parser/internal/domain/entities"
+	"hh-resume-parser/internal/domain/repositories"
+	"hh-resume-parser/internal/infrastructure/logger"
+)
+
+// ContactLedger реализует журнал открытий контактов в JSON файле
+// Открытие записывается сразу после получения контактов, поэтому расход
+// не теряется, даже если запуск будет прерван
+type ContactLedger struct {
+	file   string
+	logger logger.Logger
+	mu     sync.Mutex
+}
+
+// NewContactLedger создает журнал открытий контактов в указанном файле
+func NewContactLedger(file string, logger logger.Logger) repositories.ContactLedger {
+	return &ContactLedger{
+		file:   file,
+		logger: logger,
+	}
+}
+
+// Openings возвращает все записанные открытия контактов
+func (l *ContactLedger) Openings(ctx context.Context) ([]entities.ContactOpening, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return readJSONList[entities.ContactOpening](l.file)
+}
+
+// RecordOpening добавляет открытие контактов в журнал
+func (l *ContactLedger) RecordOpening(ctx context.Context, opening entities.ContactOpening) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	openings, err := readJSONList[entities.ContactOpening](l.file)
+	if err != nil {
+		return err
+	}
+
+	if err := writeJSONFile(l.file, append(openings, opening)); err != nil {
+		return err
+	}
+
+	l.logger.Debug("Открытие контактов записано в журнал", map[string]interface{}{
+		"resume_id": opening.ResumeID,
+		"file":      l.file,
+	})
+	return nil
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/csv"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"hh-resume-parser/internal/domain/entities"
 	"hh-resume-parser/internal/domain/repositories"
@@ -25,8 +27,134 @@ func NewCSVStorage(file string, logger logger.Logger) repositories.StorageReposi
 	}
 }
 
+// resumeHeaders - заголовки столбцов файла резюме
+// Новые столбцы добавляются только в конец, чтобы не сдвигать столбцы, которые читаются по номеру
+var resumeHeaders = []string{
+	"ID", "Name", "Title", "Skills", "Experience",
+	"Education", "Last Update", "Phone", "Email",
+	"URL", "Location", "Age", "Gender",
+	"Area ID", "Total Experience Months", "Professional Roles", "Specializations",
+	"Languages", "Citizenship", "Work Ticket", "Relocation",
+	"Employment", "Schedule", "Business Trip Readiness", "Certificates",
+	"Education Level", "About", "Matched Keywords", "Source", "Changes",
+	"Score", "Score Breakdown", "Skills Original", "Inferred Skills",
+}
+
 // SaveResumes сохраняет резюме в CSV формате
 func (s *CSVStorage) SaveResumes(ctx context.Context, resumes []entities.Resume) error {
+	return writeAllResumes(ctx, s, resumes)
+}
+
+// OpenResumes начинает постраничную запись резюме в CSV
+// Заголовки записываются сразу, строки порции - вслед за ними
+func (s *CSVStorage) OpenResumes(ctx context.Context) (repositories.ResumeBatchWriter, error) {
+	return createResumeFile(s.file, "csv", s.logger, s.writeHeader, s.writeRecords)
+}
+
+// AppendResumes продолжает запись резюме после строк, уже сохраненных в файле
+// Файл с другим набором столбцов (записанный другой версией) не дописывается
+func (s *CSVStorage) AppendResumes(ctx context.Context) (repositories.ResumeBatchWriter, error) {
+	if err := s.checkHeader(); err != nil {
+		return nil, err
+	}
+	return openResumeFile(s.file, "csv", s.logger, s.writeHeader, s.writeRecords)
+}
+
+// checkHeader - проверка, что заголовки существующего файла совпадают с resumeHeaders
+// Отсутствующий или пустой файл проверку проходит: он будет создан заново
+func (s *CSVStorage) checkHeader() error {
+	file, err := os.Open(s.file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("ошибка чтения %s: %w", s.file, err)
+	}
+	defer file.Close()
+
+	header, err := csv.NewReader(file).Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("ошибка чтения заголовков %s: %w", s.file, err)
+	}
+	if strings.Join(header, ",") != strings.Join(resumeHeaders, ",") {
+		return fmt.Errorf("%w: столбцы файла %s не совпадают с текущим форматом, дозапись невозможна - укажите новый файл вывода",
+			repositories.ErrBadArgument, s.file)
+	}
+	return nil
+}
+
+// writeHeader - запись строки заголовков
+func (s *CSVStorage) writeHeader(file *os.File) error {
+	writer := csv.NewWriter(file)
+	if err := writer.Write(resumeHeaders); err != nil {
+		return fmt.Errorf("ошибка записи заголовков: %w", err)
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// writeRecords - запись строк порции резюме
+func (s *CSVStorage) writeRecords(file *os.File, resumes []entities.Resume) error {
+	writer := csv.NewWriter(file)
+	for _, resume := range resumes {
+		if err := writer.Write(s.resumeRecord(resume)); err != nil {
+			return fmt.Errorf("ошибка записи резюме %s: %w", resume.ID, err)
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// resumeRecord - строка файла для одного резюме
+func (s *CSVStorage) resumeRecord(resume entities.Resume) []string {
+	return []string{
+		resume.ID,
+		resume.Name,
+		resume.Title,
+		s.formatSkills(resume.Skills),
+		s.formatExperience(resume.Experience),
+		s.formatEducation(resume.Education),
+		resume.LastUpdate.Format("2006-01-02 15:04:05"),
+		resume.Contact.Phone,
+		resume.Contact.Email,
+		resume.URL,
+		resume.Location,
+		fmt.Sprintf("%d", resume.Age),
+		resume.Gender,
+		resume.AreaID,
+		fmt.Sprintf("%d", resume.TotalExperience),
+		joinStrings(resume.ProfessionalRoles, "; "),
+		joinStrings(resume.Specializations, "; "),
+		formatLanguages(resume.Languages),
+		joinStrings(resume.Citizenship, "; "),
+		joinStrings(resume.WorkTicket, "; "),
+		formatRelocation(resume.Relocation),
+		joinStrings(resume.Employment, "; "),
+		joinStrings(resume.Schedule, "; "),
+		resume.BusinessTripReadiness,
+		formatCertificates(resume.Certificates),
+		resume.EducationLevel,
+		resume.About,
+		joinStrings(resume.MatchedKeywords, "; "),
+		resume.Source,
+		formatChanges(resume.Changes),
+		formatScore(resume.Score),
+		resume.Score.Breakdown(),
+		s.formatSkills(resume.SkillsOriginal),
+		formatInferredSkills(resume.InferredSkills),
+	}
+}
+
+// GetSavedResumeIDs возвращает список ID сохраненных резюме
+func (s *CSVStorage) GetSavedResumeIDs(ctx context.Context) ([]string, error) {
+	return s.readIDs()
+}
+
+// SaveVacancies сохраняет вакансии в CSV формате
+func (s *CSVStorage) SaveVacancies(ctx context.Context, vacancies []entities.Vacancy) error {
 	file, err := os.Create(s.file)
 	if err != nil {
 		return fmt.Errorf("ошибка создания файла: %w", err)
@@ -36,48 +164,74 @@ func (s *CSVStorage) SaveResumes(ctx context.Context, resumes []entities.Resume)
 	writer := csv.NewWriter(file)
 	defer writer.Flush()
 
-	// Записываем заголовки
 	headers := []string{
-		"ID", "Name", "Title", "Skills", "Experience",
-		"Education", "Last Update", "Phone", "Email",
-		"URL", "Location", "Age", "Gender",
+		"ID", "Name", "Employer", "Employer ID", "Location", "Area ID",
+		"Salary From", "Salary To", "Currency", "Gross",
+		"Experience", "Employment", "Schedule", "Key Skills", "Professional Roles",
+		"Requirement", "Responsibility", "Description", "Published At", "URL", "Archived",
+		"Matched Keywords",
 	}
 	if err := writer.Write(headers); err != nil {
 		return fmt.Errorf("ошибка записи заголовков: %w", err)
 	}
 
-	// Записываем данные
-	for _, resume := range resumes {
+	for _, vacancy := range vacancies {
+		var salaryFrom, salaryTo, currency, gross string
+		if vacancy.Salary != nil {
+			if vacancy.Salary.From > 0 {
+				salaryFrom = fmt.Sprintf("%d", vacancy.Salary.From)
+			}
+			if vacancy.Salary.To > 0 {
+				salaryTo = fmt.Sprintf("%d", vacancy.Salary.To)
+			}
+			currency = vacancy.Salary.Currency
+			gross = fmt.Sprintf("%t", vacancy.Salary.Gross)
+		}
+
 		record := []string{
-			resume.ID,
-			resume.Name,
-			resume.Title,
-			s.formatSkills(resume.Skills),
-			s.formatExperience(resume.Experience),
-			s.formatEducation(resume.Education),
-			resume.LastUpdate.Format("2006-01-02 15:04:05"),
-			resume.Contact.Phone,
-			resume.Contact.Email,
-			resume.URL,
-			resume.Location,
-			fmt.Sprintf("%d", resume.Age),
-			resume.Gender,
+			vacancy.ID,
+			vacancy.Name,
+			vacancy.Employer.Name,
+			vacancy.Employer.ID,
+			vacancy.Location,
+			vacancy.AreaID,
+			salaryFrom,
+			salaryTo,
+			currency,
+			gross,
+			vacancy.Experience,
+			vacancy.Employment,
+			vacancy.Schedule,
+			joinStrings(vacancy.KeySkills, "; "),
+			joinStrings(vacancy.ProfessionalRoles, "; "),
+			vacancy.Requirement,
+			vacancy.Responsibility,
+			vacancy.Description,
+			vacancy.PublishedAt.Format("2006-01-02 15:04:05"),
+			vacancy.URL,
+			fmt.Sprintf("%t", vacancy.Archived),
+			joinStrings(vacancy.MatchedKeywords, "; "),
 		}
 		if err := writer.Write(record); err != nil {
-			return fmt.Errorf("ошибка записи резюме %s: %w", resume.ID, err)
+			return fmt.Errorf("ошибка записи вакансии %s: %w", vacancy.ID, err)
 		}
 	}
 
-	s.logger.Info("Резюме сохранены в CSV", map[string]interface{}{
+	s.logger.Info("Вакансии сохранены в CSV", map[string]interface{}{
 		"file":  s.file,
-		"count": len(resumes),
+		"count": len(vacancies),
 	})
 
 	return nil
 }
 
-// GetSavedResumeIDs возвращает список ID сохраненных резюме
-func (s *CSVStorage) GetSavedResumeIDs(ctx context.Context) ([]string, error) {
+// GetSavedVacancyIDs возвращает список ID сохраненных вакансий
+func (s *CSVStorage) GetSavedVacancyIDs(ctx context.Context) ([]string, error) {
+	return s.readIDs()
+}
+
+// readIDs читает идентификаторы из первой колонки файла
+func (s *CSVStorage) readIDs() ([]string, error) {
 	file, err := os.Open(s.file)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -120,6 +274,9 @@ func (s *CSVStorage) formatExperience(experience []entities.Job) string {
 	for _, job := range experience {
 		part := fmt.Sprintf("%s at %s (%s - %s)",
 			job.Position, job.Company, job.StartDate, job.EndDate)
+		if job.Industry != "" {
+			part += " [" + job.Industry + "]"
+		}
 		parts = append(parts, part)
 	}
 	return joinStrings(parts, " | ")
@@ -135,6 +292,69 @@ func (s *CSVStorage) formatEducation(education []entities.Edu) string {
 	return joinStrings(parts, " | ")
 }
 
+// formatLanguages форматирует языки как "Английский (B2); Русский (Родной)"
+func formatLanguages(languages []entities.Language) string {
+	var parts []string
+	for _, lang := range languages {
+		if lang.Level != "" {
+			parts = append(parts, fmt.Sprintf("%s (%s)", lang.Name, lang.Level))
+		} else {
+			parts = append(parts, lang.Name)
+		}
+	}
+	return joinStrings(parts, "; ")
+}
+
+// formatRelocation форматирует готовность к переезду вместе с регионами
+func formatRelocation(relocation *entities.Relocation) string {
+	if relocation == nil {
+		return ""
+	}
+	if len(relocation.Areas) == 0 {
+		return relocation.Type
+	}
+	return fmt.Sprintf("%s: %s", relocation.Type, joinStrings(relocation.Areas, ", "))
+}
+
+// formatCertificates форматирует сертификаты как "Название (дата)"
+func formatCertificates(certificates []entities.Certificate) string {
+	var parts []string
+	for _, cert := range certificates {
+		if cert.AchievedAt != "" {
+			parts = append(parts, fmt.Sprintf("%s (%s)", cert.Title, cert.AchievedAt))
+		} else {
+			parts = append(parts, cert.Title)
+		}
+	}
+	return joinStrings(parts, "; ")
+}
+
+// formatChanges - отличия от прежней версии резюме: "skills: +Kubernetes; salary: 250000 RUR → 300000 RUR"
+func formatChanges(changes []entities.FieldChange) string {
+	parts := make([]string, len(changes))
+	for i, change := range changes {
+		parts[i] = change.String()
+	}
+	return joinStrings(parts, "; ")
+}
+
+// formatInferredSkills - найденные в тексте навыки: "Kafka (description, 0.70); gRPC (position, 0.80)"
+func formatInferredSkills(skills []entities.InferredSkill) string {
+	parts := make([]string, len(skills))
+	for i, skill := range skills {
+		parts[i] = skill.String()
+	}
+	return joinStrings(parts, "; ")
+}
+
+// formatScore - итоговая оценка резюме (пусто, если резюме не оценивалось)
+func formatScore(score *entities.ResumeScore) string {
+	if score == nil {
+		return ""
+	}
+	return fmt.Sprintf("%.1f", score.Total)
+}
+
 func joinStrings(items []string, sep string) string {
 	if len(items) == 0 {
 		return ""
@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"context"
+	"sync"
+
+	"hh-resume-parser/internal/domain/entities"
+	"hh-resume-parser/internal/domain/repositories"
+	"hh-resume-parser/internal/infrastructure/logger"
+)
+
+// InvitationLedger реализует журнал приглашений в JSON файле
+// Каждое приглашение записывается сразу после отправки, поэтому прерванный запуск
+// не приведет к повторному приглашению при следующем
+type InvitationLedger struct {
+	file   string
+	logger logger.Logger
+	mu     sync.Mutex
+}
+
+// NewInvitationLedger создает журнал приглашений в указанном файле
+func NewInvitationLedger(file string, logger logger.Logger) repositories.InvitationLedger {
+	return &InvitationLedger{
+		file:   file,
+		logger: logger,
+	}
+}
+
+// InvitedResumeIDs возвращает ID резюме, уже приглашенных на вакансию
+func (l *InvitationLedger) InvitedResumeIDs(ctx context.Context, vacancyID string) ([]string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	invitations, err := readJSONList[entities.Invitation](l.file)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(invitations))
+	for _, invitation := range invitations {
+		if invitation.VacancyID == vacancyID {
+			ids = append(ids, invitation.ResumeID)
+		}
+	}
+
+	return ids, nil
+}
+
+// RecordInvitation добавляет приглашение в журнал
+func (l *InvitationLedger) RecordInvitation(ctx context.Context, invitation entities.Invitation) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	invitations, err := readJSONList[entities.Invitation](l.file)
+	if err != nil {
+		return err
+	}
+
+	if err := writeJSONFile(l.file, append(invitations, invitation)); err != nil {
+		return err
+	}
+
+	l.logger.Debug("Приглашение записано в журнал", map[string]interface{}{
+		"resume_id":  invitation.ResumeID,
+		"vacancy_id": invitation.VacancyID,
+		"file":       l.file,
+	})
+	return nil
+}
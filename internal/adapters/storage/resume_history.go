@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"hh-resume-parser/internal/domain/entities"
+	"hh-resume-parser/internal/domain/repositories"
+	"hh-resume-parser/internal/infrastructure/logger"
+)
+
+// ResumeHistory реализует историю версий резюме в файле JSON Lines
+// Каждая версия дописывается в конец файла отдельной строкой, поэтому запись страницы
+// не переписывает историю целиком, а сбой во время записи не затрагивает прежние версии
+type ResumeHistory struct {
+	file   string
+	logger logger.Logger
+	mu     sync.Mutex
+}
+
+// NewResumeHistory создает историю версий резюме в указанном файле
+func NewResumeHistory(file string, logger logger.Logger) repositories.ResumeHistoryStore {
+	return &ResumeHistory{
+		file:   file,
+		logger: logger,
+	}
+}
+
+// Latest возвращает последние версии всех известных резюме
+func (h *ResumeHistory) Latest(ctx context.Context) (map[string]entities.ResumeVersion, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	histories, err := h.read()
+	if err != nil {
+		return nil, err
+	}
+
+	latest := make(map[string]entities.ResumeVersion, len(histories))
+	for id, versions := range histories {
+		latest[id] = versions[len(versions)-1]
+	}
+	return latest, nil
+}
+
+// Versions возвращает все версии резюме по порядку
+func (h *ResumeHistory) Versions(ctx context.Context, id string) ([]entities.ResumeVersion, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	histories, err := h.read()
+	if err != nil {
+		return nil, err
+	}
+	return histories[id], nil
+}
+
+// AppendVersions дописывает версии резюме в конец файла
+func (h *ResumeHistory) AppendVersions(ctx context.Context, versions []entities.ResumeVersion) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	file, err := os.OpenFile(h.file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("ошибка открытия %s: %w", h.file, err)
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	for _, version := range versions {
+		if err := encoder.Encode(version); err != nil {
+			return fmt.Errorf("ошибка записи %s: %w", h.file, err)
+		}
+	}
+	if err := file.Sync(); err != nil {
+		return fmt.Errorf("ошибка записи %s: %w", h.file, err)
+	}
+
+	h.logger.Debug("Версии резюме записаны в историю", map[string]interface{}{
+		"file":  h.file,
+		"count": len(versions),
+	})
+	return nil
+}
+
+// read читает историю версий по ID резюме; отсутствующий файл означает пустую историю
+// Запись с тем же хэшем, что и у последней версии, переносит в нее только дату обновления
+func (h *ResumeHistory) read() (map[string][]entities.ResumeVersion, error) {
+	histories := make(map[string][]entities.ResumeVersion)
+
+	file, err := os.Open(h.file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return histories, nil
+		}
+		return nil, fmt.Errorf("ошибка чтения %s: %w", h.file, err)
+	}
+	defer file.Close()
+
+	decoder := json.NewDecoder(file)
+	for {
+		var version entities.ResumeVersion
+		err := decoder.Decode(&version)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			// Последняя строка не дописана из-за сбоя, прежние версии остаются целыми
+			h.logger.Warn("Последняя запись истории версий повреждена и пропущена", map[string]interface{}{
+				"file": h.file,
+			})
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("некорректный JSON в %s: %w", h.file, err)
+		}
+
+		versions := histories[version.ID]
+		if n := len(versions); n > 0 && versions[n-1].Hash == version.Hash {
+			versions[n-1].LastUpdate = version.LastUpdate
+			continue
+		}
+		histories[version.ID] = append(versions, version)
+	}
+
+	return histories, nil
+}
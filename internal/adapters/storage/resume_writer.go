@@ -0,0 +1,141 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"hh-resume-parser/internal/domain/entities"
+	"hh-resume-parser/internal/domain/repositories"
+	"hh-resume-parser/internal/infrastructure/logger"
+)
+
+// resumeWriter - постраничная запись резюме в файл вывода
+// Формат задается функцией записи порции; после каждой порции файл сбрасывается на диск
+type resumeWriter struct {
+	file   *os.File
+	path   string
+	format string
+	logger logger.Logger
+
+	// write - запись порции резюме в формате файла
+	write func(file *os.File, resumes []entities.Resume) error
+
+	count  int  // Количество записанных резюме
+	closed bool // Файл уже закрыт
+}
+
+// createResumeFile - создание файла вывода и запись заголовка формата
+func createResumeFile(path, format string, logger logger.Logger,
+	header func(file *os.File) error, write func(file *os.File, resumes []entities.Resume) error) (*resumeWriter, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка создания файла: %w", err)
+	}
+
+	if err := header(file); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("ошибка записи заголовка %s: %w", path, err)
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("ошибка записи %s: %w", path, err)
+	}
+
+	return &resumeWriter{file: file, path: path, format: format, logger: logger, write: write}, nil
+}
+
+// openResumeFile - открытие существующего файла вывода для дозаписи
+// Запись продолжается с конца файла; отсутствующий или пустой файл создается с заголовком формата
+func openResumeFile(path, format string, logger logger.Logger,
+	header func(file *os.File) error, write func(file *os.File, resumes []entities.Resume) error) (*resumeWriter, error) {
+	info, err := os.Stat(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("ошибка чтения %s: %w", path, err)
+	}
+	if err != nil || info.Size() == 0 {
+		return createResumeFile(path, format, logger, header, write)
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка открытия файла: %w", err)
+	}
+	if _, err := file.Seek(0, io.SeekEnd); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("ошибка открытия файла: %w", err)
+	}
+
+	logger.Info("Продолжение записи в файл вывода", map[string]interface{}{
+		"format": format,
+		"file":   path,
+	})
+	return &resumeWriter{file: file, path: path, format: format, logger: logger, write: write}, nil
+}
+
+// AppendBatch - запись порции резюме и сброс файла на диск
+func (w *resumeWriter) AppendBatch(ctx context.Context, resumes []entities.Resume) error {
+	if w.closed {
+		return fmt.Errorf("файл %s уже закрыт", w.path)
+	}
+	if len(resumes) == 0 {
+		return nil
+	}
+
+	if err := w.write(w.file, resumes); err != nil {
+		return fmt.Errorf("ошибка записи %s: %w", w.path, err)
+	}
+	if err := w.file.Sync(); err != nil {
+		return fmt.Errorf("ошибка записи %s: %w", w.path, err)
+	}
+	w.count += len(resumes)
+
+	w.logger.Debug("Порция резюме записана", map[string]interface{}{
+		"file":  w.path,
+		"count": len(resumes),
+		"total": w.count,
+	})
+	return nil
+}
+
+// Commit - завершение записи
+// Файл корректен после каждой порции, поэтому завершение только фиксирует итог в логе
+func (w *resumeWriter) Commit(ctx context.Context) error {
+	if w.closed {
+		return fmt.Errorf("файл %s уже закрыт", w.path)
+	}
+
+	w.logger.Info("Резюме сохранены в файл", map[string]interface{}{
+		"format": w.format,
+		"file":   w.path,
+		"count":  w.count,
+	})
+	return nil
+}
+
+// Close - закрытие файла
+func (w *resumeWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	return w.file.Close()
+}
+
+// writeAllResumes - сохранение списка резюме целиком через постраничную запись
+func writeAllResumes(ctx context.Context, storage repositories.ResumeStreamStorage, resumes []entities.Resume) error {
+	writer, err := storage.OpenResumes(ctx)
+	if err != nil {
+		return err
+	}
+	defer writer.Close()
+
+	if err := writer.AppendBatch(ctx, resumes); err != nil {
+		return err
+	}
+	if err := writer.Commit(ctx); err != nil {
+		return err
+	}
+	return writer.Close()
+}
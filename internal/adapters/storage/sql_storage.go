@@ -28,39 +28,165 @@ func NewSQLStorage(file string, logger logger.Logger) repositories.StorageReposi
 
 // SaveResumes сохраняет резюме в SQL скрипт
 func (s *SQLStorage) SaveResumes(ctx context.Context, resumes []entities.Resume) error {
+	return writeAllResumes(ctx, s, resumes)
+}
+
+// OpenResumes начинает постраничную запись резюме в SQL скрипт
+// Схема таблиц записывается сразу, команды INSERT порции - вслед за ней
+func (s *SQLStorage) OpenResumes(ctx context.Context) (repositories.ResumeBatchWriter, error) {
+	return createResumeFile(s.file, "sql", s.logger, s.writeSchema, s.writeResumes)
+}
+
+// AppendResumes продолжает запись команд INSERT в конец уже сохраненного скрипта
+func (s *SQLStorage) AppendResumes(ctx context.Context) (repositories.ResumeBatchWriter, error) {
+	return openResumeFile(s.file, "sql", s.logger, s.writeSchema, s.writeResumes)
+}
+
+// writeResumes - запись команд INSERT для порции резюме
+func (s *SQLStorage) writeResumes(file *os.File, resumes []entities.Resume) error {
+	for _, resume := range resumes {
+		if err := s.writeResume(file, resume); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetSavedResumeIDs возвращает список ID сохраненных резюме
+func (s *SQLStorage) GetSavedResumeIDs(ctx context.Context) ([]string, error) {
+	// В случае SQL скрипта мы не можем получить список сохраненных ID
+	// без реального подключения к базе данных
+	return []string{}, nil
+}
+
+// SaveVacancies сохраняет вакансии в SQL скрипт
+func (s *SQLStorage) SaveVacancies(ctx context.Context, vacancies []entities.Vacancy) error {
 	file, err := os.Create(s.file)
 	if err != nil {
 		return fmt.Errorf("ошибка создания файла: %w", err)
 	}
 	defer file.Close()
 
-	// Записываем схему таблиц
-	if err := s.writeSchema(file); err != nil {
+	if _, err := file.WriteString(vacancySchema); err != nil {
 		return err
 	}
 
-	// Записываем данные
-	for _, resume := range resumes {
-		if err := s.writeResume(file, resume); err != nil {
+	for _, vacancy := range vacancies {
+		if err := s.writeVacancy(file, vacancy); err != nil {
 			return err
 		}
 	}
 
-	s.logger.Info("Резюме сохранены в SQL", map[string]interface{}{
+	s.logger.Info("Вакансии сохранены в SQL", map[string]interface{}{
 		"file":  s.file,
-		"count": len(resumes),
+		"count": len(vacancies),
 	})
 
 	return nil
 }
 
-// GetSavedResumeIDs возвращает список ID сохраненных резюме
-func (s *SQLStorage) GetSavedResumeIDs(ctx context.Context) ([]string, error) {
-	// В случае SQL скрипта мы не можем получить список сохраненных ID
-	// без реального подключения к базе данных
+// GetSavedVacancyIDs возвращает список ID сохраненных вакансий
+// Как и для резюме, без подключения к базе данных список недоступен
+func (s *SQLStorage) GetSavedVacancyIDs(ctx context.Context) ([]string, error) {
 	return []string{}, nil
 }
 
+// vacancySchema - SQL схема таблицы вакансий
+const vacancySchema = `
+-- Схема базы данных для вакансий
+CREATE TABLE IF NOT EXISTS vacancies (
+    id VARCHAR(255) PRIMARY KEY,
+    name VARCHAR(500),
+    employer_id VARCHAR(255),
+    employer_name VARCHAR(500),
+    employer_url VARCHAR(500),
+    location VARCHAR(255),
+    area_id VARCHAR(50),
+    salary_from INT,
+    salary_to INT,
+    salary_currency VARCHAR(10),
+    salary_gross BOOLEAN,
+    experience VARCHAR(255),
+    employment VARCHAR(255),
+    schedule VARCHAR(255),
+    key_skills TEXT,
+    professional_roles TEXT,
+    requirement TEXT,
+    responsibility TEXT,
+    description TEXT,
+    published_at TIMESTAMP,
+    url VARCHAR(500),
+    archived BOOLEAN,
+    matched_keywords TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_vacancies_employer ON vacancies(employer_id);
+CREATE INDEX IF NOT EXISTS idx_vacancies_key_skills ON vacancies USING gin (to_tsvector('russian', key_skills));
+
+`
+
+// writeVacancy записывает одну вакансию в SQL формате
+func (s *SQLStorage) writeVacancy(file *os.File, vacancy entities.Vacancy) error {
+	salaryFrom, salaryTo, currency, gross := "NULL", "NULL", "NULL", "NULL"
+	if vacancy.Salary != nil {
+		if vacancy.Salary.From > 0 {
+			salaryFrom = fmt.Sprintf("%d", vacancy.Salary.From)
+		}
+		if vacancy.Salary.To > 0 {
+			salaryTo = fmt.Sprintf("%d", vacancy.Salary.To)
+		}
+		currency = "'" + escape(vacancy.Salary.Currency) + "'"
+		gross = fmt.Sprintf("%t", vacancy.Salary.Gross)
+	}
+
+	vacancySQL := fmt.Sprintf(`
+INSERT INTO vacancies (
+    id, name, employer_id, employer_name, employer_url,
+    location, area_id, salary_from, salary_to, salary_currency, salary_gross,
+    experience, employment, schedule, key_skills, professional_roles,
+    requirement, responsibility, description, published_at, url, archived,
+    matched_keywords
+) VALUES (
+    '%s', '%s', '%s', '%s', '%s',
+    '%s', '%s', %s, %s, %s, %s,
+    '%s', '%s', '%s', '%s', '%s',
+    '%s', '%s', '%s', '%s', '%s', %t,
+    '%s'
+) ON CONFLICT (id) DO UPDATE SET
+    salary_from = EXCLUDED.salary_from,
+    salary_to = EXCLUDED.salary_to,
+    archived = EXCLUDED.archived;
+`,
+		escape(vacancy.ID),
+		escape(vacancy.Name),
+		escape(vacancy.Employer.ID),
+		escape(vacancy.Employer.Name),
+		escape(vacancy.Employer.URL),
+		escape(vacancy.Location),
+		escape(vacancy.AreaID),
+		salaryFrom,
+		salaryTo,
+		currency,
+		gross,
+		escape(vacancy.Experience),
+		escape(vacancy.Employment),
+		escape(vacancy.Schedule),
+		escape(strings.Join(vacancy.KeySkills, "; ")),
+		escape(strings.Join(vacancy.ProfessionalRoles, "; ")),
+		escape(vacancy.Requirement),
+		escape(vacancy.Responsibility),
+		escape(vacancy.Description),
+		vacancy.PublishedAt.Format(time.RFC3339),
+		escape(vacancy.URL),
+		vacancy.Archived,
+		escape(strings.Join(vacancy.MatchedKeywords, "; ")),
+	)
+
+	_, err := file.WriteString(vacancySQL)
+	return err
+}
+
 // writeSchema записывает SQL схему таблиц
 func (s *SQLStorage) writeSchema(file *os.File) error {
 	schema := `
@@ -70,6 +196,8 @@ CREATE TABLE IF NOT EXISTS resumes (
     name VARCHAR(500),
     title VARCHAR(500),
     skills TEXT,
+    skills_original TEXT,
+    inferred_skills TEXT,
     last_update TIMESTAMP,
     contact_phone VARCHAR(50),
     contact_email VARCHAR(255),
@@ -77,6 +205,24 @@ CREATE TABLE IF NOT EXISTS resumes (
     location VARCHAR(255),
     age INT,
     gender VARCHAR(50),
+    area_id VARCHAR(50),
+    total_experience_months INT,
+    professional_roles TEXT,
+    specializations TEXT,
+    citizenship TEXT,
+    work_ticket TEXT,
+    relocation_type VARCHAR(255),
+    relocation_areas TEXT,
+    employment TEXT,
+    schedule TEXT,
+    business_trip_readiness VARCHAR(255),
+    education_level VARCHAR(255),
+    about TEXT,
+    matched_keywords TEXT,
+    source VARCHAR(50),
+    changes TEXT,
+    score NUMERIC(4, 1),
+    score_breakdown TEXT,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
@@ -101,6 +247,22 @@ CREATE TABLE IF NOT EXISTS education (
     level VARCHAR(255)
 );
 
+CREATE TABLE IF NOT EXISTS languages (
+    id SERIAL PRIMARY KEY,
+    resume_id VARCHAR(255) REFERENCES resumes(id),
+    name VARCHAR(255),
+    level VARCHAR(255)
+);
+
+CREATE TABLE IF NOT EXISTS certificates (
+    id SERIAL PRIMARY KEY,
+    resume_id VARCHAR(255) REFERENCES resumes(id),
+    title VARCHAR(500),
+    type VARCHAR(50),
+    achieved_at VARCHAR(50),
+    url VARCHAR(500)
+);
+
 -- Индексы для оптимизации поиска
 CREATE INDEX IF NOT EXISTS idx_resumes_skills ON resumes USING gin (to_tsvector('russian', skills));
 CREATE INDEX IF NOT EXISTS idx_resumes_location ON resumes(location);
@@ -108,30 +270,113 @@ CREATE INDEX IF NOT EXISTS idx_experience_company ON experience(company);
 CREATE INDEX IF NOT EXISTS idx_education_institution ON education(institution);
 
 `
-	_, err := file.WriteString(schema)
+	if _, err := file.WriteString(schema); err != nil {
+		return err
+	}
+
+	// Таблица, созданная прежней версией, дополняется новыми столбцами
+	for _, column := range addedResumeColumns {
+		if _, err := fmt.Fprintf(file, "ALTER TABLE resumes ADD COLUMN IF NOT EXISTS %s;\n", column); err != nil {
+			return err
+		}
+	}
+	_, err := file.WriteString("\n")
 	return err
 }
 
+// addedResumeColumns - столбцы resumes, добавленные после первой версии схемы
+// CREATE TABLE IF NOT EXISTS не меняет существующую таблицу, и без этих столбцов INSERT завершится ошибкой
+var addedResumeColumns = []string{
+	"area_id VARCHAR(50)",
+	"total_experience_months INT",
+	"professional_roles TEXT",
+	"specializations TEXT",
+	"citizenship TEXT",
+	"work_ticket TEXT",
+	"relocation_type VARCHAR(255)",
+	"relocation_areas TEXT",
+	"employment TEXT",
+	"schedule TEXT",
+	"business_trip_readiness VARCHAR(255)",
+	"education_level VARCHAR(255)",
+	"about TEXT",
+	"matched_keywords TEXT",
+	"source VARCHAR(50)",
+	"changes TEXT",
+	"skills_original TEXT",
+	"inferred_skills TEXT",
+	"score NUMERIC(4, 1)",
+	"score_breakdown TEXT",
+}
+
 // writeResume записывает одно резюме в SQL формате
 func (s *SQLStorage) writeResume(file *os.File, resume entities.Resume) error {
+	var relocationType string
+	var relocationAreas []string
+	if resume.Relocation != nil {
+		relocationType = resume.Relocation.Type
+		relocationAreas = resume.Relocation.Areas
+	}
+
+	// Обновленное резюме заменяет прежнюю версию целиком, включая опыт, образование и сертификаты
+	if len(resume.Changes) > 0 {
+		deleteSQL := fmt.Sprintf(`
+DELETE FROM experience WHERE resume_id = '%[1]s';
+DELETE FROM education WHERE resume_id = '%[1]s';
+DELETE FROM languages WHERE resume_id = '%[1]s';
+DELETE FROM certificates WHERE resume_id = '%[1]s';
+`, escape(resume.ID))
+		if _, err := file.WriteString(deleteSQL); err != nil {
+			return err
+		}
+	}
+
 	// Основная информация о резюме
 	mainSQL := fmt.Sprintf(`
 INSERT INTO resumes (
-    id, name, title, skills, last_update,
+    id, name, title, skills, skills_original, inferred_skills, last_update,
     contact_phone, contact_email, url,
-    location, age, gender
+    location, age, gender,
+    area_id, total_experience_months, professional_roles, specializations,
+    citizenship, work_ticket, relocation_type, relocation_areas,
+    employment, schedule, business_trip_readiness, education_level, about,
+    matched_keywords, source, changes, score, score_breakdown
 ) VALUES (
-    '%s', '%s', '%s', '%s', '%s',
+    '%s', '%s', '%s', '%s', '%s', '%s', '%s',
     '%s', '%s', '%s',
-    '%s', %d, '%s'
+    '%s', %d, '%s',
+    '%s', %d, '%s', '%s',
+    '%s', '%s', '%s', '%s',
+    '%s', '%s', '%s', '%s', '%s',
+    '%s', '%s', '%s', %s, '%s'
 ) ON CONFLICT (id) DO UPDATE SET
+    name = EXCLUDED.name,
+    title = EXCLUDED.title,
+    skills = EXCLUDED.skills,
+    skills_original = EXCLUDED.skills_original,
+    inferred_skills = EXCLUDED.inferred_skills,
     last_update = EXCLUDED.last_update,
-    skills = EXCLUDED.skills;
+    location = EXCLUDED.location,
+    age = EXCLUDED.age,
+    total_experience_months = EXCLUDED.total_experience_months,
+    professional_roles = EXCLUDED.professional_roles,
+    specializations = EXCLUDED.specializations,
+    relocation_type = EXCLUDED.relocation_type,
+    relocation_areas = EXCLUDED.relocation_areas,
+    employment = EXCLUDED.employment,
+    schedule = EXCLUDED.schedule,
+    education_level = EXCLUDED.education_level,
+    about = EXCLUDED.about,
+    changes = EXCLUDED.changes,
+    score = EXCLUDED.score,
+    score_breakdown = EXCLUDED.score_breakdown;
 `,
 		escape(resume.ID),
 		escape(resume.Name),
 		escape(resume.Title),
 		escape(strings.Join(resume.Skills, "; ")),
+		escape(strings.Join(resume.SkillsOriginal, "; ")),
+		escape(formatInferredSkills(resume.InferredSkills)),
 		resume.LastUpdate.Format(time.RFC3339),
 		escape(resume.Contact.Phone),
 		escape(resume.Contact.Email),
@@ -139,6 +384,24 @@ INSERT INTO resumes (
 		escape(resume.Location),
 		resume.Age,
 		escape(resume.Gender),
+		escape(resume.AreaID),
+		resume.TotalExperience,
+		escape(strings.Join(resume.ProfessionalRoles, "; ")),
+		escape(strings.Join(resume.Specializations, "; ")),
+		escape(strings.Join(resume.Citizenship, "; ")),
+		escape(strings.Join(resume.WorkTicket, "; ")),
+		escape(relocationType),
+		escape(strings.Join(relocationAreas, "; ")),
+		escape(strings.Join(resume.Employment, "; ")),
+		escape(strings.Join(resume.Schedule, "; ")),
+		escape(resume.BusinessTripReadiness),
+		escape(resume.EducationLevel),
+		escape(resume.About),
+		escape(strings.Join(resume.MatchedKeywords, "; ")),
+		escape(resume.Source),
+		escape(formatChanges(resume.Changes)),
+		sqlScore(resume.Score),
+		escape(resume.Score.Breakdown()),
 	)
 
 	if _, err := file.WriteString(mainSQL); err != nil {
@@ -194,9 +457,49 @@ INSERT INTO education (
 		}
 	}
 
+	// Знание языков
+	for _, lang := range resume.Languages {
+		langSQL := fmt.Sprintf(`
+INSERT INTO languages (resume_id, name, level) VALUES ('%s', '%s', '%s');
+`,
+			escape(resume.ID),
+			escape(lang.Name),
+			escape(lang.Level),
+		)
+
+		if _, err := file.WriteString(langSQL); err != nil {
+			return err
+		}
+	}
+
+	// Сертификаты
+	for _, cert := range resume.Certificates {
+		certSQL := fmt.Sprintf(`
+INSERT INTO certificates (resume_id, title, type, achieved_at, url) VALUES ('%s', '%s', '%s', '%s', '%s');
+`,
+			escape(resume.ID),
+			escape(cert.Title),
+			escape(cert.Type),
+			escape(cert.AchievedAt),
+			escape(cert.URL),
+		)
+
+		if _, err := file.WriteString(certSQL); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// sqlScore - итоговая оценка резюме для SQL (NULL, если резюме не оценивалось)
+func sqlScore(score *entities.ResumeScore) string {
+	if score == nil {
+		return "NULL"
+	}
+	return fmt.Sprintf("%.1f", score.Total)
+}
+
 // escape экранирует специальные символы в SQL строках
 func escape(s string) string {
 	return strings.ReplaceAll(s, "'", "''")
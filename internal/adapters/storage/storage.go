@@ -1,8 +1,10 @@
 package storage
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"os"
 
 	"hh-resume-parser/internal/domain/entities"
@@ -28,10 +30,120 @@ func NewFileStorage(format, file string, logger logger.Logger) repositories.Stor
 
 // SaveResumes сохраняет резюме в файл в указанном формате
 func (s *FileStorage) SaveResumes(ctx context.Context, resumes []entities.Resume) error {
-	s.logger.Info("Сохранение резюме в файл", map[string]interface{}{
+	return writeAllResumes(ctx, s, resumes)
+}
+
+// OpenResumes начинает постраничную запись резюме в JSON массив
+// Массив закрывается после каждой порции, поэтому при прерывании файл остается корректным JSON
+func (s *FileStorage) OpenResumes(ctx context.Context) (repositories.ResumeBatchWriter, error) {
+	return createResumeFile(s.file, s.format, s.logger, s.writeArrayHeader, s.arrayWriter(1, 0))
+}
+
+// AppendResumes продолжает запись резюме в уже сохраненный JSON массив
+// Новые записи добавляются перед закрывающей скобкой, как если бы запуск не прерывался
+func (s *FileStorage) AppendResumes(ctx context.Context) (repositories.ResumeBatchWriter, error) {
+	data, err := os.ReadFile(s.file)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("ошибка чтения %s: %w", s.file, err)
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return s.OpenResumes(ctx)
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("некорректный JSON в %s: %w", s.file, err)
+	}
+
+	// Запись продолжается с конца последнего элемента, закрывающая скобка переносится в конец
+	end := bytes.LastIndexByte(data, ']')
+	for end > 1 && isJSONSpace(data[end-1]) {
+		end--
+	}
+
+	return openResumeFile(s.file, s.format, s.logger, s.writeArrayHeader, s.arrayWriter(int64(end), len(items)))
+}
+
+// writeArrayHeader записывает пустой JSON массив
+func (s *FileStorage) writeArrayHeader(file *os.File) error {
+	_, err := file.WriteString("[]\n")
+	return err
+}
+
+// arrayWriter возвращает функцию записи порции резюме в JSON массив
+// end - позиция закрывающей скобки массива, written - количество элементов в массиве
+func (s *FileStorage) arrayWriter(end int64, written int) func(file *os.File, resumes []entities.Resume) error {
+	return func(file *os.File, resumes []entities.Resume) error {
+		var buf bytes.Buffer
+		for _, resume := range resumes {
+			data, err := json.MarshalIndent(resume, "  ", "  ")
+			if err != nil {
+				return fmt.Errorf("ошибка сериализации резюме %s: %w", resume.ID, err)
+			}
+			if written > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString("\n  ")
+			buf.Write(data)
+			written++
+		}
+		items := int64(buf.Len())
+
+		// Новые записи пишутся поверх закрывающей скобки, которая переносится в конец
+		buf.WriteString("\n]\n")
+		if _, err := file.WriteAt(buf.Bytes(), end); err != nil {
+			return err
+		}
+		end += items
+		return nil
+	}
+}
+
+// isJSONSpace проверяет, является ли байт пробельным символом JSON
+func isJSONSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\r' || c == '\t'
+}
+
+// GetSavedResumeIDs возвращает список ID сохраненных резюме
+func (s *FileStorage) GetSavedResumeIDs(ctx context.Context) ([]string, error) {
+	resumes, err := s.LoadResumes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(resumes))
+	for i, resume := range resumes {
+		ids[i] = resume.ID
+	}
+
+	return ids, nil
+}
+
+// LoadResumes читает сохраненные резюме; отсутствующий файл означает пустой список
+func (s *FileStorage) LoadResumes(ctx context.Context) ([]entities.Resume, error) {
+	file, err := os.Open(s.file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []entities.Resume{}, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	var resumes []entities.Resume
+	if err := json.NewDecoder(file).Decode(&resumes); err != nil {
+		return nil, err
+	}
+
+	return resumes, nil
+}
+
+// SaveVacancies сохраняет вакансии в файл в формате JSON
+func (s *FileStorage) SaveVacancies(ctx context.Context, vacancies []entities.Vacancy) error {
+	s.logger.Info("Сохранение вакансий в файл", map[string]interface{}{
 		"format": s.format,
 		"file":   s.file,
-		"count":  len(resumes),
+		"count":  len(vacancies),
 	})
 
 	file, err := os.Create(s.file)
@@ -42,11 +154,11 @@ func (s *FileStorage) SaveResumes(ctx context.Context, resumes []entities.Resume
 
 	encoder := json.NewEncoder(file)
 	encoder.SetIndent("", "  ")
-	return encoder.Encode(resumes)
+	return encoder.Encode(vacancies)
 }
 
-// GetSavedResumeIDs возвращает список ID сохраненных резюме
-func (s *FileStorage) GetSavedResumeIDs(ctx context.Context) ([]string, error) {
+// GetSavedVacancyIDs возвращает список ID сохраненных вакансий
+func (s *FileStorage) GetSavedVacancyIDs(ctx context.Context) ([]string, error) {
 	file, err := os.Open(s.file)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -56,14 +168,14 @@ func (s *FileStorage) GetSavedResumeIDs(ctx context.Context) ([]string, error) {
 	}
 	defer file.Close()
 
-	var resumes []entities.Resume
-	if err := json.NewDecoder(file).Decode(&resumes); err != nil {
+	var vacancies []entities.Vacancy
+	if err := json.NewDecoder(file).Decode(&vacancies); err != nil {
 		return nil, err
 	}
 
-	ids := make([]string, len(resumes))
-	for i, resume := range resumes {
-		ids[i] = resume.ID
+	ids := make([]string, len(vacancies))
+	for i, vacancy := range vacancies {
+		ids[i] = vacancy.ID
 	}
 
 	return ids, nil
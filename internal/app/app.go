@@ -2,51 +2,293 @@ package app
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
+	"hh-resume-parser/internal/adapters/cache"
 	"hh-resume-parser/internal/adapters/storage"
 	"hh-resume-parser/internal/config"
+	"hh-resume-parser/internal/domain/entities"
 	"hh-resume-parser/internal/domain/repositories"
 	"hh-resume-parser/internal/domain/usecases"
+	"hh-resume-parser/internal/infrastructure/auth"
+	"hh-resume-parser/internal/infrastructure/httpcache"
 	"hh-resume-parser/internal/infrastructure/logger"
+	"hh-resume-parser/internal/infrastructure/ratelimit"
 	hhrepo "hh-resume-parser/internal/infrastructure/repositories"
 )
 
 // Application представляет основное приложение
 type Application struct {
-	config     *config.Config
-	logger     logger.Logger
-	useCase    *usecases.ResumeUseCase
-	repository repositories.ResumeRepository
-	storage    repositories.StorageRepository
+	config         *config.Config
+	logger         logger.Logger
+	useCase        *usecases.ResumeUseCase
+	vacancyUseCase *usecases.VacancyUseCase // nil, если вакансии не собираются
+	repository     repositories.ResumeRepository
+	storage        repositories.StorageRepository
+	limiters       []sourceLimiter         // Ограничители скорости источников
+	configErr      error                   // Ошибка создания источников или правил проверки, возвращается из Run
+	tokens         *auth.TokenPool         // nil, если пул токенов не задан
+	httpCache      *httpcache.Cache        // nil, если кэш HTTP ответов отключен
+	contacts       *usecases.ContactBudget // nil, если контакты не открываются
+	scorer         *usecases.ResumeScorer  // nil, если профиль вакансии не задан
+	scorerErr      error                   // Ошибка загрузки профиля вакансии
+}
+
+// sourceLimiter - ограничитель скорости источника для статистики запросов
+type sourceLimiter struct {
+	source  string
+	api     bool // Источник - API hh.ru, статистика выводится всегда
+	limiter *ratelimit.Limiter
 }
 
 // New создает новый экземпляр приложения
 func New(cfg *config.Config, logger logger.Logger) *Application {
-	// Создаем репозиторий для работы с API
-	repository := hhrepo.NewHHRepository(cfg, logger)
+	// Справочники API кэшируются на диске
+	fileCache := cache.NewFileCache(cfg.Cache.Dir, logger)
+	var repoOpts []hhrepo.Option
 
-	// Выбираем подходящий адаптер хранилища на основе конфигурации
-	var fileStorage repositories.StorageRepository
-	switch cfg.Output.Format {
-	case "csv":
-		fileStorage = storage.NewCSVStorage(cfg.Output.File, logger)
-	case "sql":
-		fileStorage = storage.NewSQLStorage(cfg.Output.File, logger)
-	default: // json по умолчанию
-		fileStorage = storage.NewFileStorage(cfg.Output.Format, cfg.Output.File, logger)
+	// Запросы распределяются между токенами нескольких аккаунтов
+	var tokens *auth.TokenPool
+	if len(cfg.API.Tokens) > 0 {
+		tokens = auth.NewStaticTokenPool(cfg.API.Tokens, logger)
+		repoOpts = append(repoOpts, hhrepo.WithTokenPool(tokens))
 	}
 
+	// Ответы API кэшируются на диске между запусками
+	var httpCache *httpcache.Cache
+	if cfg.Cache.HTTP {
+		httpCache = httpcache.New(cfg.Cache.HTTPDir(), cfg.Cache.HTTPMaxSize, cfg.Cache.HTTPTTLs, logger)
+		repoOpts = append(repoOpts, hhrepo.WithHTTPCache(httpCache))
+	}
+
+	// Источники резюме создаются зарегистрированными фабриками, у каждого свой ограничитель скорости.
+	// Справочники, регионы и вакансии берутся из первого источника API hh.ru
+	var repository repositories.ResumeRepository
+	var sources []usecases.Source
+	var limiters []sourceLimiter
+	var configErr error
+	for _, source := range cfg.Search.ResumeSources() {
+		limiter := ratelimit.New(sourceLimits(cfg.API, source))
+		repo, err := newSource(source, SourceDeps{
+			Config:    cfg,
+			Logger:    logger,
+			Cache:     fileCache,
+			Limiter:   limiter,
+			HHOptions: repoOpts,
+		})
+		if err != nil {
+			configErr = err
+			break
+		}
+
+		api := source.Type == config.SourceHH
+		sources = append(sources, usecases.Source{
+			Name:         source.Name,
+			Repository:   repo,
+			PaidContacts: api,
+		})
+		limiters = append(limiters, sourceLimiter{source: source.Name, api: api, limiter: limiter})
+		if api && repository == nil {
+			repository = repo
+		}
+	}
+	usesAPI := repository != nil
+	if repository == nil && len(sources) > 0 {
+		repository = sources[0].Repository
+	}
+
+	// Выбираем подходящий адаптер хранилища на основе конфигурации
+	fileStorage := newStorage(cfg.Output.Format, cfg.Output.File, logger)
+
 	// Создаем основной use case
-	useCase := usecases.NewResumeUseCase(repository, fileStorage, nil, logger)
+	var opts []usecases.Option
+	if cfg.Search.FetchDetails {
+		opts = append(opts, usecases.WithDetailWorkers(cfg.Search.DetailWorkers))
+	}
+	if cfg.Search.KeywordMode == config.KeywordModeEach {
+		opts = append(opts, usecases.WithPerKeywordSearch())
+	}
+
+	// Открытия контактов в API учитываются в журнале и ограничиваются бюджетом;
+	// контакты локальных источников уже открыты и бюджет не расходуют
+	resumeOpts := append([]usecases.Option(nil), opts...)
+	resumeOpts = append(resumeOpts, usecases.WithSources(sources...))
+
+	// Обновленные с прошлого запуска резюме сохраняются повторно, прежние версии остаются в истории
+	if cfg.Output.TrackUpdates {
+		resumeOpts = append(resumeOpts, usecases.WithResumeHistory(
+			storage.NewResumeHistory(cfg.Output.HistoryPath(), logger)))
+	}
+
+	// Навыки приводятся к каноническим названиям словаря и ищутся в опыте работы;
+	// по словарю же сравниваются навыки профиля вакансии
+	var dict *usecases.SkillDictionary
+	if cfg.Skills.UsesDictionary() || cfg.Scoring.Profile != "" {
+		var err error
+		if dict, err = skillDictionary(cfg.Skills, logger); err != nil && configErr == nil {
+			configErr = err
+		}
+	}
+	if dict != nil && cfg.Skills.Normalize {
+		resumeOpts = append(resumeOpts, usecases.WithSkillDictionary(dict))
+	}
+	if dict != nil && cfg.Skills.Extract {
+		resumeOpts = append(resumeOpts, usecases.WithSkillExtractor(
+			usecases.NewSkillExtractor(dict, cfg.Skills.MinConfidence)))
+	}
+
+	// Резюме оцениваются по профилю вакансии, оценка сохраняется вместе с резюме
+	var scorer *usecases.ResumeScorer
+	var scorerErr error
+	if cfg.Scoring.Profile != "" {
+		if scorer, scorerErr = resumeScorer(cfg.Scoring.Profile, dict); scorerErr != nil && configErr == nil {
+			configErr = scorerErr
+		}
+		if scorer != nil {
+			resumeOpts = append(resumeOpts, usecases.WithResumeScorer(scorer, cfg.Scoring.MinScore))
+		}
+	}
+
+	// Резюме проверяются перед сохранением правилами из конфигурации
+	rules, err := validationRules(cfg.Validation)
+	if err != nil && configErr == nil {
+		configErr = err
+	}
+	resumeOpts = append(resumeOpts, usecases.WithValidationRules(rules...))
+
+	// Прогресс сбора резюме сохраняется после каждой страницы для продолжения прерванного запуска
+	resumeOpts = append(resumeOpts, usecases.WithCheckpoint(
+		storage.NewCheckpointStore(cfg.Output.CheckpointPath(), logger), cfg.Output.Resume))
+	var contacts *usecases.ContactBudget
+	switch {
+	case !cfg.Contacts.Open:
+		resumeOpts = append(resumeOpts, usecases.WithoutContacts())
+	case usesAPI:
+		contacts = usecases.NewContactBudget(
+			storage.NewContactLedger(cfg.Contacts.LedgerFile, logger),
+			usecases.ContactLimits{
+				Daily:       cfg.Contacts.DailyLimit,
+				Monthly:     cfg.Contacts.MonthlyLimit,
+				OnExhausted: cfg.Contacts.OnExhausted,
+			},
+			logger,
+		)
+		resumeOpts = append(resumeOpts, usecases.WithContactBudget(contacts))
+	}
+	useCase := usecases.NewResumeUseCase(repository, fileStorage, nil, logger, resumeOpts...)
+
+	// Вакансии собираются тем же репозиторием и сохраняются в отдельный файл того же формата
+	var vacancyUseCase *usecases.VacancyUseCase
+	if cfg.Search.CollectVacancies() {
+		vacancyRepo, repoOK := repository.(repositories.VacancyRepository)
+		vacancyStorage, storageOK := newStorage(cfg.Output.Format, cfg.Output.VacanciesOutputFile(), logger).(repositories.VacancyStorageRepository)
+		if repoOK && storageOK {
+			vacancyUseCase = usecases.NewVacancyUseCase(vacancyRepo, vacancyStorage, logger, opts...)
+		}
+	}
 
 	return &Application{
-		config:     cfg,
-		logger:     logger,
-		useCase:    useCase,
-		repository: repository,
-		storage:    fileStorage,
+		config:         cfg,
+		logger:         logger,
+		useCase:        useCase,
+		vacancyUseCase: vacancyUseCase,
+		repository:     repository,
+		storage:        fileStorage,
+		limiters:       limiters,
+		configErr:      configErr,
+		tokens:         tokens,
+		httpCache:      httpCache,
+		contacts:       contacts,
+		scorer:         scorer,
+		scorerErr:      scorerErr,
+	}
+}
+
+// validationRules - правила проверки резюме из конфигурации
+func validationRules(configs []config.ValidationRuleConfig) ([]usecases.ValidationRule, error) {
+	rules := make([]usecases.ValidationRule, 0, len(configs))
+	for _, rule := range configs {
+		validationRule, err := usecases.NewValidationRule(rule.Name, rule.Condition, rule.Severity)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, validationRule)
+	}
+	return rules, nil
+}
+
+// skillDictionary - словарь навыков: встроенный и дополнения из файла
+func skillDictionary(cfg config.SkillsConfig, logger logger.Logger) (*usecases.SkillDictionary, error) {
+	var sets []map[string][]string
+	if cfg.Builtin {
+		sets = append(sets, usecases.DefaultSkillAliases())
+	}
+
+	if cfg.Dictionary != "" {
+		custom, err := loadSkillAliases(cfg.Dictionary)
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, custom)
+	}
+
+	dict := usecases.NewSkillDictionary(sets...)
+	logger.Debug("Загружен словарь навыков", map[string]interface{}{
+		"skills": dict.Len(),
+		"file":   cfg.Dictionary,
+	})
+	return dict, nil
+}
+
+// loadSkillAliases - словарь навыков из JSON файла: каноническое название -> синонимы
+func loadSkillAliases(filename string) (map[string][]string, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения словаря навыков: %w", err)
+	}
+
+	var aliases map[string][]string
+	if err := json.Unmarshal(content, &aliases); err != nil {
+		return nil, fmt.Errorf("некорректный формат словаря навыков %s: %w", filename, err)
+	}
+	return aliases, nil
+}
+
+// resumeScorer - оценка резюме по профилю вакансии из JSON файла
+func resumeScorer(filename string, dict *usecases.SkillDictionary) (*usecases.ResumeScorer, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения профиля вакансии: %w", err)
+	}
+
+	var profile usecases.HiringProfile
+	if err := json.Unmarshal(content, &profile); err != nil {
+		return nil, fmt.Errorf("некорректный формат профиля вакансии %s: %w", filename, err)
+	}
+	if profile.Name == "" {
+		profile.Name = strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+	}
+	return usecases.NewResumeScorer(profile, dict)
+}
+
+// newStorage - адаптер хранилища для указанного формата
+func newStorage(format, file string, logger logger.Logger) repositories.StorageRepository {
+	switch format {
+	case "csv":
+		return storage.NewCSVStorage(file, logger)
+	case "sql":
+		return storage.NewSQLStorage(file, logger)
+	default: // json по умолчанию
+		return storage.NewFileStorage(format, file, logger)
 	}
 }
 
@@ -54,40 +296,578 @@ func New(cfg *config.Config, logger logger.Logger) *Application {
 func (a *Application) Run() error {
 	ctx := context.Background()
 
+	if err := a.config.Validate(); err != nil {
+		return fmt.Errorf("ошибка конфигурации: %w", err)
+	}
+	if a.configErr != nil {
+		return fmt.Errorf("ошибка конфигурации: %w", a.configErr)
+	}
+
 	// Создаем критерии поиска из конфигурации
 	criteria := repositories.SearchCriteria{
-		Keywords:   a.config.Search.Keywords,
-		City:       a.config.Search.City,
-		Experience: a.config.Search.Experience,
-		UpdateDays: a.config.Search.UpdateDays,
-		PerPage:    20,
+		Keywords:     a.config.Search.Keywords,
+		City:         a.config.Search.City,
+		Experience:   a.config.Search.Experience,
+		UpdateDays:   a.config.Search.UpdateDays,
+		PerPage:      repositories.DefaultPerPage,
+		KeywordLogic: repositories.TextLogic(a.config.Search.KeywordLogic),
+		TextField:    a.config.Search.TextField,
+		TextPeriod:   a.config.Search.TextPeriod,
+		Query:        a.queryClauses(),
+		Filters:      a.filters(),
+	}
+
+	// Значения фильтров проверяются по справочникам hh.ru до начала поиска
+	if err := a.validateFilters(ctx); err != nil {
+		return fmt.Errorf("ошибка конфигурации: %w", err)
 	}
 
-	a.logger.Info("Запуск парсинга резюме", map[string]interface{}{
+	// Города и регионы преобразуются в идентификаторы до начала поиска,
+	// неизвестный город считается ошибкой конфигурации
+	if resolver, ok := a.repository.(repositories.AreaResolver); ok && criteria.City != "" {
+		areaIDs, err := resolver.ResolveAreas(ctx, hhrepo.SplitAreaList(criteria.City))
+		if err != nil {
+			return fmt.Errorf("ошибка конфигурации: %w", err)
+		}
+		criteria.AreaIDs = areaIDs
+	}
+
+	a.logger.Info("Запуск парсинга", map[string]interface{}{
+		"mode":        a.config.Search.Mode,
 		"keywords":    criteria.Keywords,
+		"query":       len(criteria.Query),
 		"city":        criteria.City,
+		"areas":       criteria.AreaIDs,
 		"experience":  criteria.Experience,
 		"update_days": criteria.UpdateDays,
 		"format":      a.config.Output.Format,
 		"output":      a.config.Output.File,
 	})
 
-	// Запускаем процесс парсинга
-	startTime := time.Now()
+	if a.config.Search.CollectResumes() {
+		startTime := time.Now()
 
-	result, err := a.useCase.ParseResumesByCriteria(ctx, criteria)
-	if err != nil {
-		return fmt.Errorf("ошибка парсинга: %w", err)
+		result, err := a.useCase.ParseResumesByCriteria(ctx, criteria)
+		if err != nil {
+			if _, statErr := os.Stat(a.config.Output.CheckpointPath()); statErr == nil {
+				a.logger.Warn("Запуск можно продолжить с флагом -resume", map[string]interface{}{
+					"checkpoint": a.config.Output.CheckpointPath(),
+				})
+			}
+			return fmt.Errorf("ошибка парсинга: %w", err)
+		}
+
+		a.logResult("Парсинг резюме завершен", result, startTime)
+	}
+
+	if a.config.Search.CollectVacancies() {
+		if a.vacancyUseCase == nil {
+			return fmt.Errorf("источник или формат вывода не поддерживает сбор вакансий")
+		}
+
+		startTime := time.Now()
+
+		result, err := a.vacancyUseCase.ParseVacanciesByCriteria(ctx, criteria)
+		if err != nil {
+			return fmt.Errorf("ошибка сбора вакансий: %w", err)
+		}
+
+		a.logResult("Сбор вакансий завершен", result, startTime)
+	}
+
+	for _, source := range a.limiters {
+		stats := source.limiter.Stats()
+		if !source.api && stats.Requests == 0 {
+			continue
+		}
+		a.logger.Info("Статистика запросов к API", map[string]interface{}{
+			"source":      source.source,
+			"requests":    stats.Requests,
+			"waits":       stats.Waits,
+			"refunded":    stats.Refunded,
+			"total_wait":  stats.TotalWait.String(),
+			"max_wait":    stats.MaxWait.String(),
+			"daily_used":  stats.DailyUsed,
+			"daily_quota": stats.DailyQuota,
+			"by_endpoint": stats.ByEndpoint,
+		})
+	}
+
+	if a.tokens != nil {
+		for _, token := range a.tokens.Stats() {
+			a.logger.Info("Статистика токена", map[string]interface{}{
+				"token":       token.Name,
+				"status":      token.Status,
+				"reason":      token.Reason,
+				"requests":    token.Requests,
+				"daily_used":  token.DailyUsed,
+				"daily_quota": token.DailyQuota,
+			})
+		}
 	}
 
-	// Логируем результаты
-	a.logger.Info("Парсинг завершен", map[string]interface{}{
+	if a.contacts != nil && a.config.Search.FetchDetails && a.config.Search.CollectResumes() {
+		if usage, err := a.contacts.Usage(ctx); err == nil {
+			a.logger.Info("Расход открытий контактов", map[string]interface{}{
+				"today":         usage.Today,
+				"month":         usage.Month,
+				"daily_limit":   usage.DailyLimit,
+				"monthly_limit": usage.MonthlyLimit,
+				"blocked":       usage.Blocked,
+				"downgraded":    usage.Downgraded,
+			})
+		}
+	}
+
+	if a.httpCache != nil {
+		cacheStats := a.httpCache.Stats()
+		a.logger.Info("Статистика HTTP кэша", map[string]interface{}{
+			"hits":        cacheStats.Hits,
+			"revalidated": cacheStats.Revalidated,
+			"misses":      cacheStats.Misses,
+			"stored":      cacheStats.Stored,
+			"evicted":     cacheStats.Evicted,
+			"size_bytes":  cacheStats.Size,
+		})
+	}
+
+	return nil
+}
+
+// logResult - запись итогов сбора в лог
+func (a *Application) logResult(msg string, result *usecases.ParseResult, startTime time.Time) {
+	a.logger.Info(msg, map[string]interface{}{
 		"total_found":  result.TotalFound,
 		"saved":        result.SavedCount,
 		"skipped":      result.SkippedCount,
+		"updated":      result.UpdatedCount,
 		"errors":       len(result.Errors),
+		"available":    result.TotalAvailable,
+		"slices":       result.SliceCount,
+		"details":      result.DetailsFetched,
+		"coverage":     fmt.Sprintf("%.1f%%", result.Coverage()*100),
 		"elapsed_time": time.Since(startTime).String(),
 	})
 
+	if len(result.SkippedByRule) > 0 || len(result.WarningsByRule) > 0 {
+		a.logger.Info("Итоги проверки резюме", map[string]interface{}{
+			"dropped":  result.SkippedByRule,
+			"warnings": result.WarningsByRule,
+		})
+	}
+
+	// Итоги по источникам выводятся, только если их несколько
+	if len(result.Sources) < 2 {
+		return
+	}
+	for _, source := range result.Sources {
+		fields := map[string]interface{}{
+			"source":     source.Name,
+			"found":      source.Found,
+			"saved":      source.Saved,
+			"skipped":    source.Skipped,
+			"duplicates": source.Duplicates,
+			"errors":     len(source.Errors),
+		}
+		if source.Aborted != nil {
+			fields["aborted"] = source.Aborted.Error()
+		}
+		a.logger.Info("Итоги источника", fields)
+	}
+}
+
+// queryClauses - дополнительные условия текстового запроса из конфигурации
+// Исключаемые слова добавляются отдельным условием
+func (a *Application) queryClauses() []repositories.TextClause {
+	var clauses []repositories.TextClause
+	for _, clause := range a.config.Search.Query {
+		clauses = append(clauses, repositories.TextClause{
+			Terms:  clause.Terms,
+			Logic:  repositories.TextLogic(clause.Logic),
+			Field:  clause.Field,
+			Period: clause.Period,
+		})
+	}
+
+	if len(a.config.Search.Exclude) > 0 {
+		clauses = append(clauses, repositories.TextClause{
+			Terms: a.config.Search.Exclude,
+			Logic: repositories.LogicExcept,
+		})
+	}
+
+	return clauses
+}
+
+// filters - фильтры поиска из конфигурации
+func (a *Application) filters() repositories.SearchFilters {
+	search := a.config.Search
+	filters := repositories.SearchFilters{
+		SalaryFrom:      search.SalaryFrom,
+		SalaryTo:        search.SalaryTo,
+		Currency:        search.Currency,
+		AgeFrom:         search.AgeFrom,
+		AgeTo:           search.AgeTo,
+		Gender:          search.Gender,
+		EducationLevels: search.EducationLevel,
+		Employment:      search.Employment,
+		Schedule:        search.Schedule,
+		Relocation:      search.Relocation,
+		JobSearchStatus: search.JobSearchStatus,
+		OrderBy:         search.OrderBy,
+	}
+
+	// Формат проверен при валидации конфигурации
+	for _, value := range search.Languages {
+		if language, err := repositories.ParseLanguageFilter(value); err == nil {
+			filters.Languages = append(filters.Languages, language)
+		}
+	}
+
+	return filters
+}
+
+// searchFilters - значения фильтров поиска из конфигурации, проверяемые по справочникам
+func (a *Application) searchFilters() map[string][]string {
+	search := a.config.Search
+	filters := map[string][]string{
+		"experience":        {search.Experience},
+		"text_field":        {search.TextField},
+		"text_period":       {search.TextPeriod},
+		"currency":          {search.Currency},
+		"gender":            {search.Gender},
+		"education_level":   search.EducationLevel,
+		"employment":        search.Employment,
+		"schedule":          search.Schedule,
+		"relocation":        {search.Relocation},
+		"job_search_status": search.JobSearchStatus,
+		"order_by":          {search.OrderBy},
+	}
+
+	for _, language := range a.filters().Languages {
+		filters["language_level"] = append(filters["language_level"], language.Level)
+	}
+
+	for _, clause := range a.config.Search.Query {
+		filters["text_field"] = append(filters["text_field"], clause.Field)
+		filters["text_period"] = append(filters["text_period"], clause.Period)
+	}
+
+	return filters
+}
+
+// validateFilters - проверка значений фильтров по справочникам источника
+// Если справочники недоступны, проверка пропускается - поиск покажет ошибку сам
+func (a *Application) validateFilters(ctx context.Context) error {
+	provider, ok := a.repository.(repositories.DictionaryProvider)
+	if !ok {
+		return nil
+	}
+
+	if _, err := provider.Dictionaries(ctx); err != nil {
+		a.logger.Warn("Справочники недоступны, значения фильтров не проверяются", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return nil
+	}
+
+	var problems []error
+	filters := a.searchFilters()
+	for _, filter := range hhrepo.FilterNames() {
+		checked := make(map[string]bool)
+		for _, value := range filters[filter] {
+			if checked[value] {
+				continue
+			}
+			checked[value] = true
+			if err := provider.ValidateFilter(ctx, filter, value); err != nil {
+				problems = append(problems, err)
+			}
+		}
+	}
+
+	return errors.Join(problems...)
+}
+
+// Invite приглашает соискателей на вакансию по параметрам из конфигурации и выводит итоги
+// Соискатели из файла вывода отбираются правилом; перечисленные ID добавляются к ним,
+// данные для шаблона сообщения по ним берутся из того же файла, если он указан
+func (a *Application) Invite(w io.Writer) error {
+	ctx := context.Background()
+	params := a.config.Invite
+
+	if err := a.config.ValidateInvite(); err != nil {
+		return fmt.Errorf("ошибка конфигурации: %w", err)
+	}
+
+	negotiations, ok := a.repository.(repositories.NegotiationRepository)
+	if !ok {
+		return fmt.Errorf("источник не поддерживает приглашения соискателей")
+	}
+
+	resumes, err := a.inviteCandidates(ctx)
+	if err != nil {
+		return err
+	}
+
+	useCase := usecases.NewInviteUseCase(negotiations, storage.NewInvitationLedger(params.LedgerFile, a.logger), a.logger)
+	result, err := useCase.Invite(ctx, usecases.InviteRequest{
+		VacancyID: params.VacancyID,
+		Message:   params.Message,
+		Resumes:   resumes,
+		DryRun:    params.DryRun,
+	})
+	if result != nil {
+		printInviteResult(w, params.VacancyID, result)
+	}
+	return err
+}
+
+// inviteCandidates - соискатели для приглашения без повторов, в порядке файла и затем списка ID
+func (a *Application) inviteCandidates(ctx context.Context) ([]entities.Resume, error) {
+	params := a.config.Invite
+
+	var saved []entities.Resume
+	if params.FromFile != "" {
+		format := strings.TrimPrefix(filepath.Ext(params.FromFile), ".")
+		loader, ok := newStorage(format, params.FromFile, a.logger).(repositories.ResumeLoader)
+		if !ok {
+			return nil, fmt.Errorf("формат файла %s не поддерживает чтение резюме (нужен json)", params.FromFile)
+		}
+
+		var err error
+		if saved, err = loader.LoadResumes(ctx); err != nil {
+			return nil, fmt.Errorf("ошибка чтения резюме из %s: %w", params.FromFile, err)
+		}
+	}
+
+	filter, err := usecases.ParseResumeFilter(params.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка конфигурации: %w", err)
+	}
+
+	var candidates []entities.Resume
+	added := make(map[string]bool)
+	if params.FromFile != "" {
+		for _, resume := range filter.Filter(saved) {
+			if !added[resume.ID] {
+				added[resume.ID] = true
+				candidates = append(candidates, resume)
+			}
+		}
+	}
+
+	byID := make(map[string]entities.Resume, len(saved))
+	for _, resume := range saved {
+		byID[resume.ID] = resume
+	}
+	for _, id := range params.ResumeIDs {
+		if id == "" || added[id] {
+			continue
+		}
+		added[id] = true
+
+		resume, ok := byID[id]
+		if !ok {
+			resume = entities.Resume{ID: id}
+		}
+		candidates = append(candidates, resume)
+	}
+
+	return candidates, nil
+}
+
+// RankResumes оценивает сохраненные резюме по профилю вакансии и выводит их по убыванию оценки
+// с пояснением по составляющим. Если указан файл вывода, ранжированные резюме сохраняются в него
+func (a *Application) RankResumes(w io.Writer) error {
+	ctx := context.Background()
+	params := a.config.Scoring
+
+	if err := a.config.ValidateRank(); err != nil {
+		return fmt.Errorf("ошибка конфигурации: %w", err)
+	}
+	if a.scorerErr != nil {
+		return fmt.Errorf("ошибка конфигурации: %w", a.scorerErr)
+	}
+
+	from := a.config.RankSource()
+	loader, ok := newStorage(strings.TrimPrefix(filepath.Ext(from), "."), from, a.logger).(repositories.ResumeLoader)
+	if !ok {
+		return fmt.Errorf("формат файла %s не поддерживает чтение резюме (нужен json)", from)
+	}
+	resumes, err := loader.LoadResumes(ctx)
+	if err != nil {
+		return fmt.Errorf("ошибка чтения резюме из %s: %w", from, err)
+	}
+
+	// Резюме оцениваются заново: профиль мог измениться после сбора
+	for i := range resumes {
+		resumes[i].Score = a.scorer.Score(&resumes[i])
+	}
+	ranked := usecases.RankResumes(resumes, params.MinScore)
+	passed := len(ranked)
+	if params.Top > 0 && len(ranked) > params.Top {
+		ranked = ranked[:params.Top]
+	}
+
+	for i, resume := range ranked {
+		name := resume.Name
+		if name == "" {
+			name = resume.Title
+		}
+		fmt.Fprintf(w, "%3d. %5.1f  %-16s %s\n", i+1, resume.Score.Total, resume.ID, name)
+		for _, component := range resume.Score.Components {
+			fmt.Fprintf(w, "           %s\n", component)
+		}
+	}
+
+	if params.Output != "" {
+		format := strings.TrimPrefix(filepath.Ext(params.Output), ".")
+		if err := newStorage(format, params.Output, a.logger).SaveResumes(ctx, ranked); err != nil {
+			return fmt.Errorf("ошибка сохранения ранжированных резюме: %w", err)
+		}
+	}
+
+	fmt.Fprintf(w, "Профиль %s: оценено %d, выше порога %.1f - %d, выведено %d\n",
+		a.scorer.Name(), len(resumes), params.MinScore, passed, len(ranked))
+	return nil
+}
+
+// printInviteResult - вывод итогов приглашения
+func printInviteResult(w io.Writer, vacancyID string, result *usecases.InviteResult) {
+	for _, outcome := range result.Outcomes {
+		invitation := outcome.Invitation
+
+		var status string
+		switch outcome.Status {
+		case usecases.InviteSent:
+			status = "приглашен, отклик " + invitation.NegotiationID
+		case usecases.InvitePlanned:
+			status = fmt.Sprintf("будет приглашен: %q", invitation.Message)
+		case usecases.InviteSkipped:
+			status = "уже приглашен"
+		default:
+			status = "отклонено: " + outcome.Err.Error()
+		}
+
+		fmt.Fprintf(w, "  %-16s %-32s %s\n", invitation.ResumeID, invitation.Name, status)
+	}
+
+	fmt.Fprintf(w, "Вакансия %s: приглашено %d, будет приглашено %d, пропущено %d, отклонено %d\n",
+		vacancyID, result.Invited, result.Planned, result.Skipped, result.Failed)
+}
+
+// PrintContactReport выводит открытия контактов за месяц month (в формате 2006-01, пусто - текущий)
+// по дням, с перечнем резюме
+func (a *Application) PrintContactReport(w io.Writer, month string) error {
+	now := time.Now()
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	if month != "" {
+		parsed, err := time.ParseInLocation("2006-01", month, now.Location())
+		if err != nil {
+			return fmt.Errorf("некорректный месяц %q (формат: 2006-01)", month)
+		}
+		start = parsed
+	}
+	end := start.AddDate(0, 1, 0)
+
+	openings, err := storage.NewContactLedger(a.config.Contacts.LedgerFile, a.logger).Openings(context.Background())
+	if err != nil {
+		return err
+	}
+
+	var selected []entities.ContactOpening
+	for _, opening := range openings {
+		at := opening.OpenedAt.In(now.Location())
+		if !at.Before(start) && at.Before(end) {
+			selected = append(selected, opening)
+		}
+	}
+	sort.Slice(selected, func(i, j int) bool { return selected[i].OpenedAt.Before(selected[j].OpenedAt) })
+
+	fmt.Fprintf(w, "Открытия контактов за %s (бюджет: %s в сутки, %s в месяц)\n",
+		start.Format("2006-01"), formatLimit(a.config.Contacts.DailyLimit), formatLimit(a.config.Contacts.MonthlyLimit))
+
+	day := ""
+	for _, opening := range selected {
+		at := opening.OpenedAt.In(now.Location())
+		if at.Format("2006-01-02") != day {
+			day = at.Format("2006-01-02")
+			fmt.Fprintf(w, "%s:\n", day)
+		}
+		fmt.Fprintf(w, "  %s  %-16s %-32s %s\n", at.Format("15:04"), opening.ResumeID, opening.Name, opening.URL)
+	}
+
+	fmt.Fprintf(w, "Итого за месяц: %d\n", len(selected))
+	return nil
+}
+
+// PrintResumeHistory выводит историю версий резюме с изменениями между версиями
+func (a *Application) PrintResumeHistory(w io.Writer, ids []string) error {
+	if len(ids) == 0 {
+		return fmt.Errorf("не указаны ID резюме")
+	}
+
+	history := storage.NewResumeHistory(a.config.Output.HistoryPath(), a.logger)
+	for _, id := range ids {
+		versions, err := history.Versions(context.Background(), id)
+		if err != nil {
+			return err
+		}
+		if len(versions) == 0 {
+			fmt.Fprintf(w, "%s: нет сохраненных версий\n", id)
+			continue
+		}
+
+		fmt.Fprintf(w, "%s: %s (версий: %d)\n", id, versions[len(versions)-1].Resume.Title, len(versions))
+		for _, version := range versions {
+			fmt.Fprintf(w, "  v%d  обновлено %s, получено %s\n", version.Version,
+				version.LastUpdate.Format("2006-01-02 15:04"), version.SavedAt.Format("2006-01-02 15:04"))
+			for _, change := range version.Changes {
+				fmt.Fprintf(w, "      %s\n", change)
+			}
+		}
+	}
+	return nil
+}
+
+// formatLimit - представление бюджета для отчета
+func formatLimit(limit int) string {
+	if limit <= 0 {
+		return "без ограничения"
+	}
+	return strconv.Itoa(limit)
+}
+
+// PrintDictionaries выводит допустимые значения фильтров поиска
+// Если фильтры не указаны, выводятся все
+func (a *Application) PrintDictionaries(w io.Writer, filters []string) error {
+	provider, ok := a.repository.(repositories.DictionaryProvider)
+	if !ok {
+		return fmt.Errorf("источник не поддерживает справочники")
+	}
+
+	dictionaries, err := provider.Dictionaries(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if len(filters) == 0 {
+		filters = hhrepo.FilterNames()
+	}
+
+	for _, filter := range filters {
+		items, ok := dictionaries[filter]
+		if !ok {
+			return fmt.Errorf("неизвестный фильтр %q (доступны: %s)", filter, strings.Join(hhrepo.FilterNames(), ", "))
+		}
+
+		fmt.Fprintf(w, "%s:\n", filter)
+		for _, item := range items {
+			fmt.Fprintf(w, "  %-24s %s\n", item.ID, item.Name)
+		}
+		fmt.Fprintln(w)
+	}
+
 	return nil
 }
@@ -0,0 +1,85 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"hh-resume-parser/internal/config"
+	"hh-resume-parser/internal/domain/repositories"
+	"hh-resume-parser/internal/infrastructure/logger"
+	"hh-resume-parser/internal/infrastructure/ratelimit"
+	hhrepo "hh-resume-parser/internal/infrastructure/repositories"
+)
+
+// SourceDeps - зависимости, общие для источников резюме
+type SourceDeps struct {
+	Config  *config.Config
+	Logger  logger.Logger
+	Cache   repositories.CacheRepository // Кэш справочников на диске
+	Limiter *ratelimit.Limiter           // Собственный ограничитель скорости источника
+
+	// HHOptions - общие настройки репозиториев API hh.ru (пул токенов, кэш HTTP ответов)
+	HHOptions []hhrepo.Option
+}
+
+// SourceFactory - создание репозитория источника резюме по его настройкам
+type SourceFactory func(source config.SourceConfig, deps SourceDeps) (repositories.ResumeRepository, error)
+
+// sourceFactories - зарегистрированные типы источников резюме
+var sourceFactories = map[string]SourceFactory{
+	config.SourceHH: func(source config.SourceConfig, deps SourceDeps) (repositories.ResumeRepository, error) {
+		opts := append([]hhrepo.Option{hhrepo.WithLimiter(deps.Limiter)}, deps.HHOptions...)
+		return hhrepo.NewHHRepository(deps.Config, deps.Logger, deps.Cache, opts...), nil
+	},
+	config.SourceHTML: func(source config.SourceConfig, deps SourceDeps) (repositories.ResumeRepository, error) {
+		return hhrepo.NewHTMLRepository(source.Path, deps.Logger), nil
+	},
+	config.SourceJSON: func(source config.SourceConfig, deps SourceDeps) (repositories.ResumeRepository, error) {
+		return hhrepo.NewJSONRepository(source.Path, deps.Logger), nil
+	},
+}
+
+// RegisterSource - регистрация типа источника резюме (например, другого сайта с резюме)
+// Вызывается до создания приложения; повторная регистрация заменяет фабрику
+func RegisterSource(kind string, factory SourceFactory) {
+	sourceFactories[kind] = factory
+}
+
+// SourceTypes - зарегистрированные типы источников резюме
+func SourceTypes() []string {
+	types := make([]string, 0, len(sourceFactories))
+	for kind := range sourceFactories {
+		types = append(types, kind)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// newSource - создание репозитория источника зарегистрированной фабрикой
+func newSource(source config.SourceConfig, deps SourceDeps) (repositories.ResumeRepository, error) {
+	factory, ok := sourceFactories[source.Type]
+	if !ok {
+		return nil, fmt.Errorf("неизвестный тип источника %q (доступны: %s)", source.Type, strings.Join(SourceTypes(), ", "))
+	}
+
+	repo, err := factory(source, deps)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка создания источника %q: %w", source.Name, err)
+	}
+	return repo, nil
+}
+
+// sourceLimits - ограничения скорости источника: собственные значения поверх общих настроек API
+func sourceLimits(api config.APIConfig, source config.SourceConfig) config.APIConfig {
+	if source.RateLimit > 0 {
+		api.RateLimit = source.RateLimit
+	}
+	if source.RateBurst > 0 {
+		api.RateBurst = source.RateBurst
+	}
+	if source.DailyQuota > 0 {
+		api.DailyQuota = source.DailyQuota
+	}
+	return api
+}
@@ -1,6 +1,14 @@
 package config
 
-import "time"
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
 
 // Config - основная конфигурация приложения
 // Содержит все настройки для работы парсера резюме
@@ -9,7 +17,16 @@ type Config struct {
 	Search   SearchConfig   `json:"search"`   // Параметры поиска
 	Output   OutputConfig   `json:"output"`   // Настройки вывода
 	Database DatabaseConfig `json:"database"` // Настройки базы данных
+	Cache    CacheConfig    `json:"cache"`    // Настройки локального кэша
+	Invite   InviteConfig   `json:"invite"`   // Приглашение соискателей на вакансию
+	Contacts ContactsConfig `json:"contacts"` // Открытие контактов соискателей
+	Skills   SkillsConfig   `json:"skills"`   // Приведение навыков к каноническим названиям
+	Scoring  ScoringConfig  `json:"scoring"`  // Оценка резюме по профилю вакансии
 	LogFile  string         `json:"log_file"` // Файл логов
+
+	// Validation - правила проверки резюме перед сохранением
+	// Обязательные поля (ID и имя или заголовок) проверяются всегда
+	Validation []ValidationRuleConfig `json:"validation"`
 }
 
 // APIConfig - конфигурация для работы с API hh.ru
@@ -18,20 +35,192 @@ type APIConfig struct {
 	RateLimit time.Duration `json:"rate_limit"` // Ограничение скорости запросов
 	UserAgent string        `json:"user_agent"` // User-Agent для запросов
 	Timeout   time.Duration `json:"timeout"`    // Таймаут HTTP запросов
+
+	// Подключение к API
+	BaseURL            string `json:"base_url"`             // Базовый адрес API (зеркало, прокси или тестовый сервер)
+	ProxyURL           string `json:"proxy_url"`            // Адрес HTTP(S) прокси; по умолчанию берется из окружения
+	CACertFile         string `json:"ca_cert_file"`         // Дополнительный корневой сертификат в формате PEM
+	InsecureSkipVerify bool   `json:"insecure_skip_verify"` // Не проверять TLS сертификат сервера
+
+	// Transport - пользовательский транспорт HTTP запросов
+	// Если задан, настройки прокси и TLS не применяются
+	Transport http.RoundTripper `json:"-"`
+
+	// Ограничение скорости сверх RateLimit
+	RateBurst          int                      `json:"rate_burst"`           // Количество запросов подряд без ожидания
+	EndpointRateLimits map[string]time.Duration `json:"endpoint_rate_limits"` // Интервалы для отдельных конечных точек ("/resumes")
+	DailyQuota         int                      `json:"daily_quota"`          // Суточная квота запросов (0 - без ограничения)
+
+	// DepthLimit - максимальное количество результатов, которое API отдает по одному запросу
+	DepthLimit int `json:"depth_limit"`
+
+	// Повторы запросов при ошибках 429, 5xx и сетевых сбоях
+	MaxRetries     int           `json:"max_retries"`      // Максимальное количество повторов
+	RetryBaseDelay time.Duration `json:"retry_base_delay"` // Начальная задержка экспоненциального отката
+	RetryMaxDelay  time.Duration `json:"retry_max_delay"`  // Максимальная задержка между повторами
+
+	// OAuth - авторизация приложения работодателя через OAuth2
+	// Если задан ClientID, статический токен не используется
+	OAuth OAuthConfig `json:"oauth"`
+
+	// Tokens - пул токенов нескольких аккаунтов работодателя
+	// Если задан, Token и OAuth не используются: запросы распределяются между токенами по очереди,
+	// токен с исчерпанной квотой или отозванный исключается из ротации.
+	// RateLimit, RateBurst и DailyQuota выше остаются общими для всех токенов
+	Tokens []TokenConfig `json:"tokens"`
+}
+
+// TokenConfig - токен аккаунта в пуле со своими ограничениями
+type TokenConfig struct {
+	Name       string        `json:"name"`        // Имя токена в логах (по умолчанию token-N)
+	Token      string        `json:"token"`       // Токен доступа
+	RateLimit  time.Duration `json:"rate_limit"`  // Интервал между запросами с этим токеном (0 - без ограничения)
+	RateBurst  int           `json:"rate_burst"`  // Количество запросов подряд без ожидания
+	DailyQuota int           `json:"daily_quota"` // Суточная квота токена (0 - без ограничения)
+}
+
+// OAuthConfig - параметры OAuth2 авторизации hh.ru
+type OAuthConfig struct {
+	ClientID      string        `json:"client_id"`      // Идентификатор приложения
+	ClientSecret  string        `json:"client_secret"`  // Секрет приложения
+	RedirectURI   string        `json:"redirect_uri"`   // Адрес перенаправления, указанный в настройках приложения
+	AuthURL       string        `json:"auth_url"`       // Адрес страницы авторизации
+	TokenURL      string        `json:"token_url"`      // Адрес получения и обновления токенов
+	TokenFile     string        `json:"token_file"`     // Файл для хранения токенов
+	RefreshBefore time.Duration `json:"refresh_before"` // За сколько до истечения обновлять токен
 }
 
 // SearchConfig - параметры поиска резюме
 type SearchConfig struct {
 	Keywords   []string `json:"keywords"`    // Ключевые слова для поиска
-	City       string   `json:"city"`        // Город поиска
+	City       string   `json:"city"`        // Города, регионы или ID регионов через запятую
 	Experience string   `json:"experience"`  // Требуемый опыт работы
 	UpdateDays int      `json:"update_days"` // Количество дней с последнего обновления
+
+	// Построение текстового запроса
+	KeywordLogic string        `json:"keyword_logic"` // Как объединяются ключевые слова: all, any, phrase
+	TextField    string        `json:"text_field"`    // Где искать ключевые слова: everywhere, title, skills, ...
+	TextPeriod   string        `json:"text_period"`   // За какой период опыта искать: all_time, last_year, ...
+	Exclude      []string      `json:"exclude"`       // Слова, которых не должно быть в результатах
+	Query        []QueryClause `json:"query"`         // Дополнительные условия, объединяются с ключевыми словами по И
+
+	// Фильтры поиска; пустые значения не ограничивают выдачу
+	// Значения проверяются по справочникам hh.ru (подкоманда dictionaries)
+	SalaryFrom      int      `json:"salary_from"`       // Нижняя граница зарплаты
+	SalaryTo        int      `json:"salary_to"`         // Верхняя граница зарплаты
+	Currency        string   `json:"currency"`          // Валюта зарплаты (RUR, USD, EUR, ...)
+	AgeFrom         int      `json:"age_from"`          // Минимальный возраст
+	AgeTo           int      `json:"age_to"`            // Максимальный возраст
+	Gender          string   `json:"gender"`            // Пол (male, female)
+	EducationLevel  []string `json:"education_level"`   // Уровни образования
+	Employment      []string `json:"employment"`        // Типы занятости
+	Schedule        []string `json:"schedule"`          // Графики работы
+	Relocation      string   `json:"relocation"`        // Готовность к переезду
+	Languages       []string `json:"languages"`         // Знание языков в формате "eng" или "eng.b2"
+	JobSearchStatus []string `json:"job_search_status"` // Статусы поиска работы
+	OrderBy         string   `json:"order_by"`          // Сортировка выдачи
+
+	// KeywordMode - combined: один запрос по всем ключевым словам;
+	// each: отдельный поиск по каждому слову с записью совпавших слов
+	KeywordMode string `json:"keyword_mode"`
+
+	// Mode - что собирать: резюме (resumes), вакансии (vacancies) или и то, и другое (both)
+	Mode string `json:"mode"`
+
+	// SplitQueries - разбивать запросы, превышающие лимит глубины выдачи
+	SplitQueries bool `json:"split_queries"`
+
+	// FetchDetails - загружать полную версию для каждого нового результата поиска
+	FetchDetails bool `json:"fetch_details"`
+	// DetailWorkers - количество параллельных загрузок полных версий
+	DetailWorkers int `json:"detail_workers"`
+
+	// HTMLDir - каталог сохраненных страниц резюме hh.ru
+	// Если задан, резюме читаются из страниц вместо API и токен не нужен
+	HTMLDir string `json:"html_dir"`
+
+	// Sources - источники резюме, результаты которых объединяются за один запуск
+	// Если не заданы, резюме собираются из API hh.ru (или из HTMLDir)
+	Sources []SourceConfig `json:"sources"`
+}
+
+// Типы источников резюме
+const (
+	SourceHH   = "hh"   // API hh.ru
+	SourceHTML = "html" // Каталог сохраненных страниц hh.ru
+	SourceJSON = "json" // Файл или каталог JSON выгрузок в формате вывода парсера
+)
+
+// SourceConfig - источник резюме
+type SourceConfig struct {
+	Name string `json:"name"` // Имя источника в логах и в поле source резюме (по умолчанию - тип)
+	Type string `json:"type"` // Тип источника: hh, html, json
+	Path string `json:"path"` // Каталог или файл локального источника
+
+	// Собственные ограничения скорости источника; нулевые значения берутся из настроек api
+	RateLimit  time.Duration `json:"rate_limit"`  // Интервал между запросами
+	RateBurst  int           `json:"rate_burst"`  // Количество запросов подряд без ожидания
+	DailyQuota int           `json:"daily_quota"` // Суточная квота запросов
+}
+
+// QueryClause - условие текстового запроса
+type QueryClause struct {
+	Terms  []string `json:"terms"`  // Слова или фразы
+	Logic  string   `json:"logic"`  // all, any, phrase или except (по умолчанию all)
+	Field  string   `json:"field"`  // Где искать (по умолчанию везде)
+	Period string   `json:"period"` // За какой период опыта искать (по умолчанию за все время)
 }
 
 // OutputConfig - настройки форматов вывода
 type OutputConfig struct {
 	Format string `json:"format"` // Формат вывода (csv, json, sql)
 	File   string `json:"file"`   // Файл для сохранения результатов
+
+	// VacanciesFile - файл для сохранения вакансий
+	// По умолчанию vacancies с расширением File в том же каталоге
+	VacanciesFile string `json:"vacancies_file"`
+
+	// CheckpointFile - файл контрольной точки для продолжения прерванного запуска
+	// По умолчанию рядом с File: <File>.checkpoint.json
+	CheckpointFile string `json:"checkpoint_file"`
+
+	// Resume - продолжить прерванный запуск из контрольной точки, дописывая резюме в File
+	Resume bool `json:"resume"`
+
+	// TrackUpdates - сохранять повторно резюме, обновленные с прошлого запуска, и вести историю версий
+	TrackUpdates bool `json:"track_updates"`
+
+	// HistoryFile - история версий резюме
+	// По умолчанию рядом с File: <File>.history.jsonl
+	HistoryFile string `json:"history_file"`
+}
+
+// CheckpointPath - файл контрольной точки с учетом значения по умолчанию
+func (o OutputConfig) CheckpointPath() string {
+	if o.CheckpointFile != "" {
+		return o.CheckpointFile
+	}
+	return o.File + ".checkpoint.json"
+}
+
+// HistoryPath - файл истории версий резюме с учетом значения по умолчанию
+func (o OutputConfig) HistoryPath() string {
+	if o.HistoryFile != "" {
+		return o.HistoryFile
+	}
+	return o.File + ".history.jsonl"
+}
+
+// VacanciesOutputFile - файл для сохранения вакансий с учетом значения по умолчанию
+func (o OutputConfig) VacanciesOutputFile() string {
+	if o.VacanciesFile != "" {
+		return o.VacanciesFile
+	}
+	ext := filepath.Ext(o.File)
+	if ext == "" {
+		ext = "." + o.Format
+	}
+	return filepath.Join(filepath.Dir(o.File), "vacancies"+ext)
 }
 
 // DatabaseConfig - настройки подключения к PostgreSQL
@@ -43,17 +232,127 @@ type DatabaseConfig struct {
 	DBName   string `json:"db_name"`  // Имя базы данных
 }
 
+// CacheConfig - настройки локального кэша справочных данных
+type CacheConfig struct {
+	Dir             string        `json:"dir"`              // Каталог кэша
+	AreasTTL        time.Duration `json:"areas_ttl"`        // Срок хранения дерева регионов
+	DictionariesTTL time.Duration `json:"dictionaries_ttl"` // Срок хранения справочников
+
+	// Кэш HTTP ответов API с перепроверкой по ETag и Last-Modified
+	HTTP        bool  `json:"http"`          // Кэшировать ответы API
+	HTTPMaxSize int64 `json:"http_max_size"` // Максимальный размер на диске в байтах (0 - без ограничения)
+
+	// HTTPTTLs - сроки свежести по путям: "/dictionaries" - точный путь, "/resumes/" - префикс
+	// Для остальных путей используется max-age из Cache-Control ответа
+	HTTPTTLs map[string]time.Duration `json:"http_ttls"`
+}
+
+// InviteConfig - параметры приглашения соискателей на вакансию (подкоманда invite)
+// Соискатели берутся из списка ID и/или из JSON файла вывода, отобранные правилом Filter
+type InviteConfig struct {
+	VacancyID  string   `json:"vacancy_id"`  // Вакансия, на которую приглашаются соискатели
+	Message    string   `json:"message"`     // Шаблон сообщения: {{.Name}}, {{.Title}}, {{.VacancyID}}, ...
+	ResumeIDs  []string `json:"resume_ids"`  // Идентификаторы приглашаемых резюме
+	FromFile   string   `json:"from_file"`   // JSON файл вывода с отобранными резюме
+	Filter     string   `json:"filter"`      // Правило отбора резюме из файла: "skills=Kafka; experience>=3"
+	DryRun     bool     `json:"dry_run"`     // Только показать, кто будет приглашен
+	LedgerFile string   `json:"ledger_file"` // Журнал отправленных приглашений
+}
+
+// ContactsConfig - открытие контактов соискателей при загрузке полных резюме
+// Открытие скрытых контактов расходует платные просмотры работодателя, поэтому по умолчанию выключено
+type ContactsConfig struct {
+	Open         bool   `json:"open"`          // Открывать контакты (false - полные резюме без контактов)
+	DailyLimit   int    `json:"daily_limit"`   // Открытий в сутки (0 - без ограничения)
+	MonthlyLimit int    `json:"monthly_limit"` // Открытий в календарный месяц (0 - без ограничения)
+	OnExhausted  string `json:"on_exhausted"`  // block - не загружать полное резюме, downgrade - загрузить без контактов
+	LedgerFile   string `json:"ledger_file"`   // Журнал открытий контактов
+}
+
+// SkillsConfig - приведение навыков резюме к каноническим названиям по словарю
+// По умолчанию выключено; написание соискателя сохраняется в поле skills_original
+type SkillsConfig struct {
+	Normalize  bool   `json:"normalize"`  // Приводить навыки к каноническим названиям
+	Builtin    bool   `json:"builtin"`    // Использовать встроенный словарь IT навыков
+	Dictionary string `json:"dictionary"` // JSON файл словаря {"Go": ["Golang", "go lang"]}, дополняет встроенный
+
+	// Поиск навыков словаря в заголовке резюме, должностях и описаниях опыта работы
+	Extract       bool    `json:"extract"`        // Искать навыки, не указанные соискателем
+	MinConfidence float64 `json:"min_confidence"` // Минимальная уверенность найденного навыка (0..1)
+}
+
+// UsesDictionary - нужен ли словарь навыков
+func (s SkillsConfig) UsesDictionary() bool {
+	return s.Normalize || s.Extract
+}
+
+// ScoringConfig - оценка резюме по профилю вакансии
+// Профиль - JSON файл с навыками, стажем, потолком зарплаты, регионами и сроком свежести
+type ScoringConfig struct {
+	Profile  string  `json:"profile"`   // Файл профиля вакансии (пусто - резюме не оцениваются)
+	MinScore float64 `json:"min_score"` // Порог оценки от 0 до 100: резюме с меньшей оценкой пропускаются
+
+	// Ранжирование сохраненных резюме (подкоманда rank)
+	From   string `json:"from"`   // JSON файл вывода с резюме (по умолчанию output.file)
+	Output string `json:"output"` // Файл для ранжированных резюме, формат по расширению (пусто - только отчет)
+	Top    int    `json:"top"`    // Сколько лучших резюме оставить (0 - все)
+}
+
+// RankSource - файл резюме для ранжирования с учетом значения по умолчанию
+func (c *Config) RankSource() string {
+	if c.Scoring.From != "" {
+		return c.Scoring.From
+	}
+	return c.Output.File
+}
+
+// Действия при нарушении правила проверки резюме
+const (
+	SeverityDrop = "drop" // Резюме пропускается
+	SeverityWarn = "warn" // Резюме сохраняется с предупреждением в журнале
+)
+
+// ValidationRuleConfig - правило проверки резюме
+// Условие записывается в формате правила отбора: "email!=; experience>=2; title!~junior"
+type ValidationRuleConfig struct {
+	Name      string `json:"name"`      // Имя правила в журнале и итогах
+	Condition string `json:"condition"` // Условие, которому должно удовлетворять резюме
+	Severity  string `json:"severity"`  // drop или warn (по умолчанию drop)
+}
+
+// HTTPDir - каталог кэша HTTP ответов
+func (c CacheConfig) HTTPDir() string {
+	return filepath.Join(c.Dir, "http")
+}
+
 // GetDefaultConfig - возвращает конфигурацию по умолчанию
 func GetDefaultConfig() *Config {
 	return &Config{
 		API: APIConfig{
-			RateLimit: time.Second,
-			UserAgent: "HH Resume Parser v2.0",
-			Timeout:   30 * time.Second,
+			RateLimit:  time.Second,
+			RateBurst:  1,
+			UserAgent:  "HH Resume Parser v2.0",
+			Timeout:    30 * time.Second,
+			BaseURL:    "https://api.hh.ru",
+			DepthLimit: 2000,
+
+			MaxRetries:     3,
+			RetryBaseDelay: 500 * time.Millisecond,
+			RetryMaxDelay:  30 * time.Second,
+
+			OAuth: OAuthConfig{
+				AuthURL:       "https://hh.ru/oauth/authorize",
+				TokenURL:      "https://api.hh.ru/token",
+				TokenFile:     ".hh_token.json",
+				RefreshBefore: 5 * time.Minute,
+			},
 		},
 		Search: SearchConfig{
-			City:       "Moscow",
-			UpdateDays: 7,
+			City:          "Moscow",
+			Mode:          ModeResumes,
+			UpdateDays:    7,
+			SplitQueries:  true,
+			DetailWorkers: 4,
 		},
 		Output: OutputConfig{
 			Format: "json",
@@ -65,6 +364,418 @@ func GetDefaultConfig() *Config {
 			User:   "postgres",
 			DBName: "resumes",
 		},
+		Cache: CacheConfig{
+			Dir:             ".cache",
+			AreasTTL:        7 * 24 * time.Hour,
+			DictionariesTTL: 24 * time.Hour,
+			HTTPMaxSize:     100 << 20,
+			HTTPTTLs: map[string]time.Duration{
+				"/dictionaries": 24 * time.Hour,
+				"/areas":        7 * 24 * time.Hour,
+			},
+		},
+		Invite: InviteConfig{
+			LedgerFile: "invitations.json",
+		},
+		Contacts: ContactsConfig{
+			OnExhausted: ContactsDowngrade,
+			LedgerFile:  "contacts.json",
+		},
+		Skills: SkillsConfig{
+			MinConfidence: 0.5,
+		},
 		LogFile: "parser.log",
+		Validation: []ValidationRuleConfig{
+			{Name: "skills_or_experience", Condition: "skills!= | jobs>0", Severity: SeverityDrop},
+		},
+	}
+}
+
+// supportedFormats - поддерживаемые форматы вывода
+var supportedFormats = map[string]bool{"json": true, "csv": true, "sql": true}
+
+// Режимы поиска по ключевым словам
+const (
+	KeywordModeCombined = "combined" // Один запрос по всем ключевым словам
+	KeywordModeEach     = "each"     // Отдельный поиск по каждому ключевому слову
+)
+
+// textLogics - допустимые способы объединения слов в условиях запроса
+var textLogics = map[string]bool{"": true, "all": true, "any": true, "phrase": true, "except": true}
+
+// Реакция на исчерпание бюджета открытий контактов
+const (
+	ContactsBlock     = "block"     // Не загружать полное резюме
+	ContactsDowngrade = "downgrade" // Загрузить полное резюме без контактов
+)
+
+// Режимы сбора данных
+const (
+	ModeResumes   = "resumes"   // Только резюме
+	ModeVacancies = "vacancies" // Только вакансии
+	ModeBoth      = "both"      // Резюме и вакансии за один запуск
+)
+
+// CollectResumes - нужно ли собирать резюме в текущем режиме
+func (s SearchConfig) CollectResumes() bool {
+	return s.Mode == "" || s.Mode == ModeResumes || s.Mode == ModeBoth
+}
+
+// CollectVacancies - нужно ли собирать вакансии в текущем режиме
+func (s SearchConfig) CollectVacancies() bool {
+	return s.Mode == ModeVacancies || s.Mode == ModeBoth
+}
+
+// ResumeSources - источники резюме с учетом значений по умолчанию
+// Источник без имени называется по своему типу
+func (s SearchConfig) ResumeSources() []SourceConfig {
+	var sources []SourceConfig
+	switch {
+	case len(s.Sources) > 0:
+		sources = append(sources, s.Sources...)
+	case s.HTMLDir != "":
+		sources = []SourceConfig{{Type: SourceHTML, Path: s.HTMLDir}}
+	default:
+		sources = []SourceConfig{{Type: SourceHH}}
+	}
+
+	for i := range sources {
+		if sources[i].Name == "" {
+			sources[i].Name = sources[i].Type
+		}
+	}
+	return sources
+}
+
+// UsesAPI - нужен ли в текущем режиме доступ к API hh.ru
+// Вакансии собираются только из API
+func (s SearchConfig) UsesAPI() bool {
+	if s.CollectVacancies() {
+		return true
+	}
+	for _, source := range s.ResumeSources() {
+		if source.Type == SourceHH {
+			return true
+		}
+	}
+	return false
+}
+
+// validateSources - проверка источников резюме
+// Тип источника проверяется при создании приложения: набор типов расширяется регистрацией
+func (s SearchConfig) validateSources() error {
+	names := make(map[string]bool)
+	hasAPI := false
+	for i, source := range s.ResumeSources() {
+		switch {
+		case source.Type == "":
+			return fmt.Errorf("не указан тип источника %d", i+1)
+		case (source.Type == SourceHTML || source.Type == SourceJSON) && source.Path == "":
+			return fmt.Errorf("не указан путь к файлам источника %q", source.Name)
+		case source.RateLimit < 0 || source.RateBurst < 0 || source.DailyQuota < 0:
+			return fmt.Errorf("ограничения источника %q не могут быть отрицательными", source.Name)
+		case names[source.Name]:
+			return fmt.Errorf("повторяющееся имя источника %q", source.Name)
+		}
+		names[source.Name] = true
+		hasAPI = hasAPI || source.Type == SourceHH
+	}
+
+	if s.CollectVacancies() && !hasAPI {
+		if len(s.Sources) == 0 {
+			return fmt.Errorf("сохраненные страницы содержат только резюме, режим %q недоступен", s.Mode)
+		}
+		return fmt.Errorf("вакансии собираются только из API hh.ru, режим %q недоступен без источника %s", s.Mode, SourceHH)
 	}
+	return nil
+}
+
+// HasCredentials - задан ли способ авторизации в API
+func (c APIConfig) HasCredentials() bool {
+	return c.Token != "" || c.OAuth.ClientID != "" || len(c.Tokens) > 0
+}
+
+// validateTokens - проверка пула токенов
+func (c APIConfig) validateTokens() error {
+	names := make(map[string]bool, len(c.Tokens))
+	for i, token := range c.Tokens {
+		if token.Token == "" {
+			return fmt.Errorf("не указан токен %d в пуле токенов", i+1)
+		}
+		if token.RateLimit < 0 || token.RateBurst < 0 || token.DailyQuota < 0 {
+			return fmt.Errorf("ограничения токена %d не могут быть отрицательными", i+1)
+		}
+		if token.Name == "" {
+			continue
+		}
+		if names[token.Name] {
+			return fmt.Errorf("повторяющееся имя токена %q в пуле токенов", token.Name)
+		}
+		names[token.Name] = true
+	}
+	return nil
+}
+
+// Validate - проверка конфигурации, не требующая обращения к сети
+func (c *Config) Validate() error {
+	if err := c.Search.validateSources(); err != nil {
+		return err
+	}
+	if c.Search.UsesAPI() && !c.API.HasCredentials() {
+		return fmt.Errorf("не указан API токен или Client ID приложения")
+	}
+	if err := c.API.validateTokens(); err != nil {
+		return err
+	}
+
+	if len(c.Search.Keywords) == 0 {
+		return fmt.Errorf("не указаны ключевые слова для поиска")
+	}
+
+	if !supportedFormats[c.Output.Format] {
+		return fmt.Errorf("неподдерживаемый формат вывода %q (доступны: json, csv, sql)", c.Output.Format)
+	}
+
+	switch c.Search.Mode {
+	case "", ModeResumes, ModeVacancies, ModeBoth:
+	default:
+		return fmt.Errorf("неизвестный режим сбора %q (доступны: %s, %s, %s)", c.Search.Mode, ModeResumes, ModeVacancies, ModeBoth)
+	}
+
+	switch c.Search.KeywordMode {
+	case "", KeywordModeCombined, KeywordModeEach:
+	default:
+		return fmt.Errorf("неизвестный режим поиска по ключевым словам %q (доступны: %s, %s)",
+			c.Search.KeywordMode, KeywordModeCombined, KeywordModeEach)
+	}
+
+	if !textLogics[c.Search.KeywordLogic] || c.Search.KeywordLogic == "except" {
+		return fmt.Errorf("недопустимый способ объединения ключевых слов %q (доступны: all, any, phrase)", c.Search.KeywordLogic)
+	}
+
+	for i, clause := range c.Search.Query {
+		if len(clause.Terms) == 0 {
+			return fmt.Errorf("условие запроса %d не содержит слов", i+1)
+		}
+		if !textLogics[clause.Logic] {
+			return fmt.Errorf("недопустимый способ объединения слов %q в условии %d (доступны: all, any, phrase, except)", clause.Logic, i+1)
+		}
+	}
+
+	if err := c.Search.validateFilters(); err != nil {
+		return err
+	}
+
+	for path, ttl := range c.Cache.HTTPTTLs {
+		if !strings.HasPrefix(path, "/") || ttl < 0 {
+			return fmt.Errorf("некорректный срок HTTP кэша %s=%s", path, ttl)
+		}
+	}
+
+	if c.Contacts.DailyLimit < 0 || c.Contacts.MonthlyLimit < 0 {
+		return fmt.Errorf("бюджет открытий контактов не может быть отрицательным")
+	}
+
+	switch c.Contacts.OnExhausted {
+	case "", ContactsBlock, ContactsDowngrade:
+	default:
+		return fmt.Errorf("неизвестная реакция на исчерпание бюджета контактов %q (доступны: %s, %s)",
+			c.Contacts.OnExhausted, ContactsBlock, ContactsDowngrade)
+	}
+
+	if c.Contacts.Open && c.Contacts.LedgerFile == "" {
+		return fmt.Errorf("не указан файл журнала открытий контактов")
+	}
+
+	if c.Search.FetchDetails && c.Search.DetailWorkers <= 0 {
+		return fmt.Errorf("количество потоков загрузки полных версий должно быть положительным")
+	}
+
+	if c.Search.CollectVacancies() && c.Search.CollectResumes() && c.Output.VacanciesOutputFile() == c.Output.File {
+		return fmt.Errorf("резюме и вакансии не могут сохраняться в один файл %q", c.Output.File)
+	}
+
+	if err := c.validateRules(); err != nil {
+		return err
+	}
+
+	if c.Output.CheckpointPath() == c.Output.File {
+		return fmt.Errorf("контрольная точка не может сохраняться в файл вывода %q", c.Output.File)
+	}
+
+	if c.Output.TrackUpdates && c.Output.HistoryPath() == c.Output.File {
+		return fmt.Errorf("история версий резюме не может сохраняться в файл вывода %q", c.Output.File)
+	}
+
+	if _, err := url.ParseRequestURI(c.API.BaseURL); err != nil {
+		return fmt.Errorf("некорректный адрес API %q: %w", c.API.BaseURL, err)
+	}
+
+	if c.API.ProxyURL != "" {
+		if _, err := url.ParseRequestURI(c.API.ProxyURL); err != nil {
+			return fmt.Errorf("некорректный адрес прокси %q: %w", c.API.ProxyURL, err)
+		}
+	}
+
+	if c.API.CACertFile != "" {
+		if _, err := os.Stat(c.API.CACertFile); err != nil {
+			return fmt.Errorf("недоступен файл сертификата: %w", err)
+		}
+	}
+
+	if err := c.validateScoring(); err != nil {
+		return err
+	}
+	// Оцененные резюме сохраняются в конце запуска, и контрольная точка для продолжения не ведется
+	if c.Scoring.Profile != "" && c.Output.Resume {
+		return fmt.Errorf("прерванный запуск с оценкой по профилю вакансии продолжить нельзя: уберите -resume или -profile")
+	}
+
+	if c.Skills.MinConfidence < 0 || c.Skills.MinConfidence > 1 {
+		return fmt.Errorf("минимальная уверенность найденного навыка должна быть от 0 до 1")
+	}
+
+	if c.Skills.UsesDictionary() && !c.Skills.Builtin && c.Skills.Dictionary == "" {
+		return fmt.Errorf("словарь навыков пуст: включите встроенный словарь или укажите файл словаря")
+	}
+	if c.Skills.UsesDictionary() && c.Skills.Dictionary != "" {
+		if _, err := os.Stat(c.Skills.Dictionary); err != nil {
+			return fmt.Errorf("недоступен словарь навыков: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// validateRules - проверка имен и действий правил проверки резюме
+// Условия разбираются при создании приложения
+func (c *Config) validateRules() error {
+	names := make(map[string]bool, len(c.Validation))
+	for i, rule := range c.Validation {
+		if rule.Name == "" {
+			return fmt.Errorf("не указано имя правила проверки %d", i+1)
+		}
+		if names[rule.Name] {
+			return fmt.Errorf("правило проверки %s указано дважды", rule.Name)
+		}
+		names[rule.Name] = true
+
+		if strings.TrimSpace(rule.Condition) == "" {
+			return fmt.Errorf("не указано условие правила проверки %s", rule.Name)
+		}
+		switch rule.Severity {
+		case "", SeverityDrop, SeverityWarn:
+		default:
+			return fmt.Errorf("неизвестное действие %q правила проверки %s (доступны: %s, %s)",
+				rule.Severity, rule.Name, SeverityDrop, SeverityWarn)
+		}
+	}
+	return nil
+}
+
+// validateScoring - проверка параметров оценки резюме
+func (c *Config) validateScoring() error {
+	if c.Scoring.MinScore < 0 || c.Scoring.MinScore > 100 {
+		return fmt.Errorf("порог оценки резюме должен быть от 0 до 100")
+	}
+	if c.Scoring.Profile == "" {
+		if c.Scoring.MinScore > 0 {
+			return fmt.Errorf("порог оценки задан, а профиль вакансии не указан")
+		}
+		return nil
+	}
+	if _, err := os.Stat(c.Scoring.Profile); err != nil {
+		return fmt.Errorf("недоступен профиль вакансии: %w", err)
+	}
+	return nil
+}
+
+// ValidateRank - проверка параметров ранжирования сохраненных резюме
+func (c *Config) ValidateRank() error {
+	if c.Scoring.Profile == "" {
+		return fmt.Errorf("не указан профиль вакансии")
+	}
+	if err := c.validateScoring(); err != nil {
+		return err
+	}
+	if c.Scoring.Top < 0 {
+		return fmt.Errorf("количество лучших резюме не может быть отрицательным")
+	}
+	if c.Scoring.Output != "" {
+		format := strings.TrimPrefix(filepath.Ext(c.Scoring.Output), ".")
+		if !supportedFormats[format] {
+			return fmt.Errorf("неподдерживаемый формат файла %q (доступны: json, csv, sql)", c.Scoring.Output)
+		}
+		if c.Scoring.Output == c.RankSource() {
+			return fmt.Errorf("ранжированные резюме не могут сохраняться в исходный файл %q", c.Scoring.Output)
+		}
+	}
+	return nil
+}
+
+// ValidateInvite - проверка параметров приглашения соискателей
+// Параметры поиска для приглашения не нужны и не проверяются
+func (c *Config) ValidateInvite() error {
+	if !c.API.HasCredentials() && !c.Invite.DryRun {
+		return fmt.Errorf("не указан API токен или Client ID приложения")
+	}
+	if err := c.API.validateTokens(); err != nil {
+		return err
+	}
+
+	if c.Invite.VacancyID == "" {
+		return fmt.Errorf("не указана вакансия для приглашения")
+	}
+
+	if len(c.Invite.ResumeIDs) == 0 && c.Invite.FromFile == "" {
+		return fmt.Errorf("не указаны резюме для приглашения: перечислите ID или укажите файл вывода")
+	}
+
+	if c.Invite.Filter != "" && c.Invite.FromFile == "" {
+		return fmt.Errorf("правило отбора применяется к файлу вывода, а файл не указан")
+	}
+
+	if c.Invite.LedgerFile == "" {
+		return fmt.Errorf("не указан файл журнала приглашений")
+	}
+
+	if _, err := url.ParseRequestURI(c.API.BaseURL); err != nil {
+		return fmt.Errorf("некорректный адрес API %q: %w", c.API.BaseURL, err)
+	}
+
+	return nil
+}
+
+// Допустимый возраст соискателя в фильтрах hh.ru
+const (
+	minSearchAge = 14
+	maxSearchAge = 100
+)
+
+// validateFilters - проверка диапазонов фильтров поиска
+// Значения из справочников проверяются отдельно, по данным API
+func (s SearchConfig) validateFilters() error {
+	if s.SalaryFrom < 0 || s.SalaryTo < 0 {
+		return fmt.Errorf("зарплата не может быть отрицательной")
+	}
+	if s.SalaryTo > 0 && s.SalaryFrom > s.SalaryTo {
+		return fmt.Errorf("нижняя граница зарплаты %d больше верхней %d", s.SalaryFrom, s.SalaryTo)
+	}
+
+	for _, age := range []int{s.AgeFrom, s.AgeTo} {
+		if age != 0 && (age < minSearchAge || age > maxSearchAge) {
+			return fmt.Errorf("возраст %d вне допустимого диапазона %d-%d", age, minSearchAge, maxSearchAge)
+		}
+	}
+	if s.AgeTo > 0 && s.AgeFrom > s.AgeTo {
+		return fmt.Errorf("минимальный возраст %d больше максимального %d", s.AgeFrom, s.AgeTo)
+	}
+
+	for _, language := range s.Languages {
+		if id, _, _ := strings.Cut(language, "."); strings.TrimSpace(id) == "" {
+			return fmt.Errorf("не указан язык в фильтре %q (формат: eng или eng.b2)", language)
+		}
+	}
+
+	return nil
 }
@@ -0,0 +1,12 @@
+package entities
+
+import "time"
+
+// ContactOpening - открытие контактов соискателя
+// Для резюме со скрытыми контактами открытие расходует платный просмотр работодателя
+type ContactOpening struct {
+	ResumeID string    `json:"resume_id"`      // Идентификатор резюме
+	Name     string    `json:"name,omitempty"` // ФИО соискателя
+	URL      string    `json:"url,omitempty"`  // Ссылка на резюме
+	OpenedAt time.Time `json:"opened_at"`      // Время открытия
+}
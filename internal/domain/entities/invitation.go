@@ -0,0 +1,14 @@
+package entities
+
+import "time"
+
+// Invitation - приглашение соискателя на вакансию
+// Записывается в журнал приглашений, чтобы никого не пригласить дважды
+type Invitation struct {
+	ResumeID      string    `json:"resume_id"`                // Идентификатор резюме
+	VacancyID     string    `json:"vacancy_id"`               // Идентификатор вакансии
+	NegotiationID string    `json:"negotiation_id,omitempty"` // Идентификатор отклика hh.ru (пусто, если приглашение отправлено раньше)
+	Name          string    `json:"name,omitempty"`           // ФИО соискателя на момент приглашения
+	Message       string    `json:"message,omitempty"`        // Текст отправленного сообщения
+	InvitedAt     time.Time `json:"invited_at"`               // Время приглашения
+}
@@ -1,23 +1,98 @@
 package entities
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // Resume - основная сущность резюме
 // Представляет резюме соискателя с полной информацией
 type Resume struct {
-	ID          string    `json:"id"`                    // Уникальный идентификатор резюме
-	Name        string    `json:"name,omitempty"`        // ФИО соискателя
-	Skills      []string  `json:"skills,omitempty"`      // Навыки и технологии
-	Experience  []Job     `json:"experience,omitempty"`  // Опыт работы
-	Education   []Edu     `json:"education,omitempty"`   // Образование
-	LastUpdate  time.Time `json:"last_update"`           // Дата последнего обновления
-	Contact     Contact   `json:"contact,omitempty"`     // Контактная информация
-	URL         string    `json:"url,omitempty"`         // Ссылка на резюме
-	Title       string    `json:"title,omitempty"`       // Заголовок резюме
-	Salary      *Salary   `json:"salary,omitempty"`      // Желаемая зарплата
-	Location    string    `json:"location,omitempty"`    // Местоположение соискателя
-	Age         int       `json:"age,omitempty"`         // Возраст соискателя
-	Gender      string    `json:"gender,omitempty"`      // Пол соискателя
+	ID         string    `json:"id"`                   // Уникальный идентификатор резюме
+	Name       string    `json:"name,omitempty"`       // ФИО соискателя
+	Skills     []string  `json:"skills,omitempty"`     // Навыки и технологии
+	Experience []Job     `json:"experience,omitempty"` // Опыт работы
+	Education  []Edu     `json:"education,omitempty"`  // Образование
+	LastUpdate time.Time `json:"last_update"`          // Дата последнего обновления
+	Contact    Contact   `json:"contact,omitempty"`    // Контактная информация
+	URL        string    `json:"url,omitempty"`        // Ссылка на резюме
+	Title      string    `json:"title,omitempty"`      // Заголовок резюме
+	Salary     *Salary   `json:"salary,omitempty"`     // Желаемая зарплата
+	Location   string    `json:"location,omitempty"`   // Местоположение соискателя
+	Age        int       `json:"age,omitempty"`        // Возраст соискателя
+	Gender     string    `json:"gender,omitempty"`     // Пол соискателя
+
+	AreaID                string        `json:"area_id,omitempty"`                 // ID региона проживания hh.ru
+	About                 string        `json:"about,omitempty"`                   // Свободный текст блока «Обо мне»
+	TotalExperience       int           `json:"total_experience_months,omitempty"` // Общий стаж в месяцах по данным hh.ru
+	ProfessionalRoles     []string      `json:"professional_roles,omitempty"`      // Профессиональные роли
+	Specializations       []string      `json:"specializations,omitempty"`         // Специализации (устаревший классификатор hh.ru)
+	Languages             []Language    `json:"languages,omitempty"`               // Знание языков
+	Citizenship           []string      `json:"citizenship,omitempty"`             // Гражданство
+	WorkTicket            []string      `json:"work_ticket,omitempty"`             // Разрешение на работу
+	Relocation            *Relocation   `json:"relocation,omitempty"`              // Готовность к переезду
+	Employment            []string      `json:"employment,omitempty"`              // Желаемые типы занятости
+	Schedule              []string      `json:"schedule,omitempty"`                // Желаемые графики работы
+	BusinessTripReadiness string        `json:"business_trip_readiness,omitempty"` // Готовность к командировкам
+	Certificates          []Certificate `json:"certificates,omitempty"`            // Сертификаты
+	EducationLevel        string        `json:"education_level,omitempty"`         // Уровень образования
+
+	// SkillsOriginal - навыки в написании соискателя, если Skills приведены к каноническим названиям
+	SkillsOriginal []string `json:"skills_original,omitempty"`
+
+	// InferredSkills - навыки, упомянутые в заголовке и опыте работы, но не указанные соискателем
+	InferredSkills []InferredSkill `json:"inferred_skills,omitempty"`
+
+	// Score - оценка по профилю вакансии с пояснением по составляющим (при оценке резюме)
+	Score *ResumeScore `json:"score,omitempty"`
+
+	// MatchedKeywords - ключевые слова, по которым нашлось резюме (при поиске по каждому слову)
+	MatchedKeywords []string `json:"matched_keywords,omitempty"`
+
+	// Source - имя источника, из которого получено резюме (при сборе из нескольких источников)
+	Source string `json:"source,omitempty"`
+
+	// Changes - отличия от ранее сохраненной версии, если резюме обновилось с прошлого запуска
+	Changes []FieldChange `json:"changes,omitempty"`
+}
+
+// Где найден навык, не указанный соискателем
+const (
+	SkillSourceTitle       = "title"       // Заголовок резюме
+	SkillSourcePosition    = "position"    // Должность в опыте работы
+	SkillSourceDescription = "description" // Описание обязанностей
+)
+
+// InferredSkill - навык, найденный в тексте резюме
+type InferredSkill struct {
+	Name       string  `json:"name"`       // Каноническое название навыка
+	Source     string  `json:"source"`     // Где найден (SkillSourceTitle, ...), при нескольких упоминаниях - самое надежное
+	Confidence float64 `json:"confidence"` // Уверенность от 0 до 1
+}
+
+// String - описание навыка: "Kafka (description, 0.70)"
+func (s InferredSkill) String() string {
+	return fmt.Sprintf("%s (%s, %.2f)", s.Name, s.Source, s.Confidence)
+}
+
+// Language - знание языка
+type Language struct {
+	Name  string `json:"name"`            // Язык
+	Level string `json:"level,omitempty"` // Уровень владения
+}
+
+// Relocation - готовность к переезду
+type Relocation struct {
+	Type  string   `json:"type"`            // Отношение к переезду
+	Areas []string `json:"areas,omitempty"` // Регионы, в которые соискатель готов переехать
+}
+
+// Certificate - сертификат соискателя
+type Certificate struct {
+	Title      string `json:"title"`                 // Название
+	Type       string `json:"type,omitempty"`        // Тип (custom - загружен соискателем)
+	AchievedAt string `json:"achieved_at,omitempty"` // Дата получения
+	URL        string `json:"url,omitempty"`         // Ссылка на сертификат
 }
 
 // Job - опыт работы
@@ -71,8 +146,11 @@ func (r *Resume) IsValid() bool {
 }
 
 // GetExperienceYears - возвращает общий стаж работы в годах
-// Подсчитывает примерное количество лет опыта на основе дат
+// Использует стаж, посчитанный hh.ru, а при его отсутствии - примерную оценку
 func (r *Resume) GetExperienceYears() int {
+	if r.TotalExperience > 0 {
+		return r.TotalExperience / 12
+	}
 	if len(r.Experience) == 0 {
 		return 0
 	}
@@ -99,7 +177,7 @@ func (r *Resume) GetLatestJob() *Job {
 	if len(r.Experience) == 0 {
 		return nil
 	}
-	
+
 	// Возвращаем первую запись (предполагается, что они отсортированы по дате)
 	return &r.Experience[0]
 }
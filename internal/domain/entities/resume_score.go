@@ -0,0 +1,44 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResumeScore - оценка резюме по профилю вакансии
+// Итог складывается из баллов составляющих, максимум каждой задается ее весом в профиле
+type ResumeScore struct {
+	Profile    string           `json:"profile,omitempty"` // Название профиля вакансии
+	Total      float64          `json:"total"`             // Итоговая оценка от 0 до 100
+	Components []ScoreComponent `json:"components"`        // Составляющие оценки
+}
+
+// ScoreComponent - составляющая оценки резюме
+type ScoreComponent struct {
+	Name      string  `json:"name"`              // required_skills, nice_to_have_skills, experience, salary, location, freshness
+	Value     float64 `json:"value"`             // Насколько резюме соответствует требованию, от 0 до 1
+	Points    float64 `json:"points"`            // Баллы в итоговой оценке
+	MaxPoints float64 `json:"max_points"`        // Максимум баллов составляющей
+	Details   string  `json:"details,omitempty"` // Пояснение: найденные и недостающие навыки, стаж, ...
+}
+
+// String - описание составляющей: "experience 15.0/15.0 (5 лет, минимум 3)"
+func (c ScoreComponent) String() string {
+	description := fmt.Sprintf("%s %.1f/%.1f", c.Name, c.Points, c.MaxPoints)
+	if c.Details != "" {
+		description += " (" + c.Details + ")"
+	}
+	return description
+}
+
+// Breakdown - составляющие оценки одной строкой через " | "
+func (s *ResumeScore) Breakdown() string {
+	if s == nil {
+		return ""
+	}
+	parts := make([]string, len(s.Components))
+	for i, component := range s.Components {
+		parts[i] = component.String()
+	}
+	return strings.Join(parts, " | ")
+}
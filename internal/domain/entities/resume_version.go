@@ -0,0 +1,222 @@
+package entities
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ResumeVersion - сохраненная версия резюме
+// Версии одного резюме образуют историю: каждая следующая хранит отличия от предыдущей
+type ResumeVersion struct {
+	ID         string        `json:"id"`                // Идентификатор резюме
+	Version    int           `json:"version"`           // Номер версии, начиная с 1
+	Hash       string        `json:"hash"`              // Хэш содержимого (см. ContentHash)
+	LastUpdate time.Time     `json:"last_update"`       // Дата обновления резюме на hh.ru
+	SavedAt    time.Time     `json:"saved_at"`          // Время получения версии
+	Changes    []FieldChange `json:"changes,omitempty"` // Отличия от предыдущей версии
+	Resume     Resume        `json:"resume"`            // Содержимое версии
+}
+
+// FieldChange - изменение одного поля резюме между версиями
+// Для списков (навыки, опыт, языки) заполняются Added и Removed, для остальных полей - From и To
+type FieldChange struct {
+	Field   string   `json:"field"`             // Поле резюме в формате JSON вывода (skills, salary, ...)
+	From    string   `json:"from,omitempty"`    // Прежнее значение
+	To      string   `json:"to,omitempty"`      // Новое значение
+	Added   []string `json:"added,omitempty"`   // Добавленные элементы списка
+	Removed []string `json:"removed,omitempty"` // Удаленные элементы списка
+}
+
+// String - описание изменения: "skills: +Kubernetes, -PHP", "salary: 250000 RUR → 300000 RUR"
+func (c FieldChange) String() string {
+	if len(c.Added) > 0 || len(c.Removed) > 0 {
+		parts := make([]string, 0, len(c.Added)+len(c.Removed))
+		for _, item := range c.Added {
+			parts = append(parts, "+"+item)
+		}
+		for _, item := range c.Removed {
+			parts = append(parts, "-"+item)
+		}
+		return c.Field + ": " + strings.Join(parts, ", ")
+	}
+
+	from, to := c.From, c.To
+	if from == "" {
+		from = "—"
+	}
+	if to == "" {
+		to = "—"
+	}
+	return c.Field + ": " + from + " → " + to
+}
+
+// ContentHash - хэш содержимого резюме
+// Не учитывает дату обновления, контакты (они зависят от того, открывались ли они),
+// найденные в тексте навыки, оценку и служебные поля запуска: совпадение хэшей означает, что резюме по существу не менялось.
+// Навыки учитываются в написании соискателя, а не в канонических названиях словаря
+func ContentHash(resume Resume) string {
+	// Канонические названия зависят от словаря навыков, а не от резюме
+	if len(resume.SkillsOriginal) > 0 {
+		resume.Skills = resume.SkillsOriginal
+	}
+	resume.SkillsOriginal = nil
+	resume.LastUpdate = time.Time{}
+	resume.Contact = Contact{}
+	resume.MatchedKeywords = nil
+	resume.Source = ""
+	resume.Changes = nil
+	resume.InferredSkills = nil // Зависят от словаря навыков, а не от резюме
+	resume.Score = nil
+
+	data, _ := json.Marshal(resume)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// DiffResumes - отличия новой версии резюме от прежней по полям
+func DiffResumes(old, new Resume) []FieldChange {
+	var changes []FieldChange
+	value := func(field, from, to string) {
+		if from != to {
+			changes = append(changes, FieldChange{Field: field, From: from, To: to})
+		}
+	}
+	list := func(field string, from, to []string) {
+		if added, removed := diffLists(from, to); len(added) > 0 || len(removed) > 0 {
+			changes = append(changes, FieldChange{Field: field, Added: added, Removed: removed})
+		}
+	}
+
+	value("title", old.Title, new.Title)
+	value("name", old.Name, new.Name)
+	value("salary", formatSalary(old.Salary), formatSalary(new.Salary))
+	value("location", old.Location, new.Location)
+	value("age", formatNumber(old.Age), formatNumber(new.Age))
+	value("gender", old.Gender, new.Gender)
+	list("skills", old.Skills, new.Skills)
+	list("skills_original", old.SkillsOriginal, new.SkillsOriginal)
+	list("experience", jobTitles(old.Experience), jobTitles(new.Experience))
+	list("education", eduTitles(old.Education), eduTitles(new.Education))
+	value("education_level", old.EducationLevel, new.EducationLevel)
+	value("total_experience_months", formatNumber(old.TotalExperience), formatNumber(new.TotalExperience))
+	list("professional_roles", old.ProfessionalRoles, new.ProfessionalRoles)
+	list("specializations", old.Specializations, new.Specializations)
+	list("languages", languageTitles(old.Languages), languageTitles(new.Languages))
+	list("citizenship", old.Citizenship, new.Citizenship)
+	list("work_ticket", old.WorkTicket, new.WorkTicket)
+	value("relocation", formatRelocation(old.Relocation), formatRelocation(new.Relocation))
+	list("employment", old.Employment, new.Employment)
+	list("schedule", old.Schedule, new.Schedule)
+	value("business_trip_readiness", old.BusinessTripReadiness, new.BusinessTripReadiness)
+	list("certificates", certificateTitles(old.Certificates), certificateTitles(new.Certificates))
+	if old.About != new.About {
+		// Свободный текст целиком не показывается, отмечается только факт изменения
+		changes = append(changes, FieldChange{Field: "about", From: textLength(old.About), To: textLength(new.About)})
+	}
+
+	return changes
+}
+
+// diffLists - элементы, добавленные в список и удаленные из него, с сохранением порядка
+func diffLists(from, to []string) (added, removed []string) {
+	before := make(map[string]bool, len(from))
+	for _, item := range from {
+		before[item] = true
+	}
+	after := make(map[string]bool, len(to))
+	for _, item := range to {
+		after[item] = true
+		if !before[item] {
+			added = append(added, item)
+		}
+	}
+	for _, item := range from {
+		if !after[item] {
+			removed = append(removed, item)
+		}
+	}
+	return added, removed
+}
+
+// formatSalary - зарплата для описания изменений: "250000 RUR"
+func formatSalary(salary *Salary) string {
+	if salary == nil || salary.Amount == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d %s", salary.Amount, salary.Currency)
+}
+
+// formatNumber - число для описания изменений; 0 означает, что значение не указано
+func formatNumber(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
+// formatRelocation - готовность к переезду для описания изменений
+func formatRelocation(relocation *Relocation) string {
+	if relocation == nil {
+		return ""
+	}
+	if len(relocation.Areas) == 0 {
+		return relocation.Type
+	}
+	return relocation.Type + " (" + strings.Join(relocation.Areas, ", ") + ")"
+}
+
+// textLength - длина свободного текста для описания изменений
+func textLength(text string) string {
+	if text == "" {
+		return ""
+	}
+	return fmt.Sprintf("%d символов", len([]rune(text)))
+}
+
+// jobTitles - места работы для сравнения версий: "Должность, Компания (начало - окончание)"
+func jobTitles(jobs []Job) []string {
+	titles := make([]string, len(jobs))
+	for i, job := range jobs {
+		title := strings.Trim(job.Position+", "+job.Company, ", ")
+		if job.StartDate != "" || job.EndDate != "" {
+			title += " (" + job.StartDate + " - " + job.EndDate + ")"
+		}
+		titles[i] = title
+	}
+	return titles
+}
+
+// eduTitles - образование для сравнения версий
+func eduTitles(education []Edu) []string {
+	titles := make([]string, len(education))
+	for i, edu := range education {
+		titles[i] = strings.Trim(edu.Institution+", "+edu.Specialty+", "+edu.Year, ", ")
+	}
+	return titles
+}
+
+// languageTitles - языки для сравнения версий: "Английский (B2)"
+func languageTitles(languages []Language) []string {
+	titles := make([]string, len(languages))
+	for i, language := range languages {
+		titles[i] = language.Name
+		if language.Level != "" {
+			titles[i] += " (" + language.Level + ")"
+		}
+	}
+	return titles
+}
+
+// certificateTitles - сертификаты для сравнения версий
+func certificateTitles(certificates []Certificate) []string {
+	titles := make([]string, len(certificates))
+	for i, certificate := range certificates {
+		titles[i] = certificate.Title
+	}
+	return titles
+}
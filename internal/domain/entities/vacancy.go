@@ -0,0 +1,49 @@
+package entities
+
+import "time"
+
+// Vacancy - вакансия работодателя
+// Используется для отслеживания вакансий конкурентов по тем же ключевым словам
+type Vacancy struct {
+	ID                string       `json:"id"`                           // Уникальный идентификатор вакансии
+	Name              string       `json:"name"`                         // Название вакансии
+	Employer          Employer     `json:"employer"`                     // Работодатель
+	Salary            *SalaryRange `json:"salary,omitempty"`             // Предлагаемая зарплата
+	Location          string       `json:"location,omitempty"`           // Регион вакансии
+	AreaID            string       `json:"area_id,omitempty"`            // ID региона hh.ru
+	Experience        string       `json:"experience,omitempty"`         // Требуемый опыт работы
+	Employment        string       `json:"employment,omitempty"`         // Тип занятости
+	Schedule          string       `json:"schedule,omitempty"`           // График работы
+	KeySkills         []string     `json:"key_skills,omitempty"`         // Ключевые навыки
+	ProfessionalRoles []string     `json:"professional_roles,omitempty"` // Профессиональные роли
+	Requirement       string       `json:"requirement,omitempty"`        // Краткие требования из выдачи
+	Responsibility    string       `json:"responsibility,omitempty"`     // Краткие обязанности из выдачи
+	Description       string       `json:"description,omitempty"`        // Полное описание (текст без разметки)
+	PublishedAt       time.Time    `json:"published_at"`                 // Дата публикации
+	URL               string       `json:"url,omitempty"`                // Ссылка на вакансию
+	Archived          bool         `json:"archived,omitempty"`           // Вакансия в архиве
+
+	// MatchedKeywords - ключевые слова, по которым нашлась вакансия (при поиске по каждому слову)
+	MatchedKeywords []string `json:"matched_keywords,omitempty"`
+}
+
+// Employer - работодатель
+type Employer struct {
+	ID   string `json:"id,omitempty"`  // Идентификатор работодателя
+	Name string `json:"name"`          // Название компании
+	URL  string `json:"url,omitempty"` // Ссылка на страницу работодателя
+}
+
+// SalaryRange - вилка зарплаты в вакансии
+type SalaryRange struct {
+	From     int    `json:"from,omitempty"` // Нижняя граница
+	To       int    `json:"to,omitempty"`   // Верхняя граница
+	Currency string `json:"currency"`       // Валюта (RUR, USD, EUR)
+	Gross    bool   `json:"gross"`          // До налогов (true) или после (false)
+}
+
+// IsValid - проверяет валидность вакансии
+// Возвращает true, если вакансия содержит минимально необходимую информацию
+func (v *Vacancy) IsValid() bool {
+	return v.ID != "" && v.Name != ""
+}
@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"context"
+	"time"
+)
+
+// CheckpointStore - хранилище контрольной точки запуска
+// Контрольная точка сохраняется после каждой страницы выдачи и позволяет продолжить
+// прерванный запуск, не запрашивая уже пройденные страницы повторно
+type CheckpointStore interface {
+	// Load - чтение контрольной точки; nil без ошибки, если ее нет
+	Load(ctx context.Context) (*Checkpoint, error)
+
+	// Save - запись контрольной точки
+	Save(ctx context.Context, checkpoint *Checkpoint) error
+
+	// Clear - удаление контрольной точки после успешного завершения запуска
+	Clear(ctx context.Context) error
+}
+
+// Checkpoint - состояние прерванного запуска
+type Checkpoint struct {
+	CriteriaHash string            `json:"criteria_hash"` // Хэш критериев поиска, для которых создана точка
+	StartedAt    time.Time         `json:"started_at"`    // Начало первого запуска
+	UpdatedAt    time.Time         `json:"updated_at"`    // Время последнего сохранения
+	Queries      []QueryCheckpoint `json:"queries"`       // Прогресс обхода по запросам
+	ProcessedIDs []string          `json:"processed_ids"` // Идентификаторы уже сохраненных записей
+}
+
+// QueryCheckpoint - прогресс обхода одного запроса (источника и ключевого слова)
+// План поиска сохраняется целиком, чтобы при продолжении части запроса совпадали с исходными
+type QueryCheckpoint struct {
+	Key    string            `json:"key"`    // Источник и ключевое слово
+	Plan   SearchPlan        `json:"plan"`   // План поиска первого запуска
+	Slices []SliceCheckpoint `json:"slices"` // Прогресс по частям плана
+}
+
+// SliceCheckpoint - прогресс обхода одной части запроса
+type SliceCheckpoint struct {
+	LastPage int  `json:"last_page"` // Последняя завершенная страница (-1 - ни одной)
+	Done     bool `json:"done"`      // Обход части завершен
+}
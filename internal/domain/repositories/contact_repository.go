@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"context"
+
+	"hh-resume-parser/internal/domain/entities"
+)
+
+// ContactlessResumeReader - источник, который может отдать полное резюме без открытия контактов
+// GetResumeByID таких источников открывает контакты и может расходовать платные просмотры
+type ContactlessResumeReader interface {
+	// GetResumeWithoutContacts - полное резюме без контактов соискателя
+	GetResumeWithoutContacts(ctx context.Context, id string) (*entities.Resume, error)
+}
+
+// ContactLedger - журнал открытий контактов соискателей
+// Используется для учета расхода платных просмотров по дням и месяцам
+type ContactLedger interface {
+	// Openings - все записанные открытия контактов
+	Openings(ctx context.Context) ([]entities.ContactOpening, error)
+
+	// RecordOpening - запись открытия контактов
+	RecordOpening(ctx context.Context, opening entities.ContactOpening) error
+}
@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Категории ошибок внешнего API
+// Используются вызывающим кодом для выбора реакции: повторить, пропустить или прервать
+var (
+	ErrUnauthorized = errors.New("не авторизован")            // Токен отсутствует, истек или отозван
+	ErrForbidden    = errors.New("доступ запрещен")           // Недостаточно прав для операции
+	ErrRateLimited  = errors.New("превышен лимит запросов")   // Слишком много запросов или исчерпана квота
+	ErrNotFound     = errors.New("ресурс не найден")          // Запрошенный ресурс не существует
+	ErrBadArgument  = errors.New("некорректный параметр")     // Неверные параметры запроса
+	ErrServer       = errors.New("ошибка на стороне сервера") // Ошибка 5xx
+	ErrUnexpected   = errors.New("неожиданный ответ сервера") // Прочие статусы
+)
+
+// ErrUnknownArea - город или регион не найден в справочнике источника
+var ErrUnknownArea = errors.New("неизвестный регион")
+
+// ErrAlreadyInvited - соискатель уже приглашен на вакансию или сам откликнулся на нее
+var ErrAlreadyInvited = errors.New("соискатель уже приглашен")
+
+// ErrContactBudgetExhausted - исчерпан бюджет открытий контактов соискателей
+var ErrContactBudgetExhausted = errors.New("исчерпан бюджет открытий контактов")
+
+// ErrCacheMiss - ключ отсутствует в кэше или срок его хранения истек
+var ErrCacheMiss = errors.New("нет данных в кэше")
+
+// APIError - ошибка, возвращенная внешним API
+// Оборачивает одну из категорий ошибок и сохраняет подробности ответа
+type APIError struct {
+	Kind       error            // Категория ошибки (ErrUnauthorized, ErrForbidden и т.д.)
+	StatusCode int              // HTTP статус ответа
+	Details    []APIErrorDetail // Подробности из тела ответа
+	RetryAfter time.Duration    // Рекомендованная пауза перед повтором (заголовок Retry-After)
+	RequestID  string           // Идентификатор запроса на стороне API
+}
+
+// APIErrorDetail - элемент списка errors[] из ответа API
+type APIErrorDetail struct {
+	Type  string `json:"type"`  // Тип ошибки
+	Value string `json:"value"` // Уточнение (например, имя некорректного параметра)
+}
+
+// Error - текстовое представление ошибки
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("API вернул статус %d: %v", e.StatusCode, e.Kind)
+
+	if len(e.Details) > 0 {
+		details := make([]string, 0, len(e.Details))
+		for _, detail := range e.Details {
+			if detail.Value != "" {
+				details = append(details, detail.Type+"="+detail.Value)
+			} else {
+				details = append(details, detail.Type)
+			}
+		}
+		msg += " (" + strings.Join(details, ", ") + ")"
+	}
+
+	return msg
+}
+
+// Unwrap - категория ошибки для errors.Is
+func (e *APIError) Unwrap() error {
+	return e.Kind
+}
+
+// IsRetryable - проверка, имеет ли смысл повторить запрос
+func IsRetryable(err error) bool {
+	return errors.Is(err, ErrRateLimited) || errors.Is(err, ErrServer)
+}
+
+// IsFatal - проверка, что продолжать работу с текущими учетными данными бессмысленно
+func IsFatal(err error) bool {
+	return errors.Is(err, ErrUnauthorized) || errors.Is(err, ErrForbidden)
+}
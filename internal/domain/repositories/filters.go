@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SearchFilters - дополнительные фильтры поиска
+// Пустые значения не ограничивают выдачу; значения передаются в API как есть
+// и проверяются по справочникам источника (см. DictionaryProvider)
+type SearchFilters struct {
+	SalaryFrom int    // Нижняя граница желаемой зарплаты
+	SalaryTo   int    // Верхняя граница желаемой зарплаты
+	Currency   string // Валюта зарплаты (RUR, USD, EUR, ...)
+
+	AgeFrom int    // Минимальный возраст
+	AgeTo   int    // Максимальный возраст
+	Gender  string // Пол (male, female)
+
+	EducationLevels []string         // Уровни образования (higher, bachelor, ...)
+	Employment      []string         // Типы занятости (full, part, project, ...)
+	Schedule        []string         // Графики работы (fullDay, remote, ...)
+	Relocation      string           // Готовность к переезду (living_or_relocation, relocation, ...)
+	Languages       []LanguageFilter // Знание языков
+	JobSearchStatus []string         // Статусы поиска работы (active_search, looking_for_offers, ...)
+	OrderBy         string           // Сортировка выдачи (publication_time, salary_desc, relevance, ...)
+}
+
+// LanguageFilter - требование к знанию языка
+type LanguageFilter struct {
+	ID    string // Код языка hh.ru (eng, deu, ...)
+	Level string // Минимальный уровень (a1 ... c2, l1 - родной); пусто - любой
+}
+
+// String - представление в формате параметра language API hh.ru: "eng.b2"
+func (l LanguageFilter) String() string {
+	if l.Level == "" {
+		return l.ID
+	}
+	return l.ID + "." + l.Level
+}
+
+// ParseLanguageFilter - разбор требования к языку в формате "eng" или "eng.b2"
+func ParseLanguageFilter(value string) (LanguageFilter, error) {
+	id, level, _ := strings.Cut(strings.TrimSpace(value), ".")
+	if id == "" {
+		return LanguageFilter{}, fmt.Errorf("%w: не указан язык в %q", ErrBadArgument, value)
+	}
+	return LanguageFilter{ID: strings.ToLower(id), Level: strings.ToLower(level)}, nil
+}
@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"context"
+
+	"hh-resume-parser/internal/domain/entities"
+)
+
+// ResumeHistoryStore - история версий резюме
+// Хранит каждую полученную версию резюме вместе с отличиями от предыдущей, поэтому
+// обновленное резюме можно сравнить с сохраненным и показать, что в нем изменилось
+type ResumeHistoryStore interface {
+	// Latest - последние версии всех известных резюме по ID
+	Latest(ctx context.Context) (map[string]entities.ResumeVersion, error)
+
+	// Versions - все версии резюме по порядку; пустой список, если резюме неизвестно
+	Versions(ctx context.Context, id string) ([]entities.ResumeVersion, error)
+
+	// AppendVersions - запись новых версий резюме
+	// Запись с тем же хэшем, что и у последней версии, обновляет только дату обновления резюме
+	AppendVersions(ctx context.Context, versions []entities.ResumeVersion) error
+}
@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"context"
+
+	"hh-resume-parser/internal/domain/entities"
+)
+
+// NegotiationRepository - действия работодателя с откликами и приглашениями
+type NegotiationRepository interface {
+	// Invite - приглашение соискателя с резюме resumeID на вакансию vacancyID
+	// Возвращает идентификатор созданного отклика; ErrAlreadyInvited, если приглашение уже есть
+	Invite(ctx context.Context, vacancyID, resumeID, message string) (string, error)
+}
+
+// InvitationLedger - журнал отправленных приглашений
+// Используется для того, чтобы не приглашать соискателя на вакансию повторно
+type InvitationLedger interface {
+	// InvitedResumeIDs - идентификаторы резюме, уже приглашенных на вакансию
+	InvitedResumeIDs(ctx context.Context, vacancyID string) ([]string, error)
+
+	// RecordInvitation - запись отправленного приглашения
+	RecordInvitation(ctx context.Context, invitation entities.Invitation) error
+}
+
+// ResumeLoader - хранилище, из которого можно прочитать сохраненные резюме целиком
+type ResumeLoader interface {
+	// LoadResumes - чтение всех сохраненных резюме
+	LoadResumes(ctx context.Context) ([]entities.Resume, error)
+}
@@ -0,0 +1,52 @@
+package repositories
+
+// TextLogic - способ объединения слов внутри условия текстового запроса
+type TextLogic string
+
+const (
+	LogicAll    TextLogic = "all"    // Все слова (И)
+	LogicAny    TextLogic = "any"    // Любое из слов (ИЛИ)
+	LogicPhrase TextLogic = "phrase" // Точная фраза
+	LogicExcept TextLogic = "except" // Ни одного из слов (НЕ)
+)
+
+// ValidLogic - проверка способа объединения слов; пустое значение означает LogicAll
+func ValidLogic(logic TextLogic) bool {
+	switch logic {
+	case "", LogicAll, LogicAny, LogicPhrase, LogicExcept:
+		return true
+	}
+	return false
+}
+
+// TextClause - условие текстового запроса
+// Условия запроса объединяются по И; внутри условия слова объединяются по Logic.
+// Слово может само быть выражением языка запросов hh.ru, например "(Kafka OR RabbitMQ)"
+type TextClause struct {
+	Terms  []string  // Слова или фразы
+	Logic  TextLogic // Способ объединения слов (по умолчанию - все слова)
+	Field  string    // Где искать: everywhere, title, skills, experience, ... (пусто - везде)
+	Period string    // За какой период опыта искать: all_time, last_year, ... (пусто - за все время)
+}
+
+// Clauses - все условия текстового запроса критериев
+// Ключевые слова образуют первое условие, за ним следуют дополнительные условия Query
+func (c SearchCriteria) Clauses() []TextClause {
+	var clauses []TextClause
+	if len(c.Keywords) > 0 {
+		clauses = append(clauses, TextClause{
+			Terms:  c.Keywords,
+			Logic:  c.KeywordLogic,
+			Field:  c.TextField,
+			Period: c.TextPeriod,
+		})
+	}
+
+	for _, clause := range c.Query {
+		if len(clause.Terms) > 0 {
+			clauses = append(clauses, clause)
+		}
+	}
+
+	return clauses
+}
@@ -2,6 +2,8 @@ package repositories
 
 import (
 	"context"
+	"time"
+
 	"hh-resume-parser/internal/domain/entities"
 )
 
@@ -11,7 +13,7 @@ type ResumeRepository interface {
 	// SearchResumes - поиск резюме по заданным критериям
 	// Возвращает список резюме и ошибку, если поиск не удался
 	SearchResumes(ctx context.Context, criteria SearchCriteria) ([]entities.Resume, error)
-	
+
 	// GetResumeByID - получение резюме по идентификатору
 	// Возвращает детальную информацию о резюме
 	GetResumeByID(ctx context.Context, id string) (*entities.Resume, error)
@@ -23,30 +25,158 @@ type StorageRepository interface {
 	// SaveResumes - сохранение списка резюме
 	// Поддерживает различные форматы вывода
 	SaveResumes(ctx context.Context, resumes []entities.Resume) error
-	
+
 	// GetSavedResumeIDs - получение идентификаторов уже сохраненных резюме
 	// Используется для предотвращения дублирования
 	GetSavedResumeIDs(ctx context.Context) ([]string, error)
 }
 
+// ResumeStreamStorage - хранилище, в которое резюме записываются по мере сбора
+// Если хранилище его поддерживает, каждая обработанная страница выдачи записывается на диск
+// до запроса следующей, и прерванный запуск не теряет уже собранные резюме
+type ResumeStreamStorage interface {
+	// OpenResumes - начало записи резюме; файл вывода создается заново
+	OpenResumes(ctx context.Context) (ResumeBatchWriter, error)
+}
+
+// ResumeAppendStorage - хранилище, которое умеет продолжать запись после уже сохраненных резюме
+// Используется при продолжении прерванного запуска
+type ResumeAppendStorage interface {
+	// AppendResumes - продолжение записи в существующий файл вывода; если файла нет, он создается
+	AppendResumes(ctx context.Context) (ResumeBatchWriter, error)
+}
+
+// ResumeBatchWriter - постраничная запись резюме
+// После каждого AppendBatch записанные резюме сохранены на диске, а файл остается корректным
+// файлом своего формата. Commit завершает запись; Close освобождает файл и вызывается всегда,
+// в том числе после ошибки или без Commit
+type ResumeBatchWriter interface {
+	// AppendBatch - запись порции резюме
+	AppendBatch(ctx context.Context, resumes []entities.Resume) error
+
+	// Commit - завершение записи
+	Commit(ctx context.Context) error
+
+	// Close - закрытие файла; повторный вызов ничего не делает
+	Close() error
+}
+
 // SearchCriteria - критерии поиска резюме
 // Содержит параметры для фильтрации результатов поиска
 type SearchCriteria struct {
 	Keywords   []string // Ключевые слова для поиска
 	City       string   // Город поиска
+	AreaIDs    []string // Идентификаторы регионов, полученные из City
 	Experience string   // Требуемый опыт работы
 	UpdateDays int      // Количество дней с последнего обновления
 	Page       int      // Номер страницы результатов
 	PerPage    int      // Количество результатов на странице
+
+	// Текстовый запрос: ключевые слова и дополнительные условия, см. Clauses
+	KeywordLogic TextLogic    // Способ объединения ключевых слов (по умолчанию - все слова)
+	TextField    string       // Где искать ключевые слова (пусто - везде)
+	TextPeriod   string       // За какой период опыта искать ключевые слова (пусто - за все время)
+	Query        []TextClause // Дополнительные условия, объединяются с ключевыми словами по И
+
+	// Filters - зарплата, возраст, образование, занятость и другие фильтры
+	Filters SearchFilters
+
+	// Окно по дате обновления резюме, используется при разбиении запроса.
+	// Если окно задано, оно заменяет фильтр UpdateDays
+	DateFrom time.Time // Начало окна
+	DateTo   time.Time // Конец окна
+}
+
+// AreaResolver - интерфейс для преобразования названий городов и регионов в идентификаторы
+type AreaResolver interface {
+	// ResolveAreas - поиск регионов по названиям или идентификаторам
+	// Возвращает ошибку, если хотя бы один регион не найден
+	ResolveAreas(ctx context.Context, names []string) ([]string, error)
+}
+
+// DictionaryItem - допустимое значение фильтра поиска
+type DictionaryItem struct {
+	ID   string // Значение, которое передается в API
+	Name string // Человекочитаемое название
+}
+
+// DictionaryProvider - источник справочников допустимых значений фильтров поиска
+// Фильтры называются так же, как в конфигурации: experience, employment, schedule, ...
+type DictionaryProvider interface {
+	// Dictionaries - допустимые значения по названиям фильтров
+	Dictionaries(ctx context.Context) (map[string][]DictionaryItem, error)
+
+	// ValidateFilter - проверка значения фильтра
+	// Для недопустимого значения возвращается ErrBadArgument с ближайшими допустимыми вариантами
+	ValidateFilter(ctx context.Context, filter, value string) error
+}
+
+// SearchPlanner - интерфейс для разбиения больших запросов на части
+// Источники с ограничением глубины выдачи (hh.ru отдает не более ~2000 результатов)
+// делят запрос на части, каждая из которых укладывается в этот лимит
+type SearchPlanner interface {
+	// PlanSearch - построение плана поиска для заданных критериев
+	PlanSearch(ctx context.Context, criteria SearchCriteria) (*SearchPlan, error)
+}
+
+// SearchPlan - план выполнения поиска
+// Содержит части запроса и сведения о покрытии выдачи
+type SearchPlan struct {
+	Slices     []QuerySlice // Части запроса
+	TotalFound int          // Общее количество резюме по исходному запросу
+	Reachable  int          // Количество резюме, доступных с учетом лимита глубины
+	DepthLimit int          // Лимит глубины выдачи источника
+}
+
+// Coverage - доля резюме, которую удается получить по плану (от 0 до 1)
+func (p *SearchPlan) Coverage() float64 {
+	if p.TotalFound == 0 {
+		return 1
+	}
+	coverage := float64(p.Reachable) / float64(p.TotalFound)
+	if coverage > 1 {
+		return 1
+	}
+	return coverage
+}
+
+// QuerySlice - часть поискового запроса
+type QuerySlice struct {
+	Criteria  SearchCriteria // Критерии поиска для этой части
+	Found     int            // Количество резюме в части (0 - неизвестно)
+	Truncated bool           // Часть превышает лимит глубины и не может быть разбита дальше
 }
 
+// Pages - количество страниц, которые нужно запросить для части
+// Возвращает 0, если количество неизвестно и листать нужно до пустой страницы
+func (s QuerySlice) Pages(depthLimit int) int {
+	if s.Found <= 0 {
+		return 0
+	}
+
+	perPage := s.Criteria.PerPage
+	if perPage <= 0 {
+		perPage = DefaultPerPage
+	}
+
+	reachable := s.Found
+	if depthLimit > 0 && reachable > depthLimit {
+		reachable = depthLimit
+	}
+
+	return (reachable + perPage - 1) / perPage
+}
+
+// DefaultPerPage - количество результатов на странице по умолчанию
+const DefaultPerPage = 20
+
 // SearchResult - результат поиска резюме
 // Содержит найденные резюме и метаинформацию о поиске
 type SearchResult struct {
-	Resumes    []entities.Resume // Найденные резюме
-	TotalFound int               // Общее количество найденных резюме
-	TotalPages int               // Общее количество страниц
-	CurrentPage int              // Текущая страница
+	Resumes     []entities.Resume // Найденные резюме
+	TotalFound  int               // Общее количество найденных резюме
+	TotalPages  int               // Общее количество страниц
+	CurrentPage int               // Текущая страница
 }
 
 // CacheRepository - интерфейс для кэширования данных
@@ -54,13 +184,13 @@ type SearchResult struct {
 type CacheRepository interface {
 	// Get - получение данных из кэша
 	Get(ctx context.Context, key string) ([]byte, error)
-	
+
 	// Set - сохранение данных в кэш
 	Set(ctx context.Context, key string, value []byte, ttl int) error
-	
+
 	// Delete - удаление данных из кэша
 	Delete(ctx context.Context, key string) error
-	
+
 	// Exists - проверка существования ключа в кэше
 	Exists(ctx context.Context, key string) bool
 }
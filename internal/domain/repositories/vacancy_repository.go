@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"context"
+
+	"hh-resume-parser/internal/domain/entities"
+)
+
+// VacancyRepository - интерфейс для работы с источником вакансий
+// Критерии поиска те же, что и у резюме: ключевые слова, регионы, опыт, окно дат
+type VacancyRepository interface {
+	// SearchVacancies - поиск вакансий по заданным критериям
+	SearchVacancies(ctx context.Context, criteria SearchCriteria) ([]entities.Vacancy, error)
+
+	// GetVacancyByID - получение полной информации о вакансии
+	GetVacancyByID(ctx context.Context, id string) (*entities.Vacancy, error)
+}
+
+// VacancySearchPlanner - разбиение поиска вакансий на части в пределах лимита глубины выдачи
+type VacancySearchPlanner interface {
+	PlanVacancySearch(ctx context.Context, criteria SearchCriteria) (*SearchPlan, error)
+}
+
+// VacancyStorageRepository - интерфейс для сохранения вакансий
+type VacancyStorageRepository interface {
+	// SaveVacancies - сохранение списка вакансий
+	SaveVacancies(ctx context.Context, vacancies []entities.Vacancy) error
+
+	// GetSavedVacancyIDs - получение идентификаторов уже сохраненных вакансий
+	GetSavedVacancyIDs(ctx context.Context) ([]string, error)
+}
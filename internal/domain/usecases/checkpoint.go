@@ -0,0 +1,155 @@
+package usecases
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"hh-resume-parser/internal/domain/repositories"
+	"hh-resume-parser/internal/infrastructure/logger"
+)
+
+// runCheckpoint - контрольная точка текущего запуска
+// После каждой страницы выдачи сохраняется, какие страницы каждой части запроса уже пройдены
+// и какие записи сохранены. Методы допускают nil: контрольная точка не ведется
+type runCheckpoint struct {
+	store   repositories.CheckpointStore
+	state   *repositories.Checkpoint
+	queries map[string]int // Индексы запросов в state.Queries по ключу
+	logger  logger.Logger
+}
+
+// newRunCheckpoint - контрольная точка с заданным состоянием (новым или загруженным)
+func newRunCheckpoint(store repositories.CheckpointStore, state *repositories.Checkpoint, logger logger.Logger) *runCheckpoint {
+	c := &runCheckpoint{store: store, state: state, queries: make(map[string]int), logger: logger}
+	for i, query := range state.Queries {
+		c.queries[query.Key] = i
+	}
+	return c
+}
+
+// plan - план поиска, сохраненный для запроса; nil, если запрос еще не начинался
+func (c *runCheckpoint) plan(key string) *repositories.SearchPlan {
+	if c == nil {
+		return nil
+	}
+	i, ok := c.queries[key]
+	if !ok {
+		return nil
+	}
+	plan := c.state.Queries[i].Plan
+	return &plan
+}
+
+// startPlan - начало обхода плана поиска для запроса
+// План попадает в файл вместе с первой пройденной страницей
+func (c *runCheckpoint) startPlan(key string, plan *repositories.SearchPlan) {
+	if c == nil {
+		return
+	}
+	if _, ok := c.queries[key]; ok {
+		return
+	}
+
+	slices := make([]repositories.SliceCheckpoint, len(plan.Slices))
+	for i := range slices {
+		slices[i].LastPage = -1
+	}
+	c.queries[key] = len(c.state.Queries)
+	c.state.Queries = append(c.state.Queries, repositories.QueryCheckpoint{
+		Key:    key,
+		Plan:   *plan,
+		Slices: slices,
+	})
+}
+
+// slice - прогресс части запроса; nil, если он не отслеживается
+func (c *runCheckpoint) slice(key string, slice int) *repositories.SliceCheckpoint {
+	if c == nil {
+		return nil
+	}
+	i, ok := c.queries[key]
+	if !ok || slice >= len(c.state.Queries[i].Slices) {
+		return nil
+	}
+	return &c.state.Queries[i].Slices[slice]
+}
+
+// nextPage - страница, с которой продолжается обход части; done - часть уже пройдена
+func (c *runCheckpoint) nextPage(key string, slice int) (page int, done bool) {
+	progress := c.slice(key, slice)
+	if progress == nil {
+		return 0, false
+	}
+	return progress.LastPage + 1, progress.Done
+}
+
+// pageDone - отметка о завершении страницы и сохранении ее записей
+func (c *runCheckpoint) pageDone(ctx context.Context, key string, slice, page int, ids []string) error {
+	progress := c.slice(key, slice)
+	if progress == nil {
+		return nil
+	}
+	progress.LastPage = page
+	c.state.ProcessedIDs = append(c.state.ProcessedIDs, ids...)
+	return c.save(ctx)
+}
+
+// sliceDone - отметка о завершении обхода части запроса
+func (c *runCheckpoint) sliceDone(ctx context.Context, key string, slice int) error {
+	progress := c.slice(key, slice)
+	if progress == nil {
+		return nil
+	}
+	progress.Done = true
+	return c.save(ctx)
+}
+
+// save - запись контрольной точки
+func (c *runCheckpoint) save(ctx context.Context) error {
+	c.state.UpdatedAt = time.Now()
+	if err := c.store.Save(ctx, c.state); err != nil {
+		return fmt.Errorf("ошибка сохранения контрольной точки: %w", err)
+	}
+	return nil
+}
+
+// finish - завершение запуска
+// Если запуск пройден до конца, контрольная точка удаляется, иначе остается для продолжения
+func (c *runCheckpoint) finish(ctx context.Context, completed bool) {
+	if c == nil {
+		return
+	}
+
+	if !completed {
+		c.logger.Info("Прогресс сохранен в контрольной точке, запуск можно продолжить", map[string]interface{}{
+			"saved":   len(c.state.ProcessedIDs),
+			"queries": len(c.state.Queries),
+		})
+		return
+	}
+	if err := c.store.Clear(ctx); err != nil {
+		c.logger.Error("Ошибка удаления контрольной точки", err)
+	}
+}
+
+// criteriaHash - отпечаток запуска: критерии поиска, источники и режим поиска по ключевым словам
+// Продолжить можно только запуск с тем же отпечатком
+func criteriaHash(criteria repositories.SearchCriteria, sources []Source, perKeyword bool) string {
+	names := make([]string, len(sources))
+	for i, source := range sources {
+		names[i] = source.Name
+	}
+
+	data, _ := json.Marshal(struct {
+		Criteria   repositories.SearchCriteria
+		Sources    []string
+		PerKeyword bool
+	}{criteria, names, perKeyword})
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
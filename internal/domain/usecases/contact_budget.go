@@ -0,0 +1,205 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"hh-resume-parser/internal/domain/entities"
+	"hh-resume-parser/internal/domain/repositories"
+	"hh-resume-parser/internal/infrastructure/logger"
+)
+
+// Реакция на исчерпание бюджета открытий контактов
+const (
+	ContactsBlock     = "block"     // Не загружать полное резюме, оставить данные из выдачи
+	ContactsDowngrade = "downgrade" // Загрузить полное резюме без контактов
+)
+
+// ContactLimits - бюджет открытий контактов
+type ContactLimits struct {
+	Daily       int    // Открытий в сутки (0 - без ограничения)
+	Monthly     int    // Открытий в календарный месяц (0 - без ограничения)
+	OnExhausted string // ContactsBlock или ContactsDowngrade
+}
+
+// ContactBudget - учет и ограничение открытий контактов соискателей
+// Каждое открытие записывается в журнал; повторное открытие уже открытых контактов
+// не расходует бюджет. Безопасен для одновременного использования из нескольких горутин
+type ContactBudget struct {
+	ledger repositories.ContactLedger
+	limits ContactLimits
+	logger logger.Logger
+	now    func() time.Time
+
+	mu         sync.Mutex
+	loaded     bool
+	opened     map[string]bool // Резюме, контакты которых уже открыты
+	spent      []time.Time     // Время открытий, включая выполняющиеся
+	blocked    int             // Полные резюме, не загруженные из-за бюджета
+	downgraded int             // Полные резюме, загруженные без контактов из-за бюджета
+	exhausted  bool            // Исчерпание бюджета уже записано в лог
+}
+
+// ContactUsage - расход открытий контактов
+type ContactUsage struct {
+	Today        int // Открыто сегодня
+	Month        int // Открыто в текущем месяце
+	DailyLimit   int // Бюджет на сутки (0 - без ограничения)
+	MonthlyLimit int // Бюджет на месяц (0 - без ограничения)
+	Blocked      int // Не загружено полных резюме за время работы
+	Downgraded   int // Загружено полных резюме без контактов за время работы
+}
+
+// NewContactBudget - создание учета открытий контактов с журналом ledger
+func NewContactBudget(ledger repositories.ContactLedger, limits ContactLimits, logger logger.Logger) *ContactBudget {
+	return &ContactBudget{
+		ledger: ledger,
+		limits: limits,
+		logger: logger,
+		now:    time.Now,
+		opened: make(map[string]bool),
+	}
+}
+
+// Fetch - загрузка полного резюме с открытием контактов в пределах бюджета
+// withContacts открывает контакты; withoutContacts (может отсутствовать) загружает резюме без них.
+// Когда бюджет исчерпан, резюме загружается без контактов или возвращается ErrContactBudgetExhausted
+func (b *ContactBudget) Fetch(ctx context.Context, id string,
+	withContacts, withoutContacts func(ctx context.Context, id string) (*entities.Resume, error)) (*entities.Resume, error) {
+	b.mu.Lock()
+	if err := b.load(ctx); err != nil {
+		b.mu.Unlock()
+		return nil, err
+	}
+
+	if b.opened[id] {
+		b.mu.Unlock()
+		return withContacts(ctx, id)
+	}
+
+	now := b.now()
+	if !b.available(now) {
+		downgrade := b.limits.OnExhausted != ContactsBlock && withoutContacts != nil
+		if downgrade {
+			b.downgraded++
+		} else {
+			b.blocked++
+		}
+		if !b.exhausted {
+			b.exhausted = true
+			b.logger.Warn("Бюджет открытий контактов исчерпан", map[string]interface{}{
+				"daily_limit":   b.limits.Daily,
+				"monthly_limit": b.limits.Monthly,
+				"downgrade":     downgrade,
+			})
+		}
+		b.mu.Unlock()
+
+		if downgrade {
+			return withoutContacts(ctx, id)
+		}
+		return nil, fmt.Errorf("%w: резюме %s", repositories.ErrContactBudgetExhausted, id)
+	}
+
+	// Открытие резервируется до запроса, чтобы параллельные загрузки не превысили бюджет
+	b.opened[id] = true
+	b.spent = append(b.spent, now)
+	b.mu.Unlock()
+
+	resume, err := withContacts(ctx, id)
+	if err != nil {
+		b.release(id, now)
+		return nil, err
+	}
+
+	opening := entities.ContactOpening{ResumeID: id, Name: resume.Name, URL: resume.URL, OpenedAt: now}
+	if err := b.ledger.RecordOpening(ctx, opening); err != nil {
+		// Расход учтен в памяти и ограничит текущий запуск, но следующий о нем не узнает
+		b.logger.Error("Ошибка записи журнала открытий контактов", err)
+	}
+
+	return resume, nil
+}
+
+// Usage - текущий расход открытий контактов
+func (b *ContactBudget) Usage(ctx context.Context) (ContactUsage, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.load(ctx); err != nil {
+		return ContactUsage{}, err
+	}
+
+	today, month := b.count(b.now())
+	return ContactUsage{
+		Today:        today,
+		Month:        month,
+		DailyLimit:   b.limits.Daily,
+		MonthlyLimit: b.limits.Monthly,
+		Blocked:      b.blocked,
+		Downgraded:   b.downgraded,
+	}, nil
+}
+
+// load - загрузка журнала открытий при первом обращении (вызывается под блокировкой)
+func (b *ContactBudget) load(ctx context.Context) error {
+	if b.loaded {
+		return nil
+	}
+
+	openings, err := b.ledger.Openings(ctx)
+	if err != nil {
+		return fmt.Errorf("ошибка чтения журнала открытий контактов: %w", err)
+	}
+	for _, opening := range openings {
+		b.opened[opening.ResumeID] = true
+		b.spent = append(b.spent, opening.OpenedAt)
+	}
+
+	b.loaded = true
+	return nil
+}
+
+// available - остался ли бюджет на открытие (вызывается под блокировкой)
+func (b *ContactBudget) available(now time.Time) bool {
+	today, month := b.count(now)
+	if b.limits.Daily > 0 && today >= b.limits.Daily {
+		return false
+	}
+	if b.limits.Monthly > 0 && month >= b.limits.Monthly {
+		return false
+	}
+	return true
+}
+
+// count - количество открытий за сутки и календарный месяц now (вызывается под блокировкой)
+func (b *ContactBudget) count(now time.Time) (today, month int) {
+	year, mon, day := now.Date()
+	for _, at := range b.spent {
+		at = at.In(now.Location())
+		if at.Year() != year || at.Month() != mon {
+			continue
+		}
+		month++
+		if at.Day() == day {
+			today++
+		}
+	}
+	return today, month
+}
+
+// release - отмена резерва открытия после неудачного запроса
+func (b *ContactBudget) release(id string, at time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.opened, id)
+	for i := len(b.spent) - 1; i >= 0; i-- {
+		if b.spent[i].Equal(at) {
+			b.spent = append(b.spent[:i], b.spent[i+1:]...)
+			break
+		}
+	}
+}
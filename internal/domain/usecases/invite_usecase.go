@@ -0,0 +1,196 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"hh-resume-parser/internal/domain/entities"
+	"hh-resume-parser/internal/domain/repositories"
+	"hh-resume-parser/internal/infrastructure/logger"
+)
+
+// InviteUseCase - сценарий приглашения отобранных соискателей на вакансию
+// Каждое приглашение записывается в журнал, поэтому повторный запуск никого не пригласит дважды
+type InviteUseCase struct {
+	negotiationRepo repositories.NegotiationRepository // Репозиторий для отправки приглашений
+	ledger          repositories.InvitationLedger      // Журнал отправленных приглашений
+	logger          logger.Logger                      // Логгер для записи событий
+	now             func() time.Time
+}
+
+// NewInviteUseCase - создание нового экземпляра use case приглашений
+func NewInviteUseCase(
+	negotiationRepo repositories.NegotiationRepository,
+	ledger repositories.InvitationLedger,
+	logger logger.Logger,
+) *InviteUseCase {
+	return &InviteUseCase{
+		negotiationRepo: negotiationRepo,
+		ledger:          ledger,
+		logger:          logger,
+		now:             time.Now,
+	}
+}
+
+// InviteRequest - параметры приглашения
+type InviteRequest struct {
+	VacancyID string            // Вакансия, на которую приглашаются соискатели
+	Message   string            // Шаблон сообщения (text/template), данные - MessageData
+	Resumes   []entities.Resume // Приглашаемые соискатели; для резюме из списка ID известен только ID
+	DryRun    bool              // Только показать, кто будет приглашен, без запросов к API и записи в журнал
+}
+
+// MessageData - данные для шаблона сообщения
+// Поля резюме доступны напрямую: {{.Name}}, {{.Title}}, {{.Location}}, ...
+type MessageData struct {
+	entities.Resume
+	VacancyID string // Вакансия, на которую приглашается соискатель
+}
+
+// InviteStatus - итог приглашения одного соискателя
+type InviteStatus string
+
+const (
+	InviteSent     InviteStatus = "invited"         // Приглашение отправлено
+	InvitePlanned  InviteStatus = "dry_run"         // Приглашение будет отправлено (пробный запуск)
+	InviteSkipped  InviteStatus = "already_invited" // Соискатель уже приглашен ранее
+	InviteRejected InviteStatus = "failed"          // API отклонил приглашение
+)
+
+// InviteOutcome - итог приглашения одного соискателя
+type InviteOutcome struct {
+	Invitation entities.Invitation // Приглашение (для отправленных и запланированных)
+	Status     InviteStatus        // Итог
+	Err        error               // Причина отказа для InviteRejected
+}
+
+// InviteResult - итоги приглашения
+type InviteResult struct {
+	Outcomes []InviteOutcome // Итоги по каждому соискателю в порядке запроса
+	Invited  int             // Отправлено приглашений
+	Planned  int             // Запланировано приглашений (пробный запуск)
+	Skipped  int             // Пропущено уже приглашенных
+	Failed   int             // Отклонено API
+}
+
+// Invite - приглашение соискателей на вакансию
+// Уже приглашенные по журналу соискатели пропускаются; отказ API по одному соискателю
+// не прерывает остальные, а ошибки авторизации и лимита запросов прерывают приглашение
+func (uc *InviteUseCase) Invite(ctx context.Context, request InviteRequest) (*InviteResult, error) {
+	// Шаблон проверяется на пустых данных до отправки первого приглашения
+	message, err := template.New("message").Parse(request.Message)
+	if err == nil {
+		err = message.Execute(&strings.Builder{}, MessageData{})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: некорректный шаблон сообщения: %v", repositories.ErrBadArgument, err)
+	}
+
+	invitedIDs, err := uc.ledger.InvitedResumeIDs(ctx, request.VacancyID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения журнала приглашений: %w", err)
+	}
+	invited := make(map[string]bool, len(invitedIDs))
+	for _, id := range invitedIDs {
+		invited[id] = true
+	}
+
+	uc.logger.Info("Начинаем приглашение соискателей", map[string]interface{}{
+		"vacancy_id": request.VacancyID,
+		"candidates": len(request.Resumes),
+		"invited":    len(invitedIDs),
+		"dry_run":    request.DryRun,
+	})
+
+	result := &InviteResult{}
+	for _, resume := range request.Resumes {
+		if invited[resume.ID] {
+			result.Skipped++
+			result.Outcomes = append(result.Outcomes, InviteOutcome{
+				Invitation: entities.Invitation{ResumeID: resume.ID, VacancyID: request.VacancyID, Name: resume.Name},
+				Status:     InviteSkipped,
+			})
+			continue
+		}
+		invited[resume.ID] = true
+
+		var text strings.Builder
+		if err := message.Execute(&text, MessageData{Resume: resume, VacancyID: request.VacancyID}); err != nil {
+			return result, fmt.Errorf("ошибка подготовки сообщения для резюме %s: %w", resume.ID, err)
+		}
+
+		invitation := entities.Invitation{
+			ResumeID:  resume.ID,
+			VacancyID: request.VacancyID,
+			Name:      resume.Name,
+			Message:   text.String(),
+		}
+
+		if request.DryRun {
+			result.Planned++
+			result.Outcomes = append(result.Outcomes, InviteOutcome{Invitation: invitation, Status: InvitePlanned})
+			continue
+		}
+
+		outcome, err := uc.send(ctx, invitation)
+		if err != nil {
+			return result, fmt.Errorf("приглашение прервано: %w", err)
+		}
+
+		switch outcome.Status {
+		case InviteSent:
+			result.Invited++
+		case InviteSkipped:
+			result.Skipped++
+		default:
+			result.Failed++
+		}
+		result.Outcomes = append(result.Outcomes, outcome)
+	}
+
+	return result, nil
+}
+
+// send - отправка одного приглашения и запись его в журнал
+// Возвращает ошибку, если продолжать приглашение бессмысленно
+func (uc *InviteUseCase) send(ctx context.Context, invitation entities.Invitation) (InviteOutcome, error) {
+	negotiationID, err := uc.negotiationRepo.Invite(ctx, invitation.VacancyID, invitation.ResumeID, invitation.Message)
+
+	switch {
+	case err == nil:
+		invitation.NegotiationID = negotiationID
+	case errors.Is(err, repositories.ErrAlreadyInvited):
+		// Приглашение или отклик уже есть на hh.ru: записываем, чтобы больше не пытаться
+		invitation.Message = ""
+	case ctx.Err() != nil, repositories.IsFatal(err), errors.Is(err, repositories.ErrRateLimited):
+		return InviteOutcome{}, err
+	default:
+		uc.logger.Warn("Приглашение отклонено", map[string]interface{}{
+			"resume_id":  invitation.ResumeID,
+			"vacancy_id": invitation.VacancyID,
+			"error":      err.Error(),
+		})
+		return InviteOutcome{Invitation: invitation, Status: InviteRejected, Err: err}, nil
+	}
+
+	invitation.InvitedAt = uc.now()
+	if recordErr := uc.ledger.RecordInvitation(ctx, invitation); recordErr != nil {
+		// Без записи в журнал следующий запуск пригласит соискателя повторно
+		return InviteOutcome{}, fmt.Errorf("ошибка записи журнала приглашений: %w", recordErr)
+	}
+
+	if err != nil {
+		return InviteOutcome{Invitation: invitation, Status: InviteSkipped}, nil
+	}
+
+	uc.logger.Info("Соискатель приглашен", map[string]interface{}{
+		"resume_id":      invitation.ResumeID,
+		"vacancy_id":     invitation.VacancyID,
+		"negotiation_id": invitation.NegotiationID,
+	})
+	return InviteOutcome{Invitation: invitation, Status: InviteSent}, nil
+}
@@ -0,0 +1,464 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"hh-resume-parser/internal/domain/repositories"
+	"hh-resume-parser/internal/infrastructure/logger"
+)
+
+// searchSource - описание выдачи одного типа записей для общего постраничного обхода
+// Одна и та же логика плана поиска, пагинации, дедупликации и обработки ошибок
+// применяется к резюме и вакансиям
+type searchSource[T any] struct {
+	kind string // Тип записей для логов
+
+	// planner - разбиение запроса на части; nil - запрос выполняется целиком
+	planner func(ctx context.Context, criteria repositories.SearchCriteria) (*repositories.SearchPlan, error)
+
+	// search - загрузка одной страницы выдачи
+	search func(ctx context.Context, criteria repositories.SearchCriteria) ([]T, error)
+
+	// id - идентификатор записи для дедупликации
+	id func(item T) string
+
+	// matched - вызывается для каждой записи выдачи, в том числе повторной; может быть nil
+	matched func(id string)
+
+	// processed - проверка, сохранялась ли запись ранее; markProcessed - отметка о сохранении
+	processed     func(id string) bool
+	markProcessed func(id string)
+
+	// updated - запись уже сохранялась, но с тех пор обновлена и должна быть обработана заново
+	// nil - сохраненные записи всегда пропускаются
+	updated func(item T) bool
+
+	// process - обработка новых записей страницы (загрузка полных данных, валидация, обогащение)
+	// Возвращает записи для сохранения
+	process func(ctx context.Context, items []T, result *ParseResult) []T
+
+	// save - запись обработанных записей страницы до запроса следующей
+	// nil - записи накапливаются и возвращаются из collect
+	save func(ctx context.Context, items []T) error
+
+	// checkpoint - контрольная точка для продолжения прерванного запуска; nil - не ведется
+	// query - ключ запроса в контрольной точке
+	checkpoint *runCheckpoint
+	query      string
+}
+
+// planSearch - построение плана поиска
+// Если источник не умеет разбивать запросы, весь поиск выполняется одной частью.
+// При продолжении прерванного запуска используется план из контрольной точки
+func planSearch[T any](ctx context.Context, log logger.Logger, src searchSource[T], criteria repositories.SearchCriteria) (*repositories.SearchPlan, error) {
+	if plan := src.checkpoint.plan(src.query); plan != nil {
+		log.Info("План поиска восстановлен из контрольной точки", map[string]interface{}{
+			"kind":   src.kind,
+			"slices": len(plan.Slices),
+		})
+		return plan, nil
+	}
+
+	plan := &repositories.SearchPlan{
+		Slices: []repositories.QuerySlice{{Criteria: criteria}},
+	}
+	if src.planner != nil {
+		planned, err := src.planner(ctx, criteria)
+		switch {
+		case err == nil:
+			plan = planned
+		case classifyError(ctx, err, 0) == actionAbort:
+			return nil, fmt.Errorf("ошибка построения плана поиска: %w", err)
+		default:
+			log.Error("Ошибка построения плана поиска, выполняем запрос целиком", err)
+		}
+	}
+
+	src.checkpoint.startPlan(src.query, plan)
+	return plan, nil
+}
+
+// collect - поиск записей по критериям
+// В режиме perKeyword по каждому ключевому слову выполняется отдельный поиск,
+// и для каждой записи запоминаются слова, по которым она нашлась.
+// При прерывании возвращает собранные до ошибки записи вместе с ошибкой
+func collect[T any](ctx context.Context, log logger.Logger, src searchSource[T], criteria repositories.SearchCriteria,
+	perKeyword bool, result *ParseResult) ([]T, map[string][]string, error) {
+	seen := make(map[string]bool) // Записи, встреченные в выдаче за этот запуск
+
+	if !perKeyword || len(criteria.Keywords) < 2 {
+		plan, err := planSearch(ctx, log, src, criteria)
+		if err != nil {
+			return nil, nil, err
+		}
+		items, err := collectPlan(ctx, log, src, plan, seen, result)
+		return items, nil, err
+	}
+
+	var all []T
+	matches := make(map[string][]string)
+
+	for _, keyword := range criteria.Keywords {
+		keyword := keyword
+		keywordCriteria := criteria
+		keywordCriteria.Keywords = []string{keyword}
+
+		keywordSrc := src
+		keywordSrc.query = src.query + "/" + keyword
+		keywordSrc.matched = func(id string) {
+			if found := matches[id]; len(found) == 0 || found[len(found)-1] != keyword {
+				matches[id] = append(found, keyword)
+			}
+		}
+
+		log.Info("Поиск по ключевому слову", map[string]interface{}{
+			"kind":    src.kind,
+			"keyword": keyword,
+		})
+
+		plan, err := planSearch(ctx, log, keywordSrc, keywordCriteria)
+		if err != nil {
+			return all, matches, err
+		}
+
+		items, err := collectPlan(ctx, log, keywordSrc, plan, seen, result)
+		all = append(all, items...)
+		if err != nil {
+			return all, matches, err
+		}
+	}
+
+	return all, matches, nil
+}
+
+// collectPlan - обход всех частей плана поиска
+// seen - записи, уже встреченные в выдаче за этот запуск; счетчики покрытия суммируются по планам
+// Возвращает новые записи; при прерывании возвращает собранные до ошибки записи вместе с ошибкой
+func collectPlan[T any](ctx context.Context, log logger.Logger, src searchSource[T], plan *repositories.SearchPlan,
+	seen map[string]bool, result *ParseResult) ([]T, error) {
+	result.TotalAvailable += plan.TotalFound
+	result.Reachable += plan.Reachable
+	result.SliceCount += len(plan.Slices)
+
+	var all []T
+
+	for i, slice := range plan.Slices {
+		log.Info("Обработка части запроса", map[string]interface{}{
+			"kind":       src.kind,
+			"slice":      i + 1,
+			"slices":     len(plan.Slices),
+			"found":      slice.Found,
+			"experience": slice.Criteria.Experience,
+			"truncated":  slice.Truncated,
+		})
+
+		items, err := walkSlice(ctx, log, src, i, slice, plan.DepthLimit, seen, result)
+		all = append(all, items...)
+		if err != nil {
+			log.Warn("Парсинг прерван, сохраняем уже собранные записи", map[string]interface{}{
+				"kind":      src.kind,
+				"collected": len(all),
+				"error":     err.Error(),
+			})
+			return all, err
+		}
+	}
+
+	return all, nil
+}
+
+// walkSlice - постраничный обход одной части запроса с номером index
+// Возвращает новые записи, прошедшие дедупликацию и обработку (если они не записываются постранично)
+// Ошибка возвращается, только если продолжать парсинг бессмысленно
+func walkSlice[T any](ctx context.Context, log logger.Logger, src searchSource[T], index int, slice repositories.QuerySlice,
+	depthLimit int, seen map[string]bool, result *ParseResult) ([]T, error) {
+	var collected []T
+	criteria := slice.Criteria
+	pages := slice.Pages(depthLimit)
+
+	// Обход продолжается со страницы, следующей за последней пройденной в прерванном запуске
+	start, done := src.checkpoint.nextPage(src.query, index)
+	if done {
+		log.Info("Часть запроса уже пройдена в прерванном запуске", map[string]interface{}{
+			"kind":  src.kind,
+			"slice": index + 1,
+		})
+		return nil, nil
+	}
+	if start > 0 {
+		log.Info("Продолжаем обход части запроса", map[string]interface{}{
+			"kind":  src.kind,
+			"slice": index + 1,
+			"page":  start,
+		})
+	}
+
+	// sliceDone - отметка о завершении части в контрольной точке
+	sliceDone := func() ([]T, error) {
+		if err := src.checkpoint.sliceDone(ctx, src.query, index); err != nil {
+			result.Errors = append(result.Errors, err)
+			return collected, err
+		}
+		return collected, nil
+	}
+
+	failures := 0 // Количество ошибок подряд
+
+	for page := start; pages == 0 || page < pages; page++ {
+		criteria.Page = page
+		items, err := src.search(ctx, criteria)
+		if err != nil {
+			log.Error("Ошибка поиска", err)
+			pageErr := fmt.Errorf("ошибка поиска на странице %d: %w", page, err)
+			result.Errors = append(result.Errors, pageErr)
+
+			failures++
+			switch classifyError(ctx, err, failures) {
+			case actionAbort:
+				return collected, pageErr
+			case actionSkipSlice:
+				return sliceDone()
+			default:
+				continue
+			}
+		}
+		failures = 0
+
+		if len(items) == 0 {
+			log.Info("Достигнут конец результатов поиска", map[string]interface{}{"page": page})
+			break
+		}
+
+		// Отбор новых записей, в том числе с учетом предыдущих частей запроса
+		fresh := make([]T, 0, len(items))
+		for _, item := range items {
+			id := src.id(item)
+			result.ProcessedCount++
+			if src.matched != nil {
+				src.matched(id)
+			}
+			if seen[id] {
+				result.SkippedCount++
+				continue
+			}
+			seen[id] = true
+			result.UniqueCount++
+
+			if src.processed(id) && (src.updated == nil || !src.updated(item)) {
+				result.SkippedCount++
+				log.Debug("Запись уже обработана, пропускаем", map[string]interface{}{"kind": src.kind, "id": id})
+				continue
+			}
+			fresh = append(fresh, item)
+		}
+
+		processed := src.process(ctx, fresh, result)
+		if src.save != nil && len(processed) > 0 {
+			// Страница записывается целиком до запроса следующей; без записи продолжать нельзя
+			if err := src.save(ctx, processed); err != nil {
+				saveErr := fmt.Errorf("ошибка сохранения страницы %d: %w", page, err)
+				result.Errors = append(result.Errors, saveErr)
+				return collected, saveErr
+			}
+		}
+		ids := make([]string, 0, len(processed))
+		for _, item := range processed {
+			if src.save == nil {
+				collected = append(collected, item)
+			}
+			id := src.id(item)
+			src.markProcessed(id)
+			ids = append(ids, id)
+			result.SavedCount++
+		}
+
+		// Страница пройдена; при продолжении запуска она не запрашивается повторно
+		if err := src.checkpoint.pageDone(ctx, src.query, index, page, ids); err != nil {
+			result.Errors = append(result.Errors, err)
+			return collected, err
+		}
+
+		log.Info("Обработана страница результатов", map[string]interface{}{
+			"kind":          src.kind,
+			"page":          page,
+			"items_on_page": len(items),
+			"total_saved":   result.SavedCount,
+		})
+	}
+
+	return sliceDone()
+}
+
+// fetchDetails - загрузка полных версий записей пулом из workers горутин
+// При ошибке загрузки остается версия из выдачи; при отмене контекста
+// незагруженные записи также остаются краткими
+func fetchDetails[T any](ctx context.Context, log logger.Logger, workers int, items []T, id func(T) string,
+	get func(ctx context.Context, item T) (T, error), result *ParseResult) []T {
+	if workers <= 0 || len(items) == 0 {
+		return items
+	}
+
+	detailed := make([]T, len(items))
+	copy(detailed, items)
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	var mu sync.Mutex // Защищает счетчики result
+
+	for w := 0; w < min(workers, len(items)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				detail, err := get(ctx, items[i])
+
+				mu.Lock()
+				if err != nil {
+					result.DetailErrors++
+					log.Warn("Не удалось загрузить полную версию, используем данные из выдачи", map[string]interface{}{
+						"id":    id(items[i]),
+						"error": err.Error(),
+					})
+				} else {
+					detailed[i] = detail
+					result.DetailsFetched++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+feed:
+	for i := range items {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	return detailed
+}
+
+// errorAction - реакция на ошибку при обходе выдачи
+type errorAction int
+
+const (
+	actionSkipPage  errorAction = iota // Пропустить страницу и перейти к следующей
+	actionSkipSlice                    // Прекратить обход текущей части запроса
+	actionAbort                        // Прервать парсинг целиком
+)
+
+// maxConsecutiveFailures - количество ошибок подряд, после которого часть запроса пропускается
+const maxConsecutiveFailures = 3
+
+// classifyError - выбор реакции на ошибку поиска по ее категории
+func classifyError(ctx context.Context, err error, failures int) errorAction {
+	switch {
+	case ctx.Err() != nil:
+		return actionAbort
+	case repositories.IsFatal(err), errors.Is(err, repositories.ErrUnknownArea):
+		// Токен недействителен, нет прав или неверно указан регион - остальные запросы завершатся так же
+		return actionAbort
+	case errors.Is(err, repositories.ErrRateLimited):
+		// Повторы в репозитории уже исчерпаны, продолжать - значит тратить квоту впустую
+		return actionAbort
+	case errors.Is(err, repositories.ErrNotFound), errors.Is(err, repositories.ErrBadArgument):
+		// Следующие страницы этой части вернут то же самое
+		return actionSkipSlice
+	case failures >= maxConsecutiveFailures:
+		return actionSkipSlice
+	default:
+		return actionSkipPage
+	}
+}
+
+// Option - дополнительная настройка сценариев парсинга
+type Option func(*options)
+
+// options - общие настройки сценариев парсинга резюме и вакансий
+type options struct {
+	detailWorkers   int            // Количество параллельных загрузок полных версий (0 - не загружать)
+	perKeyword      bool           // Отдельный поиск по каждому ключевому слову
+	contacts        *ContactBudget // Бюджет открытий контактов при загрузке полных резюме
+	withoutContacts bool           // Загружать полные резюме без контактов
+	sources         []Source       // Источники резюме (по умолчанию - единственный репозиторий сценария)
+
+	history     repositories.ResumeHistoryStore // История версий резюме (nil - сохраненные резюме пропускаются)
+	checkpoints repositories.CheckpointStore    // Хранилище контрольной точки (nil - не ведется)
+	resume      bool                            // Продолжить прерванный запуск из контрольной точки
+
+	rules       []ValidationRule // Правила проверки резюме (см. WithValidationRules)
+	customRules bool             // Правила заданы опцией, правила по умолчанию не применяются
+
+	skills    *SkillDictionary // Словарь канонических названий навыков (nil - только удаление повторов)
+	extractor *SkillExtractor  // Поиск навыков в тексте резюме (nil - не ищутся)
+
+	scorer   *ResumeScorer // Оценка резюме по профилю вакансии (nil - не оцениваются)
+	minScore float64       // Порог оценки, ниже которого резюме пропускаются
+}
+
+// WithDetailWorkers - загрузка полной версии для каждой новой записи из выдачи
+// workers - количество параллельных загрузок; ограничение скорости общее и задается репозиторием
+func WithDetailWorkers(workers int) Option {
+	return func(o *options) {
+		o.detailWorkers = workers
+	}
+}
+
+// WithPerKeywordSearch - отдельный поиск по каждому ключевому слову вместо одного общего запроса
+// Для каждой записи сохраняются ключевые слова, по которым она нашлась
+func WithPerKeywordSearch() Option {
+	return func(o *options) {
+		o.perKeyword = true
+	}
+}
+
+// WithContactBudget - учет и ограничение открытий контактов при загрузке полных резюме
+func WithContactBudget(budget *ContactBudget) Option {
+	return func(o *options) {
+		o.contacts = budget
+	}
+}
+
+// WithoutContacts - загрузка полных резюме без открытия контактов
+// Действует для источников с платными контактами, умеющих отдавать резюме без них (ContactlessResumeReader);
+// контакты из сохраненных страниц и выгрузок бесплатны и сохраняются
+func WithoutContacts() Option {
+	return func(o *options) {
+		o.withoutContacts = true
+	}
+}
+
+// WithResumeHistory - отслеживание обновлений резюме и история их версий
+// Сохраненное ранее резюме обрабатывается заново, если с тех пор оно обновилось, и сохраняется
+// повторно, если изменилось его содержимое; прежняя версия остается в истории
+func WithResumeHistory(store repositories.ResumeHistoryStore) Option {
+	return func(o *options) {
+		o.history = store
+	}
+}
+
+// WithCheckpoint - сохранение контрольной точки после каждой страницы выдачи резюме
+// resume - продолжить прерванный запуск: пройденные страницы не запрашиваются повторно,
+// а резюме дописываются в существующий файл вывода
+func WithCheckpoint(store repositories.CheckpointStore, resume bool) Option {
+	return func(o *options) {
+		o.checkpoints = store
+		o.resume = resume
+	}
+}
+
+// newOptions - применение дополнительных настроек
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
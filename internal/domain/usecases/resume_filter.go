@@ -0,0 +1,255 @@
+package usecases
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"hh-resume-parser/internal/domain/entities"
+	"hh-resume-parser/internal/domain/repositories"
+)
+
+// ResumeFilter - правило отбора сохраненных резюме
+// Записывается как условия через точку с запятой, все условия должны выполняться:
+//
+//	skills=Kafka; experience>=3; location~Москва
+//
+// Условия через | - альтернативы, достаточно одной: "skills!= | experience>0".
+// Операторы: = и != (сравнение без учета регистра), ~ и !~ (содержит и не содержит подстроку),
+// >, >=, <, <= (для числовых полей). Для списков (skills, languages, ...)
+// = и ~ выполняются, если подходит хотя бы один элемент, != и !~ - если не подходит ни один.
+// Условие с пустым значением проверяет, заполнено ли поле: "email!=" - email указан,
+// "skills=" - навыки не указаны
+type ResumeFilter struct {
+	conditions [][]filterCondition // Условия, каждое - набор альтернатив
+}
+
+// filterCondition - одно условие правила отбора
+type filterCondition struct {
+	field    string
+	operator string
+	value    string
+	number   int // Значение для числовых полей
+}
+
+// resumeField - способ получения значения поля резюме для правила отбора
+// Для числовых полей задан number; ok = false означает, что значение неизвестно
+type resumeField struct {
+	text   func(r *entities.Resume) []string
+	number func(r *entities.Resume) (value int, ok bool)
+}
+
+// resumeFields - поля резюме, доступные в правилах отбора
+var resumeFields = map[string]resumeField{
+	"id":               {text: func(r *entities.Resume) []string { return []string{r.ID} }},
+	"name":             {text: func(r *entities.Resume) []string { return []string{r.Name} }},
+	"title":            {text: func(r *entities.Resume) []string { return []string{r.Title} }},
+	"location":         {text: func(r *entities.Resume) []string { return []string{r.Location} }},
+	"area_id":          {text: func(r *entities.Resume) []string { return []string{r.AreaID} }},
+	"gender":           {text: func(r *entities.Resume) []string { return []string{r.Gender} }},
+	"email":            {text: func(r *entities.Resume) []string { return []string{r.Contact.Email} }},
+	"phone":            {text: func(r *entities.Resume) []string { return []string{r.Contact.Phone} }},
+	"education_level":  {text: func(r *entities.Resume) []string { return []string{r.EducationLevel} }},
+	"skills":           {text: func(r *entities.Resume) []string { return r.Skills }},
+	"matched_keywords": {text: func(r *entities.Resume) []string { return r.MatchedKeywords }},
+	"inferred_skills": {text: func(r *entities.Resume) []string {
+		names := make([]string, len(r.InferredSkills))
+		for i, skill := range r.InferredSkills {
+			names[i] = skill.Name
+		}
+		return names
+	}},
+	"employment":         {text: func(r *entities.Resume) []string { return r.Employment }},
+	"schedule":           {text: func(r *entities.Resume) []string { return r.Schedule }},
+	"professional_roles": {text: func(r *entities.Resume) []string { return r.ProfessionalRoles }},
+	"languages": {text: func(r *entities.Resume) []string {
+		names := make([]string, len(r.Languages))
+		for i, language := range r.Languages {
+			names[i] = language.Name
+		}
+		return names
+	}},
+	"age": {number: func(r *entities.Resume) (int, bool) { return r.Age, r.Age > 0 }},
+	"experience": {number: func(r *entities.Resume) (int, bool) {
+		return r.GetExperienceYears(), r.TotalExperience > 0 || len(r.Experience) > 0
+	}},
+	"jobs": {number: func(r *entities.Resume) (int, bool) { return len(r.Experience), true }},
+	"updated_days": {number: func(r *entities.Resume) (int, bool) {
+		if r.LastUpdate.IsZero() {
+			return 0, false
+		}
+		return int(time.Since(r.LastUpdate).Hours() / 24), true
+	}},
+	"score": {number: func(r *entities.Resume) (int, bool) {
+		if r.Score == nil {
+			return 0, false
+		}
+		return int(r.Score.Total), true
+	}},
+	"salary": {number: func(r *entities.Resume) (int, bool) {
+		if r.Salary == nil || r.Salary.Amount <= 0 {
+			return 0, false
+		}
+		return r.Salary.Amount, true
+	}},
+}
+
+// filterOperators - операторы условий; двухсимвольные проверяются первыми
+var filterOperators = []string{"!=", "!~", ">=", "<=", "=", ">", "<", "~"}
+
+// ParseResumeFilter - разбор правила отбора резюме
+// Пустое правило пропускает все резюме
+func ParseResumeFilter(rule string) (*ResumeFilter, error) {
+	filter := &ResumeFilter{}
+
+	for _, part := range strings.Split(rule, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		var alternatives []filterCondition
+		for _, alternative := range strings.Split(part, "|") {
+			condition, err := parseFilterCondition(strings.TrimSpace(alternative))
+			if err != nil {
+				return nil, err
+			}
+			alternatives = append(alternatives, condition)
+		}
+		filter.conditions = append(filter.conditions, alternatives)
+	}
+
+	return filter, nil
+}
+
+// parseFilterCondition - разбор одного условия вида "поле оператор значение"
+func parseFilterCondition(part string) (filterCondition, error) {
+	pos, operator := -1, ""
+	for _, op := range filterOperators {
+		if i := strings.Index(part, op); i > 0 && (pos < 0 || i < pos) {
+			pos, operator = i, op
+		}
+	}
+	if pos < 0 {
+		return filterCondition{}, fmt.Errorf("%w: не указан оператор в условии %q", repositories.ErrBadArgument, part)
+	}
+
+	condition := filterCondition{
+		field:    strings.ToLower(strings.TrimSpace(part[:pos])),
+		operator: operator,
+		value:    strings.TrimSpace(part[pos+len(operator):]),
+	}
+
+	field, ok := resumeFields[condition.field]
+	if !ok {
+		return filterCondition{}, fmt.Errorf("%w: неизвестное поле %q в условии %q (доступны: %s)",
+			repositories.ErrBadArgument, condition.field, part, strings.Join(ResumeFilterFields(), ", "))
+	}
+
+	if field.number != nil {
+		number, err := strconv.Atoi(condition.value)
+		if err != nil || condition.operator == "~" || condition.operator == "!~" {
+			return filterCondition{}, fmt.Errorf("%w: поле %s сравнивается с числом операторами =, !=, >, >=, <, <= (условие %q)",
+				repositories.ErrBadArgument, condition.field, part)
+		}
+		condition.number = number
+	} else if condition.operator != "=" && condition.operator != "!=" && condition.operator != "~" && condition.operator != "!~" {
+		return filterCondition{}, fmt.Errorf("%w: поле %s сравнивается операторами =, !=, ~ или !~ (условие %q)",
+			repositories.ErrBadArgument, condition.field, part)
+	}
+
+	return condition, nil
+}
+
+// ResumeFilterFields - поля резюме, доступные в правилах отбора
+func ResumeFilterFields() []string {
+	fields := make([]string, 0, len(resumeFields))
+	for name := range resumeFields {
+		fields = append(fields, name)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// Match - проверка, что резюме удовлетворяет всем условиям правила
+func (f *ResumeFilter) Match(resume *entities.Resume) bool {
+	for _, alternatives := range f.conditions {
+		matched := false
+		for _, condition := range alternatives {
+			if condition.match(resume) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter - резюме, удовлетворяющие правилу
+func (f *ResumeFilter) Filter(resumes []entities.Resume) []entities.Resume {
+	var matched []entities.Resume
+	for i := range resumes {
+		if f.Match(&resumes[i]) {
+			matched = append(matched, resumes[i])
+		}
+	}
+	return matched
+}
+
+// match - проверка одного условия
+func (c filterCondition) match(resume *entities.Resume) bool {
+	field := resumeFields[c.field]
+
+	if field.number != nil {
+		value, ok := field.number(resume)
+		if !ok {
+			// Неизвестное значение не удовлетворяет сравнению, кроме неравенства
+			return c.operator == "!="
+		}
+		switch c.operator {
+		case "=":
+			return value == c.number
+		case "!=":
+			return value != c.number
+		case ">":
+			return value > c.number
+		case ">=":
+			return value >= c.number
+		case "<":
+			return value < c.number
+		default:
+			return value <= c.number
+		}
+	}
+
+	values := field.text(resume)
+	if c.value == "" && (c.operator == "=" || c.operator == "!=") {
+		filled := false
+		for _, value := range values {
+			filled = filled || value != ""
+		}
+		return filled == (c.operator == "!=")
+	}
+
+	found := false
+	for _, value := range values {
+		if c.operator == "~" || c.operator == "!~" {
+			found = strings.Contains(strings.ToLower(value), strings.ToLower(c.value))
+		} else {
+			found = strings.EqualFold(value, c.value)
+		}
+		if found {
+			break
+		}
+	}
+
+	if c.operator == "!=" || c.operator == "!~" {
+		return !found
+	}
+	return found
+}
@@ -2,7 +2,10 @@ package usecases
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
+
 	"hh-resume-parser/internal/domain/entities"
 	"hh-resume-parser/internal/domain/repositories"
 	"hh-resume-parser/internal/infrastructure/logger"
@@ -16,26 +19,38 @@ type ResumeUseCase struct {
 	cacheRepo   repositories.CacheRepository   // Репозиторий для кэширования
 	logger      logger.Logger                  // Логгер для записи событий
 	processed   map[string]bool                // Кэш обработанных резюме в памяти
+	versions    *resumeVersions                // Последние версии резюме (nil - история не ведется)
+	options     options                        // Дополнительные настройки
 }
 
 // NewResumeUseCase - создание нового экземпляра use case
+// Если источники не заданы опцией WithSources, резюме собираются из resumeRepo
 func NewResumeUseCase(
 	resumeRepo repositories.ResumeRepository,
 	storageRepo repositories.StorageRepository,
 	cacheRepo repositories.CacheRepository,
 	logger logger.Logger,
+	opts ...Option,
 ) *ResumeUseCase {
+	options := newOptions(opts)
+	if len(options.sources) == 0 {
+		options.sources = []Source{{Repository: resumeRepo, PaidContacts: true}}
+	}
+
 	return &ResumeUseCase{
 		resumeRepo:  resumeRepo,
 		storageRepo: storageRepo,
 		cacheRepo:   cacheRepo,
 		logger:      logger,
 		processed:   make(map[string]bool),
+		options:     options,
 	}
 }
 
 // ParseResumesByCriteria - основной метод парсинга резюме по критериям
-// Выполняет поиск, фильтрацию и сохранение резюме
+// Выполняет поиск, фильтрацию и сохранение резюме.
+// Источники обходятся по очереди; ошибка, прервавшая один источник, не останавливает остальные.
+// Если хранилище поддерживает постраничную запись, каждая страница сохраняется до запроса следующей
 func (uc *ResumeUseCase) ParseResumesByCriteria(ctx context.Context, criteria repositories.SearchCriteria) (*ParseResult, error) {
 	uc.logger.Info("Начинаем парсинг резюме", map[string]interface{}{
 		"keywords":    criteria.Keywords,
@@ -58,81 +73,407 @@ func (uc *ResumeUseCase) ParseResumesByCriteria(ctx context.Context, criteria re
 		// Продолжаем работу без предварительной загрузки
 	}
 
-	// Поиск резюме по критериям
-	var allResumes []entities.Resume
-	page := 0
+	// Последние версии сохраненных резюме для поиска обновлений
+	if uc.options.history != nil {
+		if err := uc.loadResumeVersions(ctx); err != nil {
+			uc.logger.Error("Ошибка загрузки истории версий резюме, обновления не отслеживаются", err)
+			uc.versions = nil
+		}
+	}
+
+	output := uc.newResumeOutput(criteria)
+	defer output.close()
 
-	for {
-		criteria.Page = page
-		resumes, err := uc.resumeRepo.SearchResumes(ctx, criteria)
-		if err != nil {
-			uc.logger.Error("Ошибка поиска резюме", err)
-			result.Errors = append(result.Errors, fmt.Errorf("ошибка поиска на странице %d: %w", page, err))
+	checkpoint, err := uc.openCheckpoint(ctx, criteria, output)
+	if err != nil {
+		return result, err
+	}
+
+	var aborted []error
+	owners := newSourceOwners()
+
+	for _, source := range uc.options.sources {
+		if ctx.Err() != nil {
 			break
 		}
 
-		if len(resumes) == 0 {
-			uc.logger.Info("Достигнут конец результатов поиска", map[string]interface{}{"page": page})
+		if len(uc.options.sources) > 1 {
+			uc.logger.Info("Сбор резюме из источника", map[string]interface{}{"source": source.Name})
+		}
+
+		// Поиск резюме по плану с учетом лимита глубины выдачи
+		summary := &SourceResult{Name: source.Name}
+		sourceResult := &ParseResult{Errors: make([]error, 0)}
+		src := uc.searchSource(source, owners, summary)
+		if output.stream != nil {
+			src.save = output.append
+		}
+		src.checkpoint = checkpoint
+		resumes, matches, abortErr := collect(ctx, uc.logger, src, criteria, uc.options.perKeyword, sourceResult)
+		for i := range resumes {
+			resumes[i].MatchedKeywords = matches[resumes[i].ID]
+		}
+		output.buffered = append(output.buffered, resumes...)
+
+		summary.Found = sourceResult.ProcessedCount
+		summary.Saved = sourceResult.SavedCount
+		summary.Skipped = sourceResult.SkippedCount
+		summary.Errors = sourceResult.Errors
+		if abortErr != nil {
+			summary.Aborted = abortErr
+			aborted = append(aborted, abortErr)
+			if len(uc.options.sources) > 1 {
+				uc.logger.Warn("Сбор из источника прерван, переходим к следующему", map[string]interface{}{
+					"source": source.Name,
+					"error":  abortErr.Error(),
+				})
+			}
+		}
+
+		result.add(sourceResult)
+		result.Sources = append(result.Sources, *summary)
+
+		// Без записи результатов продолжать сбор бессмысленно
+		if output.failed != nil {
 			break
 		}
+	}
 
-		// Обработка найденных резюме
-		for _, resume := range resumes {
-			result.ProcessedCount++
+	// При оценке по профилю все резюме сохраняются в конце запуска, упорядоченные по оценке
+	if uc.options.scorer != nil {
+		output.buffered = RankResumes(output.buffered, 0)
+	}
 
-			// Проверка на дублирование
-			if uc.isAlreadyProcessed(resume.ID) {
-				result.SkippedCount++
-				uc.logger.Debug("Резюме уже обработано, пропускаем", map[string]interface{}{"resume_id": resume.ID})
-				continue
+	// Завершение записи; уже собранное сохраняется и после отмены контекста
+	if err := output.finish(context.WithoutCancel(ctx)); err != nil {
+		uc.logger.Error("Ошибка сохранения резюме", err)
+		return result, fmt.Errorf("ошибка сохранения резюме: %w", err)
+	}
+	checkpoint.finish(ctx, len(aborted) == 0 && ctx.Err() == nil)
+
+	result.TotalFound = result.ProcessedCount
+
+	uc.logger.Info("Покрытие выдачи", map[string]interface{}{
+		"total_available": result.TotalAvailable,
+		"reachable":       result.Reachable,
+		"unique":          result.UniqueCount,
+		"coverage":        fmt.Sprintf("%.1f%%", result.Coverage()*100),
+	})
+
+	// Парсинг считается прерванным, если не удалось собрать ни один источник
+	if len(aborted) > 0 && (len(aborted) == len(uc.options.sources) || ctx.Err() != nil) {
+		return result, fmt.Errorf("парсинг прерван: %w", errors.Join(aborted...))
+	}
+
+	return result, nil
+}
+
+// searchSource - описание выдачи резюме источника для общего постраничного обхода
+// Резюме, уже полученные из другого источника, учитываются в summary как дубликаты
+func (uc *ResumeUseCase) searchSource(source Source, owners *sourceOwners, summary *SourceResult) searchSource[entities.Resume] {
+	src := searchSource[entities.Resume]{
+		kind:   "резюме",
+		query:  source.Name,
+		search: source.Repository.SearchResumes,
+		id:     func(resume entities.Resume) string { return resume.ID },
+		processed: func(id string) bool {
+			if owner, ok := owners.byID[id]; ok && owner != source.Name {
+				summary.Duplicates++
+			}
+			return uc.isAlreadyProcessed(id)
+		},
+		markProcessed: uc.markAsProcessed,
+		updated:       uc.isUpdated,
+		process: func(ctx context.Context, resumes []entities.Resume, result *ParseResult) []entities.Resume {
+			var unique []entities.Resume
+			for _, resume := range uc.processResumes(ctx, source, resumes, result) {
+				// Соискатель, уже найденный в другом источнике под другим ID, узнается по контактам
+				if owner, ok := owners.duplicateOf(source.Name, &resume); ok {
+					summary.Duplicates++
+					result.SkippedCount++
+					uc.logger.Debug("Резюме уже получено из другого источника", map[string]interface{}{
+						"resume_id": resume.ID,
+						"source":    source.Name,
+						"owner":     owner,
+					})
+					continue
+				}
+				resume.Source = source.Name
+				owners.add(source.Name, &resume)
+				unique = append(unique, resume)
+			}
+			return uc.trackVersions(ctx, unique, result)
+		},
+	}
+	if planner, ok := source.Repository.(repositories.SearchPlanner); ok {
+		src.planner = planner.PlanSearch
+	}
+	return src
+}
+
+// processResumes - обработка новых резюме страницы: загрузка полных версий, валидация и обогащение
+// Полные версии загружаются из того же источника, что и выдача
+func (uc *ResumeUseCase) processResumes(ctx context.Context, source Source, resumes []entities.Resume, result *ParseResult) []entities.Resume {
+	// Загрузка полных резюме вместо кратких данных из выдачи
+	resumes = fetchDetails(ctx, uc.logger, uc.options.detailWorkers, resumes,
+		func(resume entities.Resume) string { return resume.ID },
+		func(ctx context.Context, summary entities.Resume) (entities.Resume, error) {
+			detail, err := uc.resumeDetails(ctx, source, summary.ID)
+			if err != nil {
+				return summary, err
 			}
+			return mergeResumeDetails(summary, *detail), nil
+		},
+		result,
+	)
+
+	rules := uc.options.validationRules()
+	var valid []entities.Resume
+	for _, resume := range resumes {
+		// Проверка резюме по правилам
+		dropped, warnings := validate(rules, &resume)
+		if dropped != "" {
+			result.SkippedCount++
+			result.countSkipped(dropped, 1)
+			uc.logger.Debug("Резюме не прошло проверку", map[string]interface{}{
+				"resume_id": resume.ID,
+				"rule":      dropped,
+			})
+			continue
+		}
+		for _, rule := range warnings {
+			result.countWarning(rule, 1)
+			uc.logger.Warn("Резюме нарушает правило проверки", map[string]interface{}{
+				"resume_id": resume.ID,
+				"rule":      rule,
+			})
+		}
+
+		// Обогащение данных резюме
+		if err := uc.enrichResumeData(ctx, &resume); err != nil {
+			uc.logger.Error("Ошибка обогащения данных резюме", err)
+			// Продолжаем с основными данными
+		}
 
-			// Валидация резюме
-			if !uc.validateResume(&resume) {
+		// Оценка по профилю вакансии с учетом найденных навыков
+		if uc.options.scorer != nil {
+			resume.Score = uc.options.scorer.Score(&resume)
+			if resume.Score.Total < uc.options.minScore {
 				result.SkippedCount++
-				uc.logger.Debug("Резюме не прошло валидацию", map[string]interface{}{"resume_id": resume.ID})
+				result.countSkipped(RuleMinScore, 1)
+				uc.logger.Debug("Оценка резюме ниже порога", map[string]interface{}{
+					"resume_id": resume.ID,
+					"score":     resume.Score.Total,
+				})
 				continue
 			}
+		}
+
+		valid = append(valid, resume)
+	}
+
+	return valid
+}
+
+// openCheckpoint - загрузка контрольной точки прерванного запуска или начало новой
+// Контрольная точка ведется, только если резюме записываются постранично и хранилище
+// умеет продолжать запись; nil - контрольная точка не ведется
+func (uc *ResumeUseCase) openCheckpoint(ctx context.Context, criteria repositories.SearchCriteria, output *resumeOutput) (*runCheckpoint, error) {
+	store := uc.options.checkpoints
+	if store == nil {
+		return nil, nil
+	}
+
+	appender, ok := uc.storageRepo.(repositories.ResumeAppendStorage)
+	if output.stream == nil || !ok {
+		uc.logger.Info("Контрольная точка не ведется: резюме сохраняются в конце запуска", map[string]interface{}{
+			"resume": uc.options.resume,
+		})
+		return nil, nil
+	}
+
+	hash := criteriaHash(criteria, uc.options.sources, uc.options.perKeyword)
+	if uc.options.resume {
+		state, err := store.Load(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка загрузки контрольной точки: %w", err)
+		}
 
-			// Обогащение данных резюме
-			if err := uc.enrichResumeData(ctx, &resume); err != nil {
-				uc.logger.Error("Ошибка обогащения данных резюме", err)
-				// Продолжаем с основными данными
+		switch {
+		case state == nil:
+			uc.logger.Warn("Контрольная точка не найдена, начинаем запуск сначала", map[string]interface{}{
+				"keywords": criteria.Keywords,
+			})
+		case state.CriteriaHash != hash:
+			return nil, fmt.Errorf("контрольная точка создана для других критериев поиска или источников, продолжить запуск нельзя")
+		default:
+			// Резюме, сохраненные прерванным запуском, не сохраняются повторно и дописываются в тот же файл
+			for _, id := range state.ProcessedIDs {
+				uc.processed[id] = true
 			}
+			output.appender = appender
+
+			uc.logger.Info("Продолжение прерванного запуска", map[string]interface{}{
+				"started_at": state.StartedAt.Format(time.RFC3339),
+				"updated_at": state.UpdatedAt.Format(time.RFC3339),
+				"saved":      len(state.ProcessedIDs),
+				"queries":    len(state.Queries),
+			})
+			return newRunCheckpoint(store, state, uc.logger), nil
+		}
+	}
+
+	return newRunCheckpoint(store, &repositories.Checkpoint{CriteriaHash: hash, StartedAt: time.Now()}, uc.logger), nil
+}
+
+// resumeOutput - запись собранных резюме в хранилище
+// Если хранилище поддерживает постраничную запись, резюме пишутся по мере сбора,
+// иначе накапливаются и сохраняются в конце запуска
+type resumeOutput struct {
+	storage  repositories.StorageRepository
+	stream   repositories.ResumeStreamStorage // nil - резюме сохраняются в конце запуска
+	appender repositories.ResumeAppendStorage // Не nil - запись продолжается в существующий файл
+	writer   repositories.ResumeBatchWriter   // Открывается при записи первой страницы
+	buffered []entities.Resume                // Резюме для сохранения в конце запуска
+	count    int                              // Количество записанных резюме
+	failed   error                            // Ошибка постраничной записи
+	logger   logger.Logger
+}
 
-			allResumes = append(allResumes, resume)
-			uc.markAsProcessed(resume.ID)
-			result.SavedCount++
+// newResumeOutput - выбор способа записи результатов
+// При поиске по каждому ключевому слову совпавшие слова известны только в конце запуска,
+// а при оценке по профилю вакансии резюме упорядочиваются по оценке, поэтому резюме сохраняются в конце
+func (uc *ResumeUseCase) newResumeOutput(criteria repositories.SearchCriteria) *resumeOutput {
+	output := &resumeOutput{storage: uc.storageRepo, logger: uc.logger}
+	if stream, ok := uc.storageRepo.(repositories.ResumeStreamStorage); ok {
+		switch {
+		case uc.options.perKeyword && len(criteria.Keywords) > 1:
+			uc.logger.Info("Резюме будут сохранены в конце запуска вместе с совпавшими ключевыми словами", map[string]interface{}{
+				"keywords": criteria.Keywords,
+			})
+		case uc.options.scorer != nil:
+			uc.logger.Warn("Резюме накапливаются в памяти и будут сохранены в конце запуска по убыванию оценки, "+
+				"прерванный запуск продолжить нельзя", map[string]interface{}{
+				"profile": uc.options.scorer.Name(),
+			})
+		default:
+			output.stream = stream
 		}
+	}
+	return output
+}
 
-		uc.logger.Info("Обработана страница результатов", map[string]interface{}{
-			"page":            page,
-			"resumes_on_page": len(resumes),
-			"total_saved":     result.SavedCount,
-		})
+// append - запись обработанной страницы резюме
+func (o *resumeOutput) append(ctx context.Context, resumes []entities.Resume) error {
+	if o.writer == nil {
+		open := o.stream.OpenResumes
+		if o.appender != nil {
+			open = o.appender.AppendResumes
+		}
+		writer, err := open(ctx)
+		if err != nil {
+			o.failed = err
+			return err
+		}
+		o.writer = writer
+	}
+
+	if err := o.writer.AppendBatch(ctx, resumes); err != nil {
+		o.failed = err
+		return err
+	}
+	o.count += len(resumes)
+	return nil
+}
+
+// finish - завершение записи: фиксация постраничной записи или сохранение накопленных резюме
+// Если ничего не найдено, файл вывода не создается и не перезаписывается
+func (o *resumeOutput) finish(ctx context.Context) error {
+	if o.failed != nil {
+		return o.failed
+	}
 
-		page++
+	if o.writer != nil {
+		if err := o.writer.Commit(ctx); err != nil {
+			return err
+		}
+		if err := o.writer.Close(); err != nil {
+			return err
+		}
 	}
 
-	// Сохранение результатов
-	if len(allResumes) > 0 {
-		if err := uc.storageRepo.SaveResumes(ctx, allResumes); err != nil {
-			uc.logger.Error("Ошибка сохранения резюме", err)
-			return result, fmt.Errorf("ошибка сохранения резюме: %w", err)
+	if len(o.buffered) > 0 {
+		if err := o.storage.SaveResumes(ctx, o.buffered); err != nil {
+			return err
 		}
+		o.count += len(o.buffered)
+	}
 
-		uc.logger.Info("Резюме успешно сохранены", map[string]interface{}{
-			"count": len(allResumes),
+	if o.count > 0 {
+		o.logger.Info("Резюме успешно сохранены", map[string]interface{}{
+			"count": o.count,
 		})
 	}
+	return nil
+}
 
-	result.TotalFound = result.ProcessedCount
-	return result, nil
+// close - закрытие файла, если запись не была завершена
+func (o *resumeOutput) close() {
+	if o.writer != nil {
+		o.writer.Close()
+	}
 }
 
-// GetResumeDetails - получение детальной информации о резюме
+// mergeResumeDetails - полное резюме, дополненное данными из выдачи там, где полей нет
+func mergeResumeDetails(summary, detail entities.Resume) entities.Resume {
+	merged := detail
+	if merged.ID == "" {
+		merged.ID = summary.ID
+	}
+	if merged.Name == "" {
+		merged.Name = summary.Name
+	}
+	if merged.Title == "" {
+		merged.Title = summary.Title
+	}
+	if merged.URL == "" {
+		merged.URL = summary.URL
+	}
+	if merged.LastUpdate.IsZero() {
+		merged.LastUpdate = summary.LastUpdate
+	}
+	if merged.Location == "" {
+		merged.Location = summary.Location
+	}
+	if merged.Age == 0 {
+		merged.Age = summary.Age
+	}
+	if merged.Gender == "" {
+		merged.Gender = summary.Gender
+	}
+	if merged.Salary == nil {
+		merged.Salary = summary.Salary
+	}
+	if len(merged.Skills) == 0 {
+		merged.Skills = summary.Skills
+	}
+	if len(merged.Experience) == 0 {
+		merged.Experience = summary.Experience
+	}
+	if len(merged.Education) == 0 {
+		merged.Education = summary.Education
+	}
+	return merged
+}
+
+// GetResumeDetails - получение детальной информации о резюме из первого источника
 func (uc *ResumeUseCase) GetResumeDetails(ctx context.Context, resumeID string) (*entities.Resume, error) {
-	uc.logger.Info("Получение детальной информации о резюме", map[string]interface{}{
+	return uc.resumeDetails(ctx, uc.options.sources[0], resumeID)
+}
+
+// resumeDetails - получение детальной информации о резюме из источника
+func (uc *ResumeUseCase) resumeDetails(ctx context.Context, source Source, resumeID string) (*entities.Resume, error) {
+	uc.logger.Debug("Получение детальной информации о резюме", map[string]interface{}{
 		"resume_id": resumeID,
 	})
 
@@ -145,9 +486,11 @@ func (uc *ResumeUseCase) GetResumeDetails(ctx context.Context, resumeID string)
 	}
 
 	// Получение из основного источника
-	resume, err := uc.resumeRepo.GetResumeByID(ctx, resumeID)
+	resume, err := uc.fetchResume(ctx, source, resumeID)
 	if err != nil {
-		uc.logger.Error("Ошибка получения резюме", err)
+		if !errors.Is(err, repositories.ErrContactBudgetExhausted) {
+			uc.logger.Error("Ошибка получения резюме", err)
+		}
 		return nil, fmt.Errorf("ошибка получения резюме %s: %w", resumeID, err)
 	}
 
@@ -159,22 +502,23 @@ func (uc *ResumeUseCase) GetResumeDetails(ctx context.Context, resumeID string)
 	return resume, nil
 }
 
-// validateResume - валидация резюме перед сохранением
-func (uc *ResumeUseCase) validateResume(resume *entities.Resume) bool {
-	// Базовая валидация
-	if !resume.IsValid() {
-		return false
-	}
-
-	// Дополнительные проверки
-	if len(resume.Skills) == 0 && len(resume.Experience) == 0 {
-		uc.logger.Debug("Резюме без навыков и опыта работы", map[string]interface{}{
-			"resume_id": resume.ID,
-		})
-		return false
+// fetchResume - загрузка полного резюме из источника с учетом бюджета открытий контактов
+func (uc *ResumeUseCase) fetchResume(ctx context.Context, source Source, resumeID string) (*entities.Resume, error) {
+	repo := source.Repository
+	reader, contactless := repo.(repositories.ContactlessResumeReader)
+
+	switch {
+	case uc.options.withoutContacts && source.PaidContacts && contactless:
+		return reader.GetResumeWithoutContacts(ctx, resumeID)
+	case uc.options.contacts != nil && source.PaidContacts:
+		var withoutContacts func(ctx context.Context, id string) (*entities.Resume, error)
+		if contactless {
+			withoutContacts = reader.GetResumeWithoutContacts
+		}
+		return uc.options.contacts.Fetch(ctx, resumeID, repo.GetResumeByID, withoutContacts)
+	default:
+		return repo.GetResumeByID(ctx, resumeID)
 	}
-
-	return true
 }
 
 // enrichResumeData - обогащение данных резюме дополнительной информацией
@@ -183,10 +527,23 @@ func (uc *ResumeUseCase) enrichResumeData(ctx context.Context, resume *entities.
 	// - Получение дополнительной информации из API
 	// - Нормализация данных
 	// - Извлечение дополнительных навыков из описаний
-	
-	// Пример: нормализация навыков
-	resume.Skills = uc.normalizeSkills(resume.Skills)
-	
+
+	// Нормализация навыков; по словарю навыки приводятся к каноническим названиям,
+	// а написание соискателя сохраняется (резюме из JSON выгрузки уже может его содержать)
+	if uc.options.skills == nil {
+		resume.Skills = uc.normalizeSkills(resume.Skills)
+	} else {
+		if len(resume.SkillsOriginal) == 0 {
+			resume.SkillsOriginal = uc.normalizeSkills(resume.Skills)
+		}
+		resume.Skills = uc.options.skills.Canonicalize(resume.SkillsOriginal)
+	}
+
+	// Навыки, упомянутые только в заголовке и опыте работы, дополняют указанные соискателем
+	if uc.options.extractor != nil {
+		resume.InferredSkills = uc.options.extractor.Extract(resume)
+	}
+
 	return nil
 }
 
@@ -195,14 +552,14 @@ func (uc *ResumeUseCase) normalizeSkills(skills []string) []string {
 	// Удаление дублей и нормализация
 	seen := make(map[string]bool)
 	var normalized []string
-	
+
 	for _, skill := range skills {
 		if skill != "" && !seen[skill] {
 			seen[skill] = true
 			normalized = append(normalized, skill)
 		}
 	}
-	
+
 	return normalized
 }
 
@@ -251,5 +608,52 @@ type ParseResult struct {
 	ProcessedCount int     // Количество обработанных резюме
 	SavedCount     int     // Количество сохраненных резюме
 	SkippedCount   int     // Количество пропущенных резюме
+	UpdatedCount   int     // Количество резюме, сохраненных повторно из-за изменений
 	Errors         []error // Список ошибок, возникших в процессе
+
+	UniqueCount    int // Количество уникальных резюме в выдаче
+	TotalAvailable int // Количество резюме по данным источника
+	Reachable      int // Количество резюме, доступных с учетом лимита глубины
+	SliceCount     int // Количество частей, на которые разбит запрос
+
+	DetailsFetched int // Количество загруженных полных резюме
+	DetailErrors   int // Количество резюме, оставшихся с данными из выдачи из-за ошибок
+
+	SkippedByRule  map[string]int // Пропущенные резюме по правилам проверки
+	WarningsByRule map[string]int // Сохраненные резюме с нарушениями правил проверки (warn)
+
+	Sources []SourceResult // Итоги по источникам резюме
+}
+
+// add - добавление итогов сбора из одного источника
+func (r *ParseResult) add(other *ParseResult) {
+	r.ProcessedCount += other.ProcessedCount
+	r.SavedCount += other.SavedCount
+	r.SkippedCount += other.SkippedCount
+	r.UpdatedCount += other.UpdatedCount
+	r.Errors = append(r.Errors, other.Errors...)
+	r.UniqueCount += other.UniqueCount
+	r.TotalAvailable += other.TotalAvailable
+	r.Reachable += other.Reachable
+	r.SliceCount += other.SliceCount
+	r.DetailsFetched += other.DetailsFetched
+	r.DetailErrors += other.DetailErrors
+	for rule, count := range other.SkippedByRule {
+		r.countSkipped(rule, count)
+	}
+	for rule, count := range other.WarningsByRule {
+		r.countWarning(rule, count)
+	}
+}
+
+// Coverage - доля найденных источником резюме, которые были обработаны
+func (r *ParseResult) Coverage() float64 {
+	if r.TotalAvailable == 0 {
+		return 1
+	}
+	coverage := float64(r.UniqueCount) / float64(r.TotalAvailable)
+	if coverage > 1 {
+		return 1
+	}
+	return coverage
 }
@@ -0,0 +1,146 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"hh-resume-parser/internal/domain/entities"
+	"hh-resume-parser/internal/domain/repositories"
+)
+
+// resumeVersions - последние известные версии резюме
+// Версии загружаются из истории, а резюме, которые есть только в файле вывода, становятся
+// первыми версиями и попадают в историю, когда у них появится следующая
+type resumeVersions struct {
+	latest   map[string]entities.ResumeVersion
+	baseline map[string]bool // Версии, которые еще не записаны в историю
+}
+
+// loadResumeVersions - загрузка последних версий резюме из истории и файла вывода
+func (uc *ResumeUseCase) loadResumeVersions(ctx context.Context) error {
+	latest, err := uc.options.history.Latest(ctx)
+	if err != nil {
+		return err
+	}
+	uc.versions = &resumeVersions{latest: latest, baseline: make(map[string]bool)}
+
+	// Резюме из файла вывода, сохраненные до ведения истории
+	loader, ok := uc.storageRepo.(repositories.ResumeLoader)
+	if !ok {
+		return nil
+	}
+	saved, err := loader.LoadResumes(ctx)
+	if err != nil {
+		return err
+	}
+	for _, resume := range saved {
+		if _, known := latest[resume.ID]; known {
+			continue
+		}
+		latest[resume.ID] = newResumeVersion(resume, 1, resume.LastUpdate)
+		uc.versions.baseline[resume.ID] = true
+	}
+
+	uc.logger.Info("Загружены версии сохраненных резюме", map[string]interface{}{
+		"count": len(latest),
+	})
+	return nil
+}
+
+// isUpdated - резюме обновлено после сохранения последней версии
+func (uc *ResumeUseCase) isUpdated(resume entities.Resume) bool {
+	if uc.versions == nil {
+		return false
+	}
+	known, ok := uc.versions.latest[resume.ID]
+	return ok && resume.LastUpdate.After(known.LastUpdate)
+}
+
+// trackVersions - сравнение резюме страницы с последними сохраненными версиями
+// Новые и измененные резюме записываются в историю, измененные получают список отличий.
+// Уже сохраненное резюме, содержимое которого не изменилось, повторно не сохраняется
+func (uc *ResumeUseCase) trackVersions(ctx context.Context, resumes []entities.Resume, result *ParseResult) []entities.Resume {
+	if uc.versions == nil {
+		return resumes
+	}
+
+	now := time.Now()
+	var changed []entities.Resume
+	var records []entities.ResumeVersion
+
+	for _, resume := range resumes {
+		version := newResumeVersion(resume, 1, now)
+		previous, known := uc.versions.latest[resume.ID]
+
+		switch {
+		case !known:
+			records = append(records, version)
+
+		case previous.Hash == version.Hash:
+			// Содержимое не изменилось: запоминается только новая дата обновления,
+			// чтобы резюме не загружалось заново при каждом запуске
+			if resume.LastUpdate.After(previous.LastUpdate) {
+				version.Version = previous.Version
+				records = append(records, version)
+				previous.LastUpdate = resume.LastUpdate
+				uc.versions.latest[resume.ID] = previous
+			}
+			if uc.isAlreadyProcessed(resume.ID) {
+				result.SkippedCount++
+				uc.logger.Debug("Резюме обновлено без изменений содержимого", map[string]interface{}{
+					"resume_id": resume.ID,
+				})
+				continue
+			}
+			changed = append(changed, resume)
+			continue
+
+		default:
+			// Прежняя версия из файла вывода сохраняется в историю вместе с новой
+			if uc.versions.baseline[resume.ID] {
+				records = append(records, previous)
+				delete(uc.versions.baseline, resume.ID)
+			}
+
+			version.Version = previous.Version + 1
+			version.Changes = entities.DiffResumes(previous.Resume, resume)
+			resume.Changes = version.Changes
+			records = append(records, version)
+			result.UpdatedCount++
+
+			descriptions := make([]string, len(version.Changes))
+			for i, change := range version.Changes {
+				descriptions[i] = change.String()
+			}
+			uc.logger.Info("Резюме обновлено", map[string]interface{}{
+				"resume_id": resume.ID,
+				"version":   version.Version,
+				"changes":   descriptions,
+			})
+		}
+
+		uc.versions.latest[resume.ID] = version
+		changed = append(changed, resume)
+	}
+
+	if len(records) > 0 {
+		if err := uc.options.history.AppendVersions(ctx, records); err != nil {
+			uc.logger.Error("Ошибка записи истории версий резюме", err)
+		}
+	}
+
+	return changed
+}
+
+// newResumeVersion - версия резюме с номером number, полученная в savedAt
+func newResumeVersion(resume entities.Resume, number int, savedAt time.Time) entities.ResumeVersion {
+	resume.Changes = nil
+	return entities.ResumeVersion{
+		ID:         resume.ID,
+		Version:    number,
+		Hash:       entities.ContentHash(resume),
+		LastUpdate: resume.LastUpdate,
+		SavedAt:    savedAt,
+		Resume:     resume,
+	}
+}
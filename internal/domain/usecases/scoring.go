@@ -0,0 +1,325 @@
+package usecases
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"hh-resume-parser/internal/domain/entities"
+	"hh-resume-parser/internal/domain/repositories"
+)
+
+// Составляющие оценки резюме
+const (
+	ScoreRequiredSkills   = "required_skills"     // Обязательные навыки
+	ScoreNiceToHaveSkills = "nice_to_have_skills" // Желательные навыки
+	ScoreExperience       = "experience"          // Стаж не меньше минимального
+	ScoreSalary           = "salary"              // Зарплата не выше потолка
+	ScoreLocation         = "location"            // Регион проживания
+	ScoreFreshness        = "freshness"           // Давность обновления резюме
+)
+
+// RuleMinScore - имя правила, по которому пропускаются резюме с оценкой ниже порога
+const RuleMinScore = "min_score"
+
+// HiringProfile - профиль вакансии для оценки резюме
+// Составляющая учитывается, только если для нее задано требование: без списка регионов
+// регион не влияет на оценку, а итог пересчитывается на оставшиеся составляющие
+type HiringProfile struct {
+	Name string `json:"name"` // Название профиля в оценке
+
+	RequiredSkills   map[string]float64 `json:"required_skills"`     // Обязательные навыки и их веса
+	NiceToHaveSkills map[string]float64 `json:"nice_to_have_skills"` // Желательные навыки и их веса
+
+	MinExperience int      `json:"min_experience"` // Минимальный стаж в годах
+	SalaryCeiling int      `json:"salary_ceiling"` // Максимальная желаемая зарплата
+	Currency      string   `json:"currency"`       // Валюта потолка зарплаты (пусто - не проверяется)
+	Locations     []string `json:"locations"`      // Подходящие регионы: названия или ID регионов hh.ru
+
+	// FreshnessHalfLife - через сколько дней после обновления резюме эта составляющая уменьшается вдвое
+	FreshnessHalfLife int `json:"freshness_half_life_days"`
+
+	// Weights - максимум баллов составляющих; незаданные берутся из DefaultScoreWeights
+	Weights map[string]float64 `json:"weights"`
+}
+
+// DefaultScoreWeights - веса составляющих оценки по умолчанию
+func DefaultScoreWeights() map[string]float64 {
+	return map[string]float64{
+		ScoreRequiredSkills:   40,
+		ScoreNiceToHaveSkills: 15,
+		ScoreExperience:       15,
+		ScoreSalary:           10,
+		ScoreLocation:         10,
+		ScoreFreshness:        10,
+	}
+}
+
+// ResumeScorer - оценка резюме по профилю вакансии
+type ResumeScorer struct {
+	profile HiringProfile
+	dict    *SkillDictionary // Словарь навыков для сравнения написаний (может быть nil)
+	weights map[string]float64
+}
+
+// NewResumeScorer - оценка по профилю; навыки профиля и резюме сравниваются по словарю dict
+func NewResumeScorer(profile HiringProfile, dict *SkillDictionary) (*ResumeScorer, error) {
+	weights := DefaultScoreWeights()
+	for name, weight := range profile.Weights {
+		if _, ok := weights[name]; !ok {
+			return nil, fmt.Errorf("%w: неизвестная составляющая оценки %q", repositories.ErrBadArgument, name)
+		}
+		if weight < 0 {
+			return nil, fmt.Errorf("%w: вес составляющей %s не может быть отрицательным", repositories.ErrBadArgument, name)
+		}
+		weights[name] = weight
+	}
+
+	for _, skills := range []map[string]float64{profile.RequiredSkills, profile.NiceToHaveSkills} {
+		for skill, weight := range skills {
+			if weight <= 0 {
+				return nil, fmt.Errorf("%w: вес навыка %s должен быть положительным", repositories.ErrBadArgument, skill)
+			}
+		}
+	}
+	if profile.MinExperience < 0 || profile.SalaryCeiling < 0 || profile.FreshnessHalfLife < 0 {
+		return nil, fmt.Errorf("%w: стаж, потолок зарплаты и срок свежести не могут быть отрицательными", repositories.ErrBadArgument)
+	}
+
+	scorer := &ResumeScorer{profile: profile, dict: dict, weights: weights}
+	if len(scorer.active()) == 0 {
+		return nil, fmt.Errorf("%w: в профиле вакансии не задано ни одного требования", repositories.ErrBadArgument)
+	}
+	return scorer, nil
+}
+
+// Name - название профиля вакансии
+func (s *ResumeScorer) Name() string {
+	return s.profile.Name
+}
+
+// active - составляющие, для которых в профиле заданы требования и ненулевой вес
+func (s *ResumeScorer) active() []string {
+	configured := map[string]bool{
+		ScoreRequiredSkills:   len(s.profile.RequiredSkills) > 0,
+		ScoreNiceToHaveSkills: len(s.profile.NiceToHaveSkills) > 0,
+		ScoreExperience:       s.profile.MinExperience > 0,
+		ScoreSalary:           s.profile.SalaryCeiling > 0,
+		ScoreLocation:         len(s.profile.Locations) > 0,
+		ScoreFreshness:        s.profile.FreshnessHalfLife > 0,
+	}
+
+	var names []string
+	for _, name := range []string{ScoreRequiredSkills, ScoreNiceToHaveSkills, ScoreExperience, ScoreSalary, ScoreLocation, ScoreFreshness} {
+		if configured[name] && s.weights[name] > 0 {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Score - оценка резюме с пояснением по составляющим
+func (s *ResumeScorer) Score(resume *entities.Resume) *entities.ResumeScore {
+	names := s.active()
+	total := 0.0
+	for _, name := range names {
+		total += s.weights[name]
+	}
+
+	score := &entities.ResumeScore{Profile: s.profile.Name}
+	for _, name := range names {
+		var value float64
+		var details string
+		switch name {
+		case ScoreRequiredSkills:
+			value, details = s.skills(resume, s.profile.RequiredSkills)
+		case ScoreNiceToHaveSkills:
+			value, details = s.skills(resume, s.profile.NiceToHaveSkills)
+		case ScoreExperience:
+			value, details = s.experience(resume)
+		case ScoreSalary:
+			value, details = s.salary(resume)
+		case ScoreLocation:
+			value, details = s.location(resume)
+		case ScoreFreshness:
+			value, details = s.freshness(resume)
+		}
+
+		maxPoints := 100 * s.weights[name] / total
+		score.Components = append(score.Components, entities.ScoreComponent{
+			Name:      name,
+			Value:     round(value, 2),
+			Points:    round(value*maxPoints, 1),
+			MaxPoints: round(maxPoints, 1),
+			Details:   details,
+		})
+		score.Total += value * maxPoints
+	}
+	score.Total = round(score.Total, 1)
+
+	return score
+}
+
+// skills - доля веса навыков профиля, которые есть в резюме
+// Указанный соискателем навык засчитывается полностью, найденный в тексте - с его уверенностью
+func (s *ResumeScorer) skills(resume *entities.Resume, wanted map[string]float64) (float64, string) {
+	have := make(map[string]float64)
+	for _, skill := range resume.Skills {
+		have[s.skillKey(skill)] = 1
+	}
+	for _, skill := range resume.InferredSkills {
+		if key := s.skillKey(skill.Name); skill.Confidence > have[key] {
+			have[key] = skill.Confidence
+		}
+	}
+
+	names := make([]string, 0, len(wanted))
+	for name := range wanted {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var matched, missing []string
+	sum, total := 0.0, 0.0
+	for _, name := range names {
+		weight := wanted[name]
+		total += weight
+		credit := have[s.skillKey(name)]
+		switch {
+		case credit == 0:
+			missing = append(missing, name)
+		case credit < 1:
+			matched = append(matched, fmt.Sprintf("%s (%.2f)", name, credit))
+		default:
+			matched = append(matched, name)
+		}
+		sum += weight * credit
+	}
+
+	var details []string
+	if len(matched) > 0 {
+		details = append(details, "есть: "+strings.Join(matched, ", "))
+	}
+	if len(missing) > 0 {
+		details = append(details, "нет: "+strings.Join(missing, ", "))
+	}
+	return sum / total, strings.Join(details, "; ")
+}
+
+// skillKey - ключ сравнения навыков с учетом синонимов словаря
+func (s *ResumeScorer) skillKey(skill string) string {
+	if s.dict != nil {
+		if name, ok := s.dict.Canonical(skill); ok {
+			skill = name
+		}
+	}
+	return skillKey(skill)
+}
+
+// experience - стаж относительно минимального
+func (s *ResumeScorer) experience(resume *entities.Resume) (float64, string) {
+	if resume.TotalExperience == 0 && len(resume.Experience) == 0 {
+		return 0, "стаж не указан"
+	}
+	years := resume.GetExperienceYears()
+	details := fmt.Sprintf("%d лет, минимум %d", years, s.profile.MinExperience)
+	return math.Min(float64(years)/float64(s.profile.MinExperience), 1), details
+}
+
+// salary - желаемая зарплата относительно потолка
+// Зарплата выше потолка снижает оценку линейно до нуля при двукратном превышении,
+// неуказанная или в другой валюте дает половину баллов
+func (s *ResumeScorer) salary(resume *entities.Resume) (float64, string) {
+	ceiling := s.profile.SalaryCeiling
+	if resume.Salary == nil || resume.Salary.Amount <= 0 {
+		return 0.5, "зарплата не указана"
+	}
+	amount := resume.Salary.Amount
+	if s.profile.Currency != "" && resume.Salary.Currency != "" && !strings.EqualFold(resume.Salary.Currency, s.profile.Currency) {
+		return 0.5, fmt.Sprintf("%d %s, потолок в %s", amount, resume.Salary.Currency, s.profile.Currency)
+	}
+
+	details := fmt.Sprintf("%d %s, потолок %d", amount, resume.Salary.Currency, ceiling)
+	if amount <= ceiling {
+		return 1, details
+	}
+	return math.Max(0, 1-float64(amount-ceiling)/float64(ceiling)), details
+}
+
+// location - регион проживания или готовность переехать в подходящий регион
+func (s *ResumeScorer) location(resume *entities.Resume) (float64, string) {
+	matches := func(place string) bool {
+		for _, location := range s.profile.Locations {
+			if place != "" && strings.EqualFold(strings.TrimSpace(location), place) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if matches(resume.Location) || matches(resume.AreaID) {
+		return 1, resume.Location
+	}
+	if resume.Relocation != nil {
+		for _, area := range resume.Relocation.Areas {
+			if matches(area) {
+				return 0.5, fmt.Sprintf("%s, готов переехать в %s", resume.Location, area)
+			}
+		}
+	}
+	if resume.Location == "" {
+		return 0, "регион не указан"
+	}
+	return 0, resume.Location
+}
+
+// freshness - давность обновления резюме: оценка уменьшается вдвое за каждый срок свежести
+func (s *ResumeScorer) freshness(resume *entities.Resume) (float64, string) {
+	if resume.LastUpdate.IsZero() {
+		return 0, "дата обновления неизвестна"
+	}
+	days := math.Max(0, time.Since(resume.LastUpdate).Hours()/24)
+	return math.Pow(0.5, days/float64(s.profile.FreshnessHalfLife)),
+		fmt.Sprintf("обновлено %d дн. назад", int(days))
+}
+
+// RankResumes - резюме по убыванию оценки, с оценкой не ниже minScore
+// Резюме без оценки считаются оцененными в 0; порядок равных оценок сохраняется
+func RankResumes(resumes []entities.Resume, minScore float64) []entities.Resume {
+	ranked := make([]entities.Resume, 0, len(resumes))
+	for _, resume := range resumes {
+		if scoreTotal(&resume) >= minScore {
+			ranked = append(ranked, resume)
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return scoreTotal(&ranked[i]) > scoreTotal(&ranked[j])
+	})
+	return ranked
+}
+
+// WithResumeScorer - оценка резюме по профилю вакансии при сборе
+// Резюме с оценкой ниже minScore пропускаются по правилу min_score. Чтобы упорядочить резюме
+// по убыванию оценки, они сохраняются в конце запуска, а не постранично (контрольная точка не ведется)
+func WithResumeScorer(scorer *ResumeScorer, minScore float64) Option {
+	return func(o *options) {
+		o.scorer = scorer
+		o.minScore = minScore
+	}
+}
+
+// scoreTotal - итоговая оценка резюме (0, если резюме не оценено)
+func scoreTotal(resume *entities.Resume) float64 {
+	if resume.Score == nil {
+		return 0
+	}
+	return resume.Score.Total
+}
+
+// round - округление до digits знаков после запятой
+func round(value float64, digits int) float64 {
+	scale := math.Pow(10, float64(digits))
+	return math.Round(value*scale) / scale
+}
@@ -0,0 +1,258 @@
+package usecases
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"hh-resume-parser/internal/domain/entities"
+)
+
+// DefaultSkillMinConfidence - минимальная уверенность, с которой найденный навык попадает в резюме
+const DefaultSkillMinConfidence = 0.5
+
+// Уверенность в найденном навыке
+const (
+	shortSkillFactor = 0.8  // Множитель для коротких написаний (Go, JS, ML): они чаще совпадают случайно
+	mentionBonus     = 0.05 // Прибавка за каждое следующее упоминание
+	maxConfidence    = 0.95 // Найденный навык не может быть надежнее указанного соискателем
+)
+
+// ambiguousSkillForms - написания навыков, совпадающие с обычными английскими словами
+// ("go live", "node", "elastic"); ищутся с учетом регистра, как аббревиатуры
+var ambiguousSkillForms = map[string]bool{
+	"go":      true,
+	"node":    true,
+	"kube":    true,
+	"rabbit":  true,
+	"elastic": true,
+}
+
+// skillSourceConfidence - уверенность в навыке в зависимости от того, где он упомянут
+var skillSourceConfidence = map[string]float64{
+	entities.SkillSourceTitle:       0.9,
+	entities.SkillSourcePosition:    0.8,
+	entities.SkillSourceDescription: 0.7,
+}
+
+// SkillExtractor - поиск навыков словаря в заголовке резюме и опыте работы
+// Написание навыка ищется целым словом или фразой: "Go" находится в "Go-разработчик",
+// но не в "Google". Короткие аббревиатуры в верхнем регистре (ML, TS, REST) и написания,
+// совпадающие с обычными словами (Go, Node), ищутся с учетом регистра, остальные - без учета
+type SkillExtractor struct {
+	dict          *SkillDictionary
+	patterns      []skillPattern
+	minConfidence float64
+}
+
+// skillPattern - написание навыка для поиска в тексте
+type skillPattern struct {
+	name  string // Каноническое название
+	text  string // Написание, приведенное как текст (см. foldText)
+	exact bool   // Искать с учетом регистра
+	short bool   // Короткое написание, совпадения менее надежны
+}
+
+// NewSkillExtractor - поиск навыков по словарю
+// minConfidence - навыки с меньшей уверенностью отбрасываются
+func NewSkillExtractor(dict *SkillDictionary, minConfidence float64) *SkillExtractor {
+	extractor := &SkillExtractor{dict: dict, minConfidence: minConfidence}
+
+	keys := make([]string, 0, len(dict.forms))
+	for key := range dict.forms {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		form := dict.forms[key]
+		length := utf8.RuneCountInString(form)
+		if length < 2 {
+			continue
+		}
+		exact := length <= 4 && isAcronym(form) || ambiguousSkillForms[strings.ToLower(form)]
+		extractor.patterns = append(extractor.patterns, skillPattern{
+			name:  dict.canonical[key],
+			text:  foldText(form, !exact),
+			exact: exact,
+			short: length <= 2,
+		})
+	}
+	return extractor
+}
+
+// Extract - навыки, упомянутые в заголовке, должностях и описаниях опыта работы
+// Навыки, которые соискатель указал сам, не возвращаются. Результат отсортирован
+// по убыванию уверенности
+func (e *SkillExtractor) Extract(resume *entities.Resume) []entities.InferredSkill {
+	declared := make(map[string]bool, len(resume.Skills))
+	for _, skill := range resume.Skills {
+		if name, ok := e.dict.Canonical(skill); ok {
+			skill = name
+		}
+		declared[skillKey(skill)] = true
+	}
+
+	type mention struct {
+		source     string
+		confidence float64
+		count      int
+	}
+	found := make(map[string]*mention)
+	var order []string
+
+	scan := func(text, source string) {
+		if strings.TrimSpace(text) == "" {
+			return
+		}
+		lower, exact := foldText(text, true), foldText(text, false)
+
+		// Разные написания одного навыка ("Kafka" и "Apache Kafka") - одно упоминание
+		counts := make(map[string]int)
+		confidences := make(map[string]float64)
+		for _, pattern := range e.patterns {
+			if declared[skillKey(pattern.name)] {
+				continue
+			}
+			count := pattern.count(lower, exact)
+			if count == 0 {
+				continue
+			}
+			confidence := skillSourceConfidence[source]
+			if pattern.short {
+				confidence *= shortSkillFactor
+			}
+			if count > counts[pattern.name] {
+				counts[pattern.name] = count
+			}
+			if confidence > confidences[pattern.name] {
+				confidences[pattern.name] = confidence
+			}
+		}
+
+		for _, pattern := range e.patterns {
+			name := pattern.name
+			count, ok := counts[name]
+			if !ok {
+				continue
+			}
+			delete(counts, name)
+
+			m := found[name]
+			if m == nil {
+				m = &mention{}
+				found[name] = m
+				order = append(order, name)
+			}
+			m.count += count
+			if confidences[name] > m.confidence {
+				m.confidence = confidences[name]
+				m.source = source
+			}
+		}
+	}
+
+	scan(resume.Title, entities.SkillSourceTitle)
+	for _, job := range resume.Experience {
+		scan(job.Position, entities.SkillSourcePosition)
+		scan(job.Description, entities.SkillSourceDescription)
+	}
+
+	var skills []entities.InferredSkill
+	for _, name := range order {
+		m := found[name]
+		confidence := math.Min(m.confidence+mentionBonus*float64(m.count-1), maxConfidence)
+		confidence = math.Round(confidence*100) / 100
+		if confidence < e.minConfidence {
+			continue
+		}
+		skills = append(skills, entities.InferredSkill{Name: name, Source: m.source, Confidence: confidence})
+	}
+	sort.SliceStable(skills, func(i, j int) bool {
+		return skills[i].Confidence > skills[j].Confidence
+	})
+	return skills
+}
+
+// WithSkillExtractor - поиск навыков, не указанных соискателем, в заголовке и опыте работы
+// Найденные навыки сохраняются в поле InferredSkills, указанные соискателем не меняются
+func WithSkillExtractor(extractor *SkillExtractor) Option {
+	return func(o *options) {
+		o.extractor = extractor
+	}
+}
+
+// count - количество упоминаний написания в тексте целым словом или фразой
+// lower и exact - текст, приведенный foldText без учета и с учетом регистра
+func (p skillPattern) count(lower, exact string) int {
+	text := lower
+	if p.exact {
+		text = exact
+	}
+
+	count := 0
+	for offset := 0; offset < len(text); {
+		i := strings.Index(text[offset:], p.text)
+		if i < 0 {
+			break
+		}
+		start, end := offset+i, offset+i+len(p.text)
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if (start == 0 || !isWordRune(before)) && (end == len(text) || !isWordRune(after) && after != '+' && after != '#') {
+			count++
+		}
+		offset = end
+	}
+	return count
+}
+
+// foldText - текст для поиска навыков: пробелы, дефисы и подчеркивания заменены одним пробелом,
+// кириллические буквы, похожие на латинские, - латинскими; lower - без учета регистра
+func foldText(text string, lower bool) string {
+	var folded strings.Builder
+	space := false
+	for _, r := range text {
+		if unicode.IsSpace(r) || r == '-' || r == '_' {
+			space = true
+			continue
+		}
+		if space && folded.Len() > 0 {
+			folded.WriteByte(' ')
+		}
+		space = false
+
+		if lower {
+			r = unicode.ToLower(r)
+		}
+		if latin, ok := cyrillicLookalikes[unicode.ToLower(r)]; ok {
+			if unicode.IsUpper(r) {
+				latin = unicode.ToUpper(latin)
+			}
+			r = latin
+		}
+		folded.WriteRune(r)
+	}
+	return folded.String()
+}
+
+// isAcronym - все буквы написания заглавные: "ML", "REST", "CI/CD"
+func isAcronym(form string) bool {
+	letters := 0
+	for _, r := range form {
+		if unicode.IsLetter(r) {
+			if !unicode.IsUpper(r) {
+				return false
+			}
+			letters++
+		}
+	}
+	return letters > 0
+}
+
+// isWordRune - символ, который продолжает слово
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
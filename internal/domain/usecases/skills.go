@@ -0,0 +1,175 @@
+package usecases
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// SkillDictionary - словарь навыков для приведения к каноническим названиям
+// Написания сравниваются без учета регистра, пробелов, точек, дефисов и подчеркиваний,
+// а кириллические буквы, похожие на латинские, считаются латинскими: "GoLang", "go lang"
+// и "Golang" - одно написание, "1С" с кириллической С совпадает с "1C"
+type SkillDictionary struct {
+	canonical map[string]string // Ключ написания -> каноническое название
+	forms     map[string]string // Ключ написания -> написание из словаря
+}
+
+// NewSkillDictionary - словарь из наборов "каноническое название -> синонимы"
+// Наборы применяются по порядку: синоним из следующего набора переопределяет предыдущий,
+// поэтому пользовательский словарь можно передать после встроенного
+func NewSkillDictionary(sets ...map[string][]string) *SkillDictionary {
+	dict := &SkillDictionary{
+		canonical: make(map[string]string),
+		forms:     make(map[string]string),
+	}
+	for _, set := range sets {
+		names := make([]string, 0, len(set))
+		for name := range set {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			name = cleanSkill(name)
+			if name == "" {
+				continue
+			}
+			dict.add(name, name)
+			for _, alias := range set[name] {
+				dict.add(name, alias)
+			}
+		}
+	}
+	return dict
+}
+
+// add - регистрация написания навыка
+func (d *SkillDictionary) add(name, alias string) {
+	alias = cleanSkill(alias)
+	key := skillKey(alias)
+	if key == "" {
+		return
+	}
+	d.canonical[key] = name
+	d.forms[key] = alias
+}
+
+// Canonical - каноническое название навыка; false, если навыка нет в словаре
+func (d *SkillDictionary) Canonical(skill string) (string, bool) {
+	name, ok := d.canonical[skillKey(skill)]
+	return name, ok
+}
+
+// Canonicalize - навыки в канонических названиях без повторов
+// Навыки, которых нет в словаре, остаются в написании соискателя с нормализованными пробелами
+func (d *SkillDictionary) Canonicalize(skills []string) []string {
+	seen := make(map[string]bool, len(skills))
+	var result []string
+	for _, skill := range skills {
+		name, ok := d.Canonical(skill)
+		if !ok {
+			name = cleanSkill(skill)
+		}
+		key := skillKey(name)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, name)
+	}
+	return result
+}
+
+// Len - количество канонических навыков в словаре
+func (d *SkillDictionary) Len() int {
+	names := make(map[string]bool, len(d.canonical))
+	for _, name := range d.canonical {
+		names[name] = true
+	}
+	return len(names)
+}
+
+// WithSkillDictionary - приведение навыков резюме к каноническим названиям словаря
+// Навыки в написании соискателя сохраняются в поле SkillsOriginal
+func WithSkillDictionary(dict *SkillDictionary) Option {
+	return func(o *options) {
+		o.skills = dict
+	}
+}
+
+// cleanSkill - навык без лишних пробелов
+func cleanSkill(skill string) string {
+	return strings.Join(strings.Fields(skill), " ")
+}
+
+// skillKey - ключ сравнения написаний навыка
+func skillKey(skill string) string {
+	var key strings.Builder
+	for _, r := range strings.ToLower(skill) {
+		if unicode.IsSpace(r) || r == '.' || r == '-' || r == '_' {
+			continue
+		}
+		if latin, ok := cyrillicLookalikes[r]; ok {
+			r = latin
+		}
+		key.WriteRune(r)
+	}
+	return key.String()
+}
+
+// cyrillicLookalikes - кириллические буквы, которые пишут вместо похожих латинских
+var cyrillicLookalikes = map[rune]rune{
+	'а': 'a', 'в': 'b', 'е': 'e', 'ё': 'e', 'к': 'k', 'м': 'm', 'н': 'h',
+	'о': 'o', 'р': 'p', 'с': 'c', 'т': 't', 'у': 'y', 'х': 'x',
+}
+
+// DefaultSkillAliases - встроенный словарь распространенных IT навыков
+func DefaultSkillAliases() map[string][]string {
+	return map[string][]string{
+		"Go":               {"Golang", "Go lang", "Го", "Голанг"},
+		"Python":           {"Python3", "Python 3", "Питон", "Пайтон"},
+		"Java":             {"Java SE", "Java EE", "Джава"},
+		"JavaScript":       {"JS", "ECMAScript", "ES6", "Джаваскрипт"},
+		"TypeScript":       {"TS"},
+		"C#":               {"CSharp", "C Sharp", "Си шарп"},
+		"C++":              {"CPP", "Си плюс плюс"},
+		"PHP":              {"ПХП"},
+		"Kotlin":           {"Котлин"},
+		"Ruby":             {"Руби"},
+		"Rust":             {"Rust lang"},
+		"1C":               {"1С:Предприятие", "1C:Enterprise", "1С Предприятие"},
+		".NET":             {"dotnet", "dot net", ".NET Core"},
+		"Node.js":          {"NodeJS", "Node"},
+		"React":            {"ReactJS", "React.js"},
+		"Vue.js":           {"Vue", "VueJS"},
+		"Angular":          {"AngularJS", "Angular 2+"},
+		"SQL":              {"SQL запросы"},
+		"PostgreSQL":       {"Postgres", "Postgre", "PgSQL", "PSQL", "Постгрес"},
+		"MySQL":            {"My SQL"},
+		"MongoDB":          {"Mongo", "Монго"},
+		"Redis":            {"Редис"},
+		"ClickHouse":       {"Click House", "Кликхаус"},
+		"Elasticsearch":    {"Elastic", "Elastic Search"},
+		"Kafka":            {"Apache Kafka", "Кафка"},
+		"RabbitMQ":         {"Rabbit MQ", "Rabbit"},
+		"gRPC":             {"grpc api"},
+		"REST API":         {"REST", "RESTful", "RESTful API"},
+		"GraphQL":          {"Graph QL"},
+		"Docker":           {"Докер"},
+		"Kubernetes":       {"K8s", "Kube", "Кубернетес"},
+		"Helm":             {"Helm charts"},
+		"Terraform":        {"Терраформ"},
+		"Ansible":          {"Ансибл"},
+		"Linux":            {"Линукс", "GNU/Linux"},
+		"Git":              {"Гит"},
+		"CI/CD":            {"CICD", "CI CD", "CI & CD"},
+		"Jenkins":          {"Дженкинс"},
+		"Prometheus":       {"Прометеус"},
+		"Grafana":          {"Графана"},
+		"AWS":              {"Amazon Web Services"},
+		"GCP":              {"Google Cloud", "Google Cloud Platform"},
+		"Microservices":    {"Микросервисы", "Микросервисная архитектура", "Microservice architecture"},
+		"Machine Learning": {"ML", "Машинное обучение"},
+	}
+}
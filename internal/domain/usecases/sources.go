@@ -0,0 +1,96 @@
+package usecases
+
+import (
+	"strings"
+	"unicode"
+
+	"hh-resume-parser/internal/domain/entities"
+	"hh-resume-parser/internal/domain/repositories"
+)
+
+// Source - именованный источник резюме
+// Результаты нескольких источников объединяются за один запуск
+type Source struct {
+	Name       string                        // Имя источника в логах, итогах и поле source резюме
+	Repository repositories.ResumeRepository // Репозиторий источника
+
+	// PaidContacts - открытие контактов в источнике расходует бюджет (WithContactBudget)
+	// Контакты локальных источников уже открыты и бюджет не расходуют
+	PaidContacts bool
+}
+
+// SourceResult - итоги сбора по одному источнику
+type SourceResult struct {
+	Name       string  // Имя источника
+	Found      int     // Количество записей в выдаче источника
+	Saved      int     // Количество сохраненных резюме
+	Skipped    int     // Количество пропущенных резюме, включая дубликаты
+	Duplicates int     // Количество резюме, уже полученных из другого источника
+	Errors     []error // Ошибки источника
+	Aborted    error   // Ошибка, из-за которой обход источника прерван
+}
+
+// WithSources - сбор резюме из нескольких источников по очереди
+// Резюме, уже полученное из предыдущего источника (по ID или контактам соискателя),
+// считается дубликатом и повторно не сохраняется
+func WithSources(sources ...Source) Option {
+	return func(o *options) {
+		o.sources = sources
+	}
+}
+
+// sourceOwners - какому источнику принадлежат резюме, собранные за запуск
+// Резюме узнается по идентификатору, а между источниками еще и по контактам соискателя
+type sourceOwners struct {
+	byID       map[string]string
+	byIdentity map[string]string
+}
+
+// newSourceOwners - создание пустого реестра
+func newSourceOwners() *sourceOwners {
+	return &sourceOwners{byID: make(map[string]string), byIdentity: make(map[string]string)}
+}
+
+// duplicateOf - источник, из которого резюме уже получено, если это другой источник
+func (o *sourceOwners) duplicateOf(source string, resume *entities.Resume) (string, bool) {
+	if owner, ok := o.byID[resume.ID]; ok && owner != source {
+		return owner, true
+	}
+	for _, key := range identityKeys(resume) {
+		if owner, ok := o.byIdentity[key]; ok && owner != source {
+			return owner, true
+		}
+	}
+	return "", false
+}
+
+// add - запись резюме за источником
+func (o *sourceOwners) add(source string, resume *entities.Resume) {
+	o.byID[resume.ID] = source
+	for _, key := range identityKeys(resume) {
+		if _, ok := o.byIdentity[key]; !ok {
+			o.byIdentity[key] = source
+		}
+	}
+}
+
+// identityKeys - ключи соискателя для поиска дубликатов между источниками:
+// email без учета регистра и последние 10 цифр телефона
+func identityKeys(resume *entities.Resume) []string {
+	var keys []string
+	if email := strings.ToLower(strings.TrimSpace(resume.Contact.Email)); email != "" {
+		keys = append(keys, "email:"+email)
+	}
+
+	digits := strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, resume.Contact.Phone)
+	if len(digits) >= 10 {
+		keys = append(keys, "phone:"+digits[len(digits)-10:])
+	}
+
+	return keys
+}
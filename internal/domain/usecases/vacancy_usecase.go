@@ -0,0 +1,128 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"hh-resume-parser/internal/domain/entities"
+	"hh-resume-parser/internal/domain/repositories"
+	"hh-resume-parser/internal/infrastructure/logger"
+)
+
+// VacancyUseCase - сценарий сбора вакансий по тем же критериям, что и резюме
+// Использует общий с резюме обход выдачи: план поиска, пагинацию, дедупликацию и обработку ошибок
+type VacancyUseCase struct {
+	vacancyRepo repositories.VacancyRepository        // Репозиторий для получения вакансий
+	storageRepo repositories.VacancyStorageRepository // Репозиторий для сохранения вакансий
+	logger      logger.Logger                         // Логгер для записи событий
+	processed   map[string]bool                       // Сохраненные ранее вакансии
+	options     options                               // Дополнительные настройки
+}
+
+// NewVacancyUseCase - создание нового экземпляра use case вакансий
+func NewVacancyUseCase(
+	vacancyRepo repositories.VacancyRepository,
+	storageRepo repositories.VacancyStorageRepository,
+	logger logger.Logger,
+	opts ...Option,
+) *VacancyUseCase {
+	return &VacancyUseCase{
+		vacancyRepo: vacancyRepo,
+		storageRepo: storageRepo,
+		logger:      logger,
+		processed:   make(map[string]bool),
+		options:     newOptions(opts),
+	}
+}
+
+// ParseVacanciesByCriteria - поиск, фильтрация и сохранение вакансий по критериям
+func (uc *VacancyUseCase) ParseVacanciesByCriteria(ctx context.Context, criteria repositories.SearchCriteria) (*ParseResult, error) {
+	uc.logger.Info("Начинаем сбор вакансий", map[string]interface{}{
+		"keywords":    criteria.Keywords,
+		"city":        criteria.City,
+		"experience":  criteria.Experience,
+		"update_days": criteria.UpdateDays,
+	})
+
+	result := &ParseResult{Errors: make([]error, 0)}
+
+	// Загрузка списка уже сохраненных вакансий для предотвращения дублирования
+	if savedIDs, err := uc.storageRepo.GetSavedVacancyIDs(ctx); err != nil {
+		uc.logger.Error("Ошибка загрузки списка сохраненных вакансий", err)
+	} else {
+		for _, id := range savedIDs {
+			uc.processed[id] = true
+		}
+	}
+
+	vacancies, matches, abortErr := collect(ctx, uc.logger, uc.searchSource(), criteria, uc.options.perKeyword, result)
+	for i := range vacancies {
+		vacancies[i].MatchedKeywords = matches[vacancies[i].ID]
+	}
+
+	if len(vacancies) > 0 {
+		if err := uc.storageRepo.SaveVacancies(ctx, vacancies); err != nil {
+			uc.logger.Error("Ошибка сохранения вакансий", err)
+			return result, fmt.Errorf("ошибка сохранения вакансий: %w", err)
+		}
+
+		uc.logger.Info("Вакансии успешно сохранены", map[string]interface{}{
+			"count": len(vacancies),
+		})
+	}
+
+	result.TotalFound = result.ProcessedCount
+
+	if abortErr != nil {
+		return result, fmt.Errorf("сбор вакансий прерван: %w", abortErr)
+	}
+
+	return result, nil
+}
+
+// searchSource - описание выдачи вакансий для общего постраничного обхода
+func (uc *VacancyUseCase) searchSource() searchSource[entities.Vacancy] {
+	src := searchSource[entities.Vacancy]{
+		kind:          "вакансии",
+		search:        uc.vacancyRepo.SearchVacancies,
+		id:            func(vacancy entities.Vacancy) string { return vacancy.ID },
+		processed:     func(id string) bool { return uc.processed[id] },
+		markProcessed: func(id string) { uc.processed[id] = true },
+		process:       uc.processVacancies,
+	}
+	if planner, ok := uc.vacancyRepo.(repositories.VacancySearchPlanner); ok {
+		src.planner = planner.PlanVacancySearch
+	}
+	return src
+}
+
+// processVacancies - обработка новых вакансий страницы: загрузка полных версий и валидация
+func (uc *VacancyUseCase) processVacancies(ctx context.Context, vacancies []entities.Vacancy, result *ParseResult) []entities.Vacancy {
+	// Описание и ключевые навыки есть только в полной версии вакансии
+	vacancies = fetchDetails(ctx, uc.logger, uc.options.detailWorkers, vacancies,
+		func(vacancy entities.Vacancy) string { return vacancy.ID },
+		func(ctx context.Context, summary entities.Vacancy) (entities.Vacancy, error) {
+			detail, err := uc.vacancyRepo.GetVacancyByID(ctx, summary.ID)
+			if err != nil {
+				return summary, err
+			}
+			// Сниппеты есть только в выдаче поиска
+			detail.Requirement = summary.Requirement
+			detail.Responsibility = summary.Responsibility
+			return *detail, nil
+		},
+		result,
+	)
+
+	var valid []entities.Vacancy
+	for _, vacancy := range vacancies {
+		if !vacancy.IsValid() {
+			result.SkippedCount++
+			uc.logger.Debug("Вакансия не прошла валидацию", map[string]interface{}{"vacancy_id": vacancy.ID})
+			continue
+		}
+		valid = append(valid, vacancy)
+	}
+
+	return valid
+}
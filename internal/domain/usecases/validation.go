@@ -0,0 +1,121 @@
+package usecases
+
+import (
+	"fmt"
+
+	"hh-resume-parser/internal/domain/entities"
+	"hh-resume-parser/internal/domain/repositories"
+)
+
+// Действия при нарушении правила проверки резюме
+const (
+	SeverityDrop = "drop" // Резюме пропускается
+	SeverityWarn = "warn" // Резюме сохраняется, нарушение попадает в журнал и итоги
+)
+
+// Имена встроенных правил проверки
+const (
+	RuleRequiredFields     = "required_fields"      // Указаны ID и имя или заголовок резюме
+	RuleSkillsOrExperience = "skills_or_experience" // Указаны навыки или опыт работы
+)
+
+// ValidationRule - правило проверки резюме перед сохранением
+// Условие записывается так же, как правило отбора (см. ResumeFilter), и должно выполняться
+// для резюме, прошедшего проверку
+type ValidationRule struct {
+	Name     string // Имя правила в журнале и итогах
+	Severity string // SeverityDrop или SeverityWarn
+	check    func(resume *entities.Resume) bool
+}
+
+// NewValidationRule - правило проверки с условием в формате ResumeFilter
+// Пустое действие означает SeverityDrop
+func NewValidationRule(name, condition, severity string) (ValidationRule, error) {
+	if name == "" {
+		return ValidationRule{}, fmt.Errorf("%w: не указано имя правила проверки", repositories.ErrBadArgument)
+	}
+	if severity == "" {
+		severity = SeverityDrop
+	}
+	if severity != SeverityDrop && severity != SeverityWarn {
+		return ValidationRule{}, fmt.Errorf("%w: неизвестное действие %q правила %s (допустимы %s и %s)",
+			repositories.ErrBadArgument, severity, name, SeverityDrop, SeverityWarn)
+	}
+
+	filter, err := ParseResumeFilter(condition)
+	if err != nil {
+		return ValidationRule{}, fmt.Errorf("правило проверки %s: %w", name, err)
+	}
+	if len(filter.conditions) == 0 {
+		return ValidationRule{}, fmt.Errorf("%w: не указано условие правила проверки %s", repositories.ErrBadArgument, name)
+	}
+
+	return ValidationRule{Name: name, Severity: severity, check: filter.Match}, nil
+}
+
+// requiredFieldsRule - базовая проверка, которая выполняется всегда
+var requiredFieldsRule = ValidationRule{
+	Name:     RuleRequiredFields,
+	Severity: SeverityDrop,
+	check:    func(resume *entities.Resume) bool { return resume.IsValid() },
+}
+
+// DefaultSkillsOrExperience - условие правила skills_or_experience, действующего по умолчанию
+const DefaultSkillsOrExperience = "skills!= | jobs>0"
+
+// DefaultValidationRules - правила проверки, если другие не заданы опцией WithValidationRules
+func DefaultValidationRules() []ValidationRule {
+	rule, _ := NewValidationRule(RuleSkillsOrExperience, DefaultSkillsOrExperience, SeverityDrop)
+	return []ValidationRule{rule}
+}
+
+// WithValidationRules - правила проверки резюме вместо правил по умолчанию
+// Базовая проверка обязательных полей выполняется в любом случае
+func WithValidationRules(rules ...ValidationRule) Option {
+	return func(o *options) {
+		o.rules = rules
+		o.customRules = true
+	}
+}
+
+// validationRules - действующие правила проверки, начиная с базовой
+func (o options) validationRules() []ValidationRule {
+	rules := o.rules
+	if !o.customRules {
+		rules = DefaultValidationRules()
+	}
+	return append([]ValidationRule{requiredFieldsRule}, rules...)
+}
+
+// validate - проверка резюме по правилам
+// Возвращает первое нарушенное правило с действием drop (пустая строка - резюме сохраняется)
+// и нарушенные правила с действием warn
+func validate(rules []ValidationRule, resume *entities.Resume) (dropped string, warnings []string) {
+	for _, rule := range rules {
+		if rule.check(resume) {
+			continue
+		}
+		if rule.Severity == SeverityWarn {
+			warnings = append(warnings, rule.Name)
+			continue
+		}
+		return rule.Name, nil
+	}
+	return "", warnings
+}
+
+// countSkipped - учет резюме, пропущенных по правилу проверки
+func (r *ParseResult) countSkipped(rule string, count int) {
+	if r.SkippedByRule == nil {
+		r.SkippedByRule = make(map[string]int)
+	}
+	r.SkippedByRule[rule] += count
+}
+
+// countWarning - учет сохраненных резюме, нарушивших правило с действием warn
+func (r *ParseResult) countWarning(rule string, count int) {
+	if r.WarningsByRule == nil {
+		r.WarningsByRule = make(map[string]int)
+	}
+	r.WarningsByRule[rule] += count
+}
@@ -0,0 +1,367 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"hh-resume-parser/internal/config"
+	"hh-resume-parser/internal/domain/repositories"
+	"hh-resume-parser/internal/infrastructure/logger"
+	"hh-resume-parser/internal/infrastructure/ratelimit"
+)
+
+// Состояние токена в пуле
+const (
+	TokenActive      = "active"      // Токен участвует в ротации
+	TokenExhausted   = "exhausted"   // Квота токена исчерпана, токен временно исключен из ротации
+	TokenUnavailable = "unavailable" // Токен не удалось получить или обновить из-за сбоя, токен временно исключен
+	TokenRevoked     = "revoked"     // Токен отозван и не может быть обновлен
+)
+
+// Задержка перед повторным получением токена после сбоя: удваивается с каждым сбоем подряд
+const (
+	tokenRetryMin = time.Second
+	tokenRetryMax = time.Minute
+)
+
+// PoolToken - токен аккаунта в пуле
+type PoolToken struct {
+	Name    string             // Имя токена в логах
+	Source  TokenSource        // Источник токена
+	Limiter *ratelimit.Limiter // Собственные ограничения токена (может отсутствовать)
+}
+
+// TokenPool - пул токенов нескольких аккаунтов с ротацией
+// Запросы распределяются между токенами по очереди. Токен с исчерпанной квотой
+// и токен, который не удалось получить из-за сбоя сети или сервера авторизации,
+// временно исключаются из ротации, отозванный - до конца работы.
+// Безопасен для одновременного использования из нескольких горутин
+type TokenPool struct {
+	members []*poolMember
+	logger  logger.Logger
+	now     func() time.Time
+
+	mu   sync.Mutex
+	next int // Номер токена, с которого начнется поиск для следующего запроса
+}
+
+// poolMember - токен пула и его состояние
+type poolMember struct {
+	PoolToken
+
+	// Поля ниже защищены мьютексом пула
+	status    string    // TokenActive, TokenExhausted, TokenUnavailable или TokenRevoked
+	until     time.Time // До какого времени исключен токен с исчерпанной квотой или после сбоя
+	reason    error     // Причина исключения из ротации
+	refreshed string    // Токен, полученный последним обновлением
+	requests  int       // Количество выданных запросов
+	failures  int       // Количество сбоев получения токена подряд
+}
+
+// Lease - токен, выданный пулом для одного запроса
+type Lease struct {
+	Token  string // Значение токена для заголовка Authorization
+	member *poolMember
+}
+
+// Label - имя токена для логов: имя и отпечаток вместо самого токена
+func (l *Lease) Label() string {
+	return fmt.Sprintf("%s#%s", l.member.Name, Fingerprint(l.Token))
+}
+
+// TokenStats - статистика использования токена пула
+type TokenStats struct {
+	Name       string // Имя токена
+	Status     string // TokenActive, TokenExhausted, TokenUnavailable или TokenRevoked
+	Reason     string // Причина исключения из ротации
+	Requests   int    // Количество запросов с этим токеном
+	DailyUsed  int    // Использовано собственной суточной квоты
+	DailyQuota int    // Собственная суточная квота (0 - без ограничения)
+}
+
+// Fingerprint - короткий отпечаток токена, по которому его можно узнать в логах, не раскрывая
+func Fingerprint(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:4])
+}
+
+// NewTokenPool - создание пула из готовых токенов
+func NewTokenPool(tokens []PoolToken, logger logger.Logger) *TokenPool {
+	p := &TokenPool{logger: logger, now: time.Now}
+	for _, token := range tokens {
+		p.members = append(p.members, &poolMember{PoolToken: token, status: TokenActive})
+	}
+	return p
+}
+
+// NewConfigTokenPool - создание пула по конфигурации
+// Токены из Tokens получают собственные ограничения скорости и квоты;
+// если пул не задан, в нем единственный токен из NewTokenSource
+func NewConfigTokenPool(cfg config.APIConfig, client *http.Client, logger logger.Logger) *TokenPool {
+	if len(cfg.Tokens) == 0 {
+		return NewTokenPool([]PoolToken{{Name: "default", Source: NewTokenSource(cfg, client, logger)}}, logger)
+	}
+	return NewStaticTokenPool(cfg.Tokens, logger)
+}
+
+// NewStaticTokenPool - создание пула из постоянных токенов с собственными ограничениями
+func NewStaticTokenPool(configs []config.TokenConfig, logger logger.Logger) *TokenPool {
+	tokens := make([]PoolToken, 0, len(configs))
+	for i, token := range configs {
+		name := token.Name
+		if name == "" {
+			name = fmt.Sprintf("token-%d", i+1)
+		}
+		tokens = append(tokens, PoolToken{
+			Name:   name,
+			Source: NewStaticTokenSource(token.Token),
+			Limiter: ratelimit.New(config.APIConfig{
+				RateLimit:  token.RateLimit,
+				RateBurst:  token.RateBurst,
+				DailyQuota: token.DailyQuota,
+			}),
+		})
+	}
+	return NewTokenPool(tokens, logger)
+}
+
+// Acquire - выбор токена для запроса к конечной точке
+// Ждет разрешения собственного ограничителя токена. Если доступных токенов нет,
+// возвращает причину исключения последнего из них (ErrRateLimited, ErrUnauthorized или ошибку сбоя)
+func (p *TokenPool) Acquire(ctx context.Context, endpoint string) (*Lease, error) {
+	p.mu.Lock()
+	start := p.next
+	p.next = (p.next + 1) % len(p.members)
+	p.mu.Unlock()
+
+	var lastErr error
+	for i := range p.members {
+		member := p.members[(start+i)%len(p.members)]
+
+		if err := p.available(member); err != nil {
+			lastErr = err
+			continue
+		}
+
+		if member.Limiter != nil {
+			if _, err := member.Limiter.Wait(ctx, endpoint); err != nil {
+				if ctx.Err() != nil {
+					return nil, err
+				}
+				// Собственная суточная квота исчерпана до начала следующих суток
+				p.exclude(member, TokenExhausted, nextDay(p.now()), err)
+				lastErr = err
+				continue
+			}
+		}
+
+		token, err := member.Source.Token(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			p.fail(member, err)
+			lastErr = err
+			continue
+		}
+
+		p.mu.Lock()
+		member.requests++
+		member.failures = 0
+		p.mu.Unlock()
+
+		return &Lease{Token: token, member: member}, nil
+	}
+
+	return nil, fmt.Errorf("нет доступных токенов: %w", lastErr)
+}
+
+// Refund - возврат бюджета запроса, на который сервер ответил 304
+func (p *TokenPool) Refund(lease *Lease, endpoint string) {
+	if lease.member.Limiter != nil {
+		lease.member.Limiter.Refund(endpoint)
+	}
+}
+
+// Unauthorized - обработка ответа 401 на запрос с токеном lease
+// Токен обновляется один раз; если сервер авторизации отказал в обновлении или обновленный токен
+// тоже отклонен, он исключается из ротации, при сбое обновления - временно.
+// Возвращает true, если запрос стоит повторить
+func (p *TokenPool) Unauthorized(ctx context.Context, lease *Lease) bool {
+	member := lease.member
+
+	p.mu.Lock()
+	retried := member.refreshed != "" && member.refreshed == lease.Token
+	p.mu.Unlock()
+
+	if !retried {
+		token, err := member.Source.Refresh(ctx, lease.Token)
+		if err == nil {
+			p.mu.Lock()
+			member.refreshed = token
+			member.failures = 0
+			p.mu.Unlock()
+			return true
+		}
+		if ctx.Err() != nil {
+			return false
+		}
+		p.fail(member, err)
+	} else {
+		p.exclude(member, TokenRevoked, time.Time{}, fmt.Errorf("%w: обновленный токен отклонен", repositories.ErrUnauthorized))
+	}
+
+	return p.Active() > 0
+}
+
+// RateLimited - обработка ответа 429 на запрос с токеном lease
+// Если в ротации есть другие токены, этот исключается до истечения retryAfter
+// (до следующих суток, если сервер не указал срок) и возвращается true.
+// Последний активный токен не исключается: запрос повторяется с обычной задержкой
+func (p *TokenPool) RateLimited(lease *Lease, retryAfter time.Duration, err error) bool {
+	p.mu.Lock()
+	now := p.now()
+	others := 0
+	for _, member := range p.members {
+		if member != lease.member && p.activeLocked(member, now) {
+			others++
+		}
+	}
+	p.mu.Unlock()
+
+	if others == 0 {
+		return false
+	}
+
+	until := nextDay(now)
+	if retryAfter > 0 {
+		until = now.Add(retryAfter)
+	}
+	p.exclude(lease.member, TokenExhausted, until, err)
+	return true
+}
+
+// Active - количество токенов в ротации
+func (p *TokenPool) Active() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	active := 0
+	for _, member := range p.members {
+		if p.activeLocked(member, now) {
+			active++
+		}
+	}
+	return active
+}
+
+// Stats - статистика использования токенов в порядке их добавления в пул
+func (p *TokenPool) Stats() []TokenStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	stats := make([]TokenStats, 0, len(p.members))
+	for _, member := range p.members {
+		s := TokenStats{Name: member.Name, Status: TokenActive, Requests: member.requests}
+		if !p.activeLocked(member, now) {
+			s.Status = member.status
+			s.Reason = member.reason.Error()
+		}
+		if member.Limiter != nil {
+			limiterStats := member.Limiter.Stats()
+			s.DailyUsed = limiterStats.DailyUsed
+			s.DailyQuota = limiterStats.DailyQuota
+		}
+		stats = append(stats, s)
+	}
+	return stats
+}
+
+// available - ошибка, если токен исключен из ротации
+func (p *TokenPool) available(member *poolMember) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.activeLocked(member, p.now()) {
+		return nil
+	}
+	return member.reason
+}
+
+// activeLocked - участвует ли токен в ротации (вызывается под блокировкой)
+// Временно исключенный токен возвращается в ротацию, когда истекает срок исключения
+func (p *TokenPool) activeLocked(member *poolMember, now time.Time) bool {
+	if (member.status == TokenExhausted || member.status == TokenUnavailable) && !now.Before(member.until) {
+		member.status = TokenActive
+		member.reason = nil
+	}
+	return member.status == TokenActive
+}
+
+// fail - исключение токена, который не удалось получить или обновить
+// Отказ сервера авторизации (ErrUnauthorized) исключает токен до конца работы, остальные ошибки -
+// сбой сети или ответ 5xx - только до истечения задержки, которая растет с каждым сбоем подряд
+func (p *TokenPool) fail(member *poolMember, err error) {
+	if errors.Is(err, repositories.ErrUnauthorized) {
+		p.exclude(member, TokenRevoked, time.Time{}, err)
+		return
+	}
+
+	p.mu.Lock()
+	member.failures++
+	delay := tokenRetryMax
+	if member.failures <= 6 {
+		delay = tokenRetryMin << (member.failures - 1)
+	}
+	until := p.now().Add(delay)
+	p.mu.Unlock()
+
+	p.exclude(member, TokenUnavailable, until, err)
+}
+
+// exclude - исключение токена из ротации до until (для отозванного токена не используется)
+// Повторное исключение по той же причине в лог не записывается
+func (p *TokenPool) exclude(member *poolMember, status string, until time.Time, reason error) {
+	p.mu.Lock()
+	p.activeLocked(member, p.now())
+	changed := member.status != status
+	member.status = status
+	member.until = until
+	member.reason = reason
+	p.mu.Unlock()
+
+	if !changed {
+		return
+	}
+
+	fields := map[string]interface{}{
+		"token":  member.Name,
+		"status": status,
+		"error":  reason.Error(),
+	}
+	if !until.IsZero() {
+		fields["until"] = until.Format(time.RFC3339)
+	}
+	switch status {
+	case TokenRevoked:
+		p.logger.Warn("Токен исключен из ротации", fields)
+	case TokenUnavailable:
+		p.logger.Warn("Не удалось получить токен, токен временно исключен из ротации", fields)
+	default:
+		p.logger.Warn("Квота токена исчерпана, токен временно исключен из ротации", fields)
+	}
+}
+
+// nextDay - начало следующих суток
+func nextDay(now time.Time) time.Time {
+	year, month, day := now.Date()
+	return time.Date(year, month, day+1, 0, 0, 0, 0, now.Location())
+}
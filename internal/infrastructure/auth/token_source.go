@@ -0,0 +1,281 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"hh-resume-parser/internal/config"
+	"hh-resume-parser/internal/domain/repositories"
+	"hh-resume-parser/internal/infrastructure/logger"
+)
+
+// TokenSource - источник токенов доступа к API
+// Реализации безопасны для одновременного использования из нескольких горутин
+type TokenSource interface {
+	// Token - получение действующего токена доступа
+	Token(ctx context.Context) (string, error)
+
+	// Refresh - принудительное обновление токена после ответа 401
+	// stale - токен, который был отклонен; если его уже заменили, возвращается текущий
+	Refresh(ctx context.Context, stale string) (string, error)
+}
+
+// NewTokenSource - создание источника токенов по конфигурации
+// При заданном ClientID используется OAuth2, иначе статический токен
+func NewTokenSource(cfg config.APIConfig, client *http.Client, logger logger.Logger) TokenSource {
+	if cfg.OAuth.ClientID != "" {
+		return NewOAuthTokenSource(cfg.OAuth, NewFileTokenStore(cfg.OAuth.TokenFile), client, logger)
+	}
+	return NewStaticTokenSource(cfg.Token)
+}
+
+// staticTokenSource - источник с постоянным токеном
+type staticTokenSource struct {
+	token string
+}
+
+// NewStaticTokenSource - создание источника с постоянным токеном
+func NewStaticTokenSource(token string) TokenSource {
+	return &staticTokenSource{token: token}
+}
+
+// Token - возврат постоянного токена
+func (s *staticTokenSource) Token(ctx context.Context) (string, error) {
+	return s.token, nil
+}
+
+// Refresh - постоянный токен обновить нельзя
+func (s *staticTokenSource) Refresh(ctx context.Context, stale string) (string, error) {
+	return "", fmt.Errorf("%w: статический токен не может быть обновлен", repositories.ErrUnauthorized)
+}
+
+// OAuthTokenSource - источник токенов OAuth2 с автоматическим обновлением
+type OAuthTokenSource struct {
+	config config.OAuthConfig
+	store  TokenStore
+	client *http.Client
+	logger logger.Logger
+
+	mu    sync.Mutex // Защищает token и сериализует обновление
+	token *Token     // Текущий токен (загружается из хранилища при первом обращении)
+}
+
+// NewOAuthTokenSource - создание источника токенов OAuth2
+func NewOAuthTokenSource(cfg config.OAuthConfig, store TokenStore, client *http.Client, logger logger.Logger) *OAuthTokenSource {
+	return &OAuthTokenSource{
+		config: cfg,
+		store:  store,
+		client: client,
+		logger: logger,
+	}
+}
+
+// AuthCodeURL - адрес страницы, на которой пользователь разрешает доступ приложению
+func (s *OAuthTokenSource) AuthCodeURL(state string) string {
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", s.config.ClientID)
+	if state != "" {
+		params.Set("state", state)
+	}
+	if s.config.RedirectURI != "" {
+		params.Set("redirect_uri", s.config.RedirectURI)
+	}
+
+	sep := "?"
+	if strings.Contains(s.config.AuthURL, "?") {
+		sep = "&"
+	}
+	return s.config.AuthURL + sep + params.Encode()
+}
+
+// Exchange - обмен кода авторизации на токены и их сохранение
+func (s *OAuthTokenSource) Exchange(ctx context.Context, code string) (*Token, error) {
+	params := url.Values{}
+	params.Set("grant_type", "authorization_code")
+	params.Set("code", code)
+	if s.config.RedirectURI != "" {
+		params.Set("redirect_uri", s.config.RedirectURI)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token, err := s.requestToken(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка обмена кода авторизации: %w", err)
+	}
+
+	if err := s.store.Save(token); err != nil {
+		return nil, err
+	}
+	s.token = token
+
+	s.logger.Info("Получен OAuth токен", map[string]interface{}{
+		"expires_at": token.ExpiresAt.Format(time.RFC3339),
+	})
+
+	return token, nil
+}
+
+// Token - получение действующего токена доступа
+// Токен обновляется заранее, если истекает в течение RefreshBefore
+func (s *OAuthTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.loadLocked(); err != nil {
+		return "", err
+	}
+
+	if s.token.ExpiresWithin(s.config.RefreshBefore) {
+		if err := s.refreshLocked(ctx); err != nil {
+			return "", err
+		}
+	}
+
+	return s.token.AccessToken, nil
+}
+
+// Refresh - принудительное обновление токена после ответа 401
+func (s *OAuthTokenSource) Refresh(ctx context.Context, stale string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.loadLocked(); err != nil {
+		return "", err
+	}
+
+	// Другой запрос уже обновил токен, пока этот ждал блокировку
+	if s.token.AccessToken != stale && !s.token.ExpiresWithin(0) {
+		return s.token.AccessToken, nil
+	}
+
+	if err := s.refreshLocked(ctx); err != nil {
+		return "", err
+	}
+
+	return s.token.AccessToken, nil
+}
+
+// loadLocked - загрузка токена из хранилища (вызывается под блокировкой)
+func (s *OAuthTokenSource) loadLocked() error {
+	if s.token != nil {
+		return nil
+	}
+
+	token, err := s.store.Load()
+	if err != nil {
+		return err
+	}
+	if token == nil || token.AccessToken == "" {
+		return fmt.Errorf("%w: OAuth токен не найден, выполните авторизацию с флагом -auth-code", repositories.ErrUnauthorized)
+	}
+
+	s.token = token
+	return nil
+}
+
+// refreshLocked - обновление токена по refresh token (вызывается под блокировкой)
+func (s *OAuthTokenSource) refreshLocked(ctx context.Context) error {
+	if s.token.RefreshToken == "" {
+		return fmt.Errorf("%w: нет токена обновления", repositories.ErrUnauthorized)
+	}
+
+	params := url.Values{}
+	params.Set("grant_type", "refresh_token")
+	params.Set("refresh_token", s.token.RefreshToken)
+
+	token, err := s.requestToken(ctx, params)
+	if err != nil {
+		return fmt.Errorf("ошибка обновления OAuth токена: %w", err)
+	}
+
+	// Сервер может не выдать новый токен обновления - продолжаем использовать старый
+	if token.RefreshToken == "" {
+		token.RefreshToken = s.token.RefreshToken
+	}
+
+	if err := s.store.Save(token); err != nil {
+		return err
+	}
+	s.token = token
+
+	s.logger.Info("OAuth токен обновлен", map[string]interface{}{
+		"expires_at": token.ExpiresAt.Format(time.RFC3339),
+	})
+
+	return nil
+}
+
+// tokenResponse - ответ сервера авторизации
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	RefreshToken     string `json:"refresh_token"`
+	TokenType        string `json:"token_type"`
+	ExpiresIn        int64  `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// requestToken - запрос к серверу авторизации
+func (s *OAuthTokenSource) requestToken(ctx context.Context, params url.Values) (*Token, error) {
+	params.Set("client_id", s.config.ClientID)
+	params.Set("client_secret", s.config.ClientSecret)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.config.TokenURL, strings.NewReader(params.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка создания запроса: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения ответа: %w", err)
+	}
+
+	var tokenResp tokenResponse
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return nil, fmt.Errorf("сервер авторизации вернул статус %d: %s", resp.StatusCode, string(body))
+	}
+
+	if resp.StatusCode != http.StatusOK || tokenResp.Error != "" {
+		kind := repositories.ErrBadArgument
+		if tokenResp.Error == "invalid_grant" || tokenResp.Error == "invalid_client" || resp.StatusCode == http.StatusUnauthorized {
+			kind = repositories.ErrUnauthorized
+		} else if resp.StatusCode >= 500 {
+			kind = repositories.ErrServer
+		}
+		return nil, fmt.Errorf("%w: %s %s", kind, tokenResp.Error, tokenResp.ErrorDescription)
+	}
+
+	if tokenResp.AccessToken == "" {
+		return nil, errors.New("сервер авторизации не вернул токен доступа")
+	}
+
+	token := &Token{
+		AccessToken:  tokenResp.AccessToken,
+		RefreshToken: tokenResp.RefreshToken,
+		TokenType:    tokenResp.TokenType,
+	}
+	if tokenResp.ExpiresIn > 0 {
+		token.ExpiresAt = time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+	}
+
+	return token, nil
+}
@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Token - набор OAuth2 токенов
+type Token struct {
+	AccessToken  string    `json:"access_token"`            // Токен доступа
+	RefreshToken string    `json:"refresh_token,omitempty"` // Токен обновления
+	TokenType    string    `json:"token_type,omitempty"`    // Тип токена (bearer)
+	ExpiresAt    time.Time `json:"expires_at,omitempty"`    // Момент истечения токена доступа
+}
+
+// ExpiresWithin - проверка, что токен истекает в течение указанного времени
+// Токен без срока годности считается бессрочным
+func (t *Token) ExpiresWithin(d time.Duration) bool {
+	if t.ExpiresAt.IsZero() {
+		return false
+	}
+	return time.Until(t.ExpiresAt) <= d
+}
+
+// TokenStore - хранилище OAuth2 токенов
+type TokenStore interface {
+	// Load - загрузка токена; возвращает nil без ошибки, если токен еще не сохранен
+	Load() (*Token, error)
+
+	// Save - сохранение токена
+	Save(token *Token) error
+}
+
+// fileTokenStore - хранение токенов в локальном файле с правами только для владельца
+type fileTokenStore struct {
+	path string
+}
+
+// NewFileTokenStore - создание файлового хранилища токенов
+func NewFileTokenStore(path string) TokenStore {
+	return &fileTokenStore{path: path}
+}
+
+// Load - чтение токена из файла
+func (s *fileTokenStore) Load() (*Token, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("ошибка чтения файла токенов %s: %w", s.path, err)
+	}
+
+	var token Token
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, fmt.Errorf("ошибка парсинга файла токенов %s: %w", s.path, err)
+	}
+
+	return &token, nil
+}
+
+// Save - запись токена в файл
+// Файл создается с правами 0600 и заменяется атомарно
+func (s *fileTokenStore) Save(token *Token) error {
+	data, err := json.MarshalIndent(token, "", "  ")
+	if err != nil {
+		return fmt.Errorf("ошибка сериализации токена: %w", err)
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("ошибка создания каталога для токенов: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, ".token-*")
+	if err != nil {
+		return fmt.Errorf("ошибка создания временного файла токенов: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return fmt.Errorf("ошибка установки прав на файл токенов: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("ошибка записи файла токенов: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("ошибка записи файла токенов: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("ошибка сохранения файла токенов: %w", err)
+	}
+
+	return nil
+}
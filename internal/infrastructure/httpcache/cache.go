@@ -0,0 +1,368 @@
+package httpcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"hh-resume-parser/internal/infrastructure/logger"
+)
+
+// Cache - дисковый кэш HTTP ответов API
+// Хранит тела успешных ответов вместе с валидаторами ETag и Last-Modified.
+// Свежие записи отдаются без запроса, устаревшие - перепроверяются условным запросом.
+// Размер кэша ограничен: при превышении удаляются записи, к которым дольше всего не обращались.
+// Безопасен для одновременного использования из нескольких горутин
+type Cache struct {
+	dir     string                   // Каталог кэша
+	maxSize int64                    // Максимальный размер записей на диске (0 - без ограничения)
+	ttls    map[string]time.Duration // Сроки свежести по путям запросов
+	logger  logger.Logger
+
+	mu     sync.Mutex
+	index  map[string]*indexEntry // Записи на диске по имени файла (загружаются при первом обращении)
+	size   int64                  // Суммарный размер записей
+	stats  Stats
+	now    func() time.Time
+	loaded bool
+}
+
+// Stats - статистика кэша за время работы
+type Stats struct {
+	Hits        int   // Ответы из кэша без запроса к API
+	Revalidated int   // Ответы 304: запрос выполнен, тело взято из кэша
+	Misses      int   // Ответы, полученные из API целиком
+	Stored      int   // Сохраненные записи
+	Evicted     int   // Удаленные из-за ограничения размера записи
+	Size        int64 // Текущий размер кэша в байтах
+}
+
+// Entry - сохраненный ответ
+type Entry struct {
+	URL          string    `json:"url"`                     // Адрес запроса
+	ETag         string    `json:"etag,omitempty"`          // Валидатор ETag
+	LastModified string    `json:"last_modified,omitempty"` // Валидатор Last-Modified
+	ContentType  string    `json:"content_type,omitempty"`  // Тип содержимого
+	Body         []byte    `json:"body"`                    // Тело ответа
+	StoredAt     time.Time `json:"stored_at"`               // Время получения ответа
+	ExpiresAt    time.Time `json:"expires_at"`              // Время, до которого ответ свежий
+}
+
+// indexEntry - сведения о файле записи для вытеснения
+type indexEntry struct {
+	size     int64
+	accessed time.Time
+}
+
+// New - создание кэша в каталоге dir
+// ttls задает сроки свежести по путям: "/dictionaries" - точный путь,
+// "/resumes/" - все пути с этим префиксом; побеждает самое длинное совпадение.
+// Если срок для пути не задан, используется max-age из Cache-Control ответа
+func New(dir string, maxSize int64, ttls map[string]time.Duration, logger logger.Logger) *Cache {
+	return &Cache{
+		dir:     dir,
+		maxSize: maxSize,
+		ttls:    ttls,
+		logger:  logger,
+		index:   make(map[string]*indexEntry),
+		now:     time.Now,
+	}
+}
+
+// Fresh - можно ли отдать запись без обращения к API
+func (e *Entry) Fresh(now time.Time) bool {
+	return now.Before(e.ExpiresAt)
+}
+
+// HasValidators - можно ли перепроверить запись условным запросом
+func (e *Entry) HasValidators() bool {
+	return e.ETag != "" || e.LastModified != ""
+}
+
+// SetConditionalHeaders - заголовки условного запроса для перепроверки записи
+func (e *Entry) SetConditionalHeaders(header http.Header) {
+	if e.ETag != "" {
+		header.Set("If-None-Match", e.ETag)
+	}
+	if e.LastModified != "" {
+		header.Set("If-Modified-Since", e.LastModified)
+	}
+}
+
+// Lookup - поиск записи по адресу запроса
+// Возвращает запись, если она свежая или ее можно перепроверить; fresh - можно ли обойтись без запроса
+func (c *Cache) Lookup(requestURL string) (entry *Entry, fresh bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry = c.read(requestURL)
+	if entry == nil {
+		return nil, false
+	}
+
+	now := c.now()
+	if entry.Fresh(now) {
+		c.stats.Hits++
+		c.touch(requestURL, now)
+		return entry, true
+	}
+	if !entry.HasValidators() {
+		return nil, false
+	}
+	return entry, false
+}
+
+// Revalidated - учет ответа 304 на условный запрос
+// Срок свежести записи продлевается по заголовкам нового ответа
+func (c *Cache) Revalidated(entry *Entry, header http.Header) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.stats.Revalidated++
+
+	now := c.now()
+	if etag := header.Get("ETag"); etag != "" {
+		entry.ETag = etag
+	}
+	if ttl, cacheable := c.freshness(entry.URL, header); cacheable {
+		entry.ExpiresAt = now.Add(ttl)
+	}
+	entry.StoredAt = now
+
+	if err := c.write(entry); err != nil {
+		c.logger.Warn("Не удалось обновить запись HTTP кэша", map[string]interface{}{"error": err.Error()})
+	}
+}
+
+// Store - учет ответа, полученного целиком, и сохранение его в кэш
+// Ответ сохраняется, если он свежий по сроку или содержит валидаторы
+func (c *Cache) Store(requestURL string, header http.Header, body []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.stats.Misses++
+
+	ttl, cacheable := c.freshness(requestURL, header)
+	if !cacheable {
+		return
+	}
+
+	now := c.now()
+	entry := &Entry{
+		URL:          requestURL,
+		ETag:         header.Get("ETag"),
+		LastModified: header.Get("Last-Modified"),
+		ContentType:  header.Get("Content-Type"),
+		Body:         body,
+		StoredAt:     now,
+		ExpiresAt:    now.Add(ttl),
+	}
+	if ttl <= 0 && !entry.HasValidators() {
+		return
+	}
+
+	if err := c.write(entry); err != nil {
+		c.logger.Warn("Не удалось сохранить ответ в HTTP кэш", map[string]interface{}{"error": err.Error()})
+		return
+	}
+	c.stats.Stored++
+	c.evict()
+}
+
+// Stats - текущая статистика кэша
+func (c *Cache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.load()
+	stats := c.stats
+	stats.Size = c.size
+	return stats
+}
+
+// freshness - срок свежести ответа и можно ли его сохранять
+// Настроенный срок для пути важнее max-age; no-store запрещает сохранение
+func (c *Cache) freshness(requestURL string, header http.Header) (time.Duration, bool) {
+	directives := parseCacheControl(header.Get("Cache-Control"))
+	if _, ok := directives["no-store"]; ok {
+		return 0, false
+	}
+
+	if ttl, ok := c.pathTTL(requestURL); ok {
+		return ttl, true
+	}
+
+	if _, ok := directives["no-cache"]; ok {
+		return 0, true
+	}
+	if value, ok := directives["max-age"]; ok {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+	}
+	return 0, true
+}
+
+// pathTTL - срок свежести, настроенный для пути запроса
+func (c *Cache) pathTTL(requestURL string) (time.Duration, bool) {
+	path := requestURL
+	if u, err := url.Parse(requestURL); err == nil {
+		path = u.Path
+	}
+
+	best := ""
+	for pattern := range c.ttls {
+		matches := path == pattern || (strings.HasSuffix(pattern, "/") && strings.HasPrefix(path, pattern))
+		if matches && len(pattern) > len(best) {
+			best = pattern
+		}
+	}
+	if best == "" {
+		return 0, false
+	}
+	return c.ttls[best], true
+}
+
+// parseCacheControl - разбор директив заголовка Cache-Control
+func parseCacheControl(value string) map[string]string {
+	directives := make(map[string]string)
+	for _, part := range strings.Split(value, ",") {
+		name, arg, _ := strings.Cut(strings.TrimSpace(part), "=")
+		if name != "" {
+			directives[strings.ToLower(name)] = strings.Trim(arg, `"`)
+		}
+	}
+	return directives
+}
+
+// load - построение индекса записей на диске (вызывается под блокировкой)
+func (c *Cache) load() {
+	if c.loaded {
+		return
+	}
+	c.loaded = true
+
+	files, err := os.ReadDir(c.dir)
+	if err != nil {
+		return
+	}
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		info, err := file.Info()
+		if err != nil {
+			continue
+		}
+		c.index[file.Name()] = &indexEntry{size: info.Size(), accessed: info.ModTime()}
+		c.size += info.Size()
+	}
+}
+
+// read - чтение записи с диска (вызывается под блокировкой)
+func (c *Cache) read(requestURL string) *Entry {
+	c.load()
+
+	data, err := os.ReadFile(filepath.Join(c.dir, fileName(requestURL)))
+	if err != nil {
+		return nil
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.URL != requestURL {
+		return nil
+	}
+	return &entry
+}
+
+// write - запись на диск через временный файл (вызывается под блокировкой)
+func (c *Cache) write(entry *Entry) error {
+	c.load()
+
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return fmt.Errorf("ошибка создания каталога HTTP кэша: %w", err)
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("ошибка сериализации ответа: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(c.dir, ".entry-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	name := fileName(entry.URL)
+	if err := os.Rename(tmp.Name(), filepath.Join(c.dir, name)); err != nil {
+		return err
+	}
+
+	if old, ok := c.index[name]; ok {
+		c.size -= old.size
+	}
+	c.index[name] = &indexEntry{size: int64(len(data)), accessed: c.now()}
+	c.size += int64(len(data))
+	return nil
+}
+
+// touch - отметка об обращении к записи для вытеснения (вызывается под блокировкой)
+func (c *Cache) touch(requestURL string, now time.Time) {
+	name := fileName(requestURL)
+	if item, ok := c.index[name]; ok {
+		item.accessed = now
+		os.Chtimes(filepath.Join(c.dir, name), now, now)
+	}
+}
+
+// evict - удаление давно не использованных записей сверх ограничения размера (вызывается под блокировкой)
+func (c *Cache) evict() {
+	if c.maxSize <= 0 || c.size <= c.maxSize {
+		return
+	}
+
+	names := make([]string, 0, len(c.index))
+	for name := range c.index {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return c.index[names[i]].accessed.Before(c.index[names[j]].accessed)
+	})
+
+	for _, name := range names {
+		if c.size <= c.maxSize {
+			break
+		}
+		if err := os.Remove(filepath.Join(c.dir, name)); err != nil && !os.IsNotExist(err) {
+			continue
+		}
+		c.size -= c.index[name].size
+		delete(c.index, name)
+		c.stats.Evicted++
+	}
+}
+
+// fileName - имя файла записи по адресу запроса
+func fileName(requestURL string) string {
+	sum := sha256.Sum256([]byte(requestURL))
+	return hex.EncodeToString(sum[:16]) + ".json"
+}
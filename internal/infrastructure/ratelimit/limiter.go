@@ -0,0 +1,220 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"hh-resume-parser/internal/config"
+	"hh-resume-parser/internal/domain/repositories"
+)
+
+// Limiter - ограничитель скорости запросов к API по алгоритму token bucket
+// Общий бюджет дополняется бюджетами отдельных конечных точек и суточной квотой.
+// Безопасен для одновременного использования из нескольких горутин
+type Limiter struct {
+	mu        sync.Mutex
+	global    *bucket            // Общий бюджет запросов (nil - без ограничения)
+	endpoints map[string]*bucket // Бюджеты конечных точек
+	quota     int                // Суточная квота запросов (0 - без ограничения)
+	day       time.Time          // Начало суток, к которым относится счетчик квоты
+	stats     Stats
+	now       func() time.Time
+}
+
+// Stats - статистика ограничителя скорости
+type Stats struct {
+	Requests   int            // Количество разрешенных запросов
+	Waits      int            // Количество запросов, которым пришлось ждать
+	TotalWait  time.Duration  // Суммарное время ожидания
+	MaxWait    time.Duration  // Максимальное время ожидания одного запроса
+	Cancelled  int            // Количество ожиданий, прерванных отменой контекста
+	Rejected   int            // Количество запросов, отклоненных из-за суточной квоты
+	Refunded   int            // Количество запросов, бюджет которых возвращен (ответ 304)
+	DailyUsed  int            // Использовано запросов за текущие сутки
+	DailyQuota int            // Суточная квота (0 - без ограничения)
+	ByEndpoint map[string]int // Количество запросов по конечным точкам
+}
+
+// bucket - корзина токенов одного бюджета
+type bucket struct {
+	interval time.Duration // Интервал пополнения одного токена
+	burst    float64       // Емкость корзины
+	tokens   float64       // Текущее количество токенов (отрицательное - очередь ожидающих)
+	updated  time.Time     // Время последнего пополнения
+}
+
+// New - создание ограничителя по конфигурации API
+// RateLimit задает интервал между запросами, RateBurst - количество запросов,
+// которые можно выполнить подряд без ожидания
+func New(cfg config.APIConfig) *Limiter {
+	l := &Limiter{
+		endpoints: make(map[string]*bucket),
+		quota:     cfg.DailyQuota,
+		now:       time.Now,
+	}
+
+	l.global = newBucket(cfg.RateLimit, cfg.RateBurst)
+	for endpoint, interval := range cfg.EndpointRateLimits {
+		if b := newBucket(interval, cfg.RateBurst); b != nil {
+			l.endpoints[Endpoint(endpoint)] = b
+		}
+	}
+
+	return l
+}
+
+// newBucket - создание корзины; при нулевом интервале ограничения нет
+func newBucket(interval time.Duration, burst int) *bucket {
+	if interval <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &bucket{
+		interval: interval,
+		burst:    float64(burst),
+		tokens:   float64(burst),
+	}
+}
+
+// Wait - ожидание разрешения на запрос к конечной точке
+// Возвращает время ожидания. При отмене контекста ожидание прерывается и токены возвращаются,
+// при исчерпании суточной квоты возвращается ошибка ErrRateLimited без ожидания
+func (l *Limiter) Wait(ctx context.Context, endpoint string) (time.Duration, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	l.mu.Lock()
+	now := l.now()
+
+	l.resetDay(now)
+	if l.quota > 0 && l.stats.DailyUsed >= l.quota {
+		l.stats.Rejected++
+		l.mu.Unlock()
+		return 0, fmt.Errorf("%w: исчерпана суточная квота (%d запросов)", repositories.ErrRateLimited, l.quota)
+	}
+
+	buckets := []*bucket{l.global, l.endpoints[endpoint]}
+	var delay time.Duration
+	for _, b := range buckets {
+		if b != nil {
+			delay = max(delay, b.reserve(now))
+		}
+	}
+
+	l.stats.DailyUsed++
+	l.mu.Unlock()
+
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			l.mu.Lock()
+			for _, b := range buckets {
+				if b != nil {
+					b.tokens = min(b.burst, b.tokens+1)
+				}
+			}
+			l.stats.DailyUsed--
+			l.stats.Cancelled++
+			l.mu.Unlock()
+			return 0, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	l.mu.Lock()
+	l.stats.Requests++
+	if l.stats.ByEndpoint == nil {
+		l.stats.ByEndpoint = make(map[string]int)
+	}
+	l.stats.ByEndpoint[endpoint]++
+	if delay > 0 {
+		l.stats.Waits++
+		l.stats.TotalWait += delay
+		l.stats.MaxWait = max(l.stats.MaxWait, delay)
+	}
+	l.mu.Unlock()
+
+	return delay, nil
+}
+
+// Refund - возврат бюджета выполненного запроса, который не расходует лимиты API
+// (ответ 304 Not Modified). Токены возвращаются в корзины, запрос не учитывается в суточной квоте
+func (l *Limiter) Refund(endpoint string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, b := range []*bucket{l.global, l.endpoints[endpoint]} {
+		if b != nil {
+			b.tokens = min(b.burst, b.tokens+1)
+		}
+	}
+	if l.stats.DailyUsed > 0 {
+		l.stats.DailyUsed--
+	}
+	l.stats.Refunded++
+}
+
+// Stats - текущая статистика ограничителя
+func (l *Limiter) Stats() Stats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.resetDay(l.now())
+
+	stats := l.stats
+	stats.DailyQuota = l.quota
+	stats.ByEndpoint = make(map[string]int, len(l.stats.ByEndpoint))
+	for endpoint, count := range l.stats.ByEndpoint {
+		stats.ByEndpoint[endpoint] = count
+	}
+	return stats
+}
+
+// resetDay - сброс счетчика квоты при наступлении новых суток (вызывается под блокировкой)
+func (l *Limiter) resetDay(now time.Time) {
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if !day.Equal(l.day) {
+		l.day = day
+		l.stats.DailyUsed = 0
+	}
+}
+
+// reserve - резервирование токена; возвращает время до его появления
+func (b *bucket) reserve(now time.Time) time.Duration {
+	if !b.updated.IsZero() {
+		elapsed := now.Sub(b.updated)
+		b.tokens = min(b.burst, b.tokens+float64(elapsed)/float64(b.interval))
+	}
+	b.updated = now
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens * float64(b.interval))
+}
+
+// Endpoint - имя конечной точки для учета бюджета по адресу или пути запроса
+// Берется первый сегмент пути: "https://api.hh.ru/resumes/123?x=1" -> "/resumes"
+func Endpoint(rawURL string) string {
+	path := rawURL
+	if u, err := url.Parse(rawURL); err == nil && u.Path != "" {
+		path = u.Path
+	}
+
+	path = strings.Trim(path, "/")
+	if i := strings.IndexByte(path, '/'); i >= 0 {
+		path = path[:i]
+	}
+	return "/" + path
+}
@@ -0,0 +1,433 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"hh-resume-parser/internal/domain/repositories"
+)
+
+// areasCacheKey - ключ дерева регионов в локальном кэше
+const areasCacheKey = "hh:areas"
+
+// HHArea - узел дерева регионов из API hh.ru
+type HHArea struct {
+	ID       string   `json:"id"`        // Идентификатор региона
+	ParentID *string  `json:"parent_id"` // Идентификатор родительского региона
+	Name     string   `json:"name"`      // Название
+	Areas    []HHArea `json:"areas"`     // Вложенные регионы
+}
+
+// areaNode - регион в индексе дерева
+type areaNode struct {
+	ID       string   // Идентификатор региона
+	Name     string   // Название
+	ParentID string   // Идентификатор родительского региона
+	Depth    int      // Глубина в дереве (0 - страна)
+	Children []string // Идентификаторы вложенных регионов
+	key      string   // Нормализованное название в латинице
+}
+
+// areaCatalog - индекс дерева регионов для поиска по названию
+type areaCatalog struct {
+	nodes map[string]*areaNode   // Регионы по идентификатору
+	byKey map[string][]*areaNode // Регионы по нормализованному названию
+	roots []string               // Идентификаторы стран верхнего уровня
+}
+
+// areaAliases - распространенные названия, которые не выводятся транслитерацией
+var areaAliases = map[string]string{
+	"moscow":           "москва",
+	"msk":              "москва",
+	"мск":              "москва",
+	"saint petersburg": "санкт-петербург",
+	"st petersburg":    "санкт-петербург",
+	"st. petersburg":   "санкт-петербург",
+	"petersburg":       "санкт-петербург",
+	"spb":              "санкт-петербург",
+	"спб":              "санкт-петербург",
+	"питер":            "санкт-петербург",
+	"nizhny novgorod":  "нижний новгород",
+	"rostov-on-don":    "ростов-на-дону",
+	"russia":           "россия",
+	"kiev":             "киев",
+	"kyiv":             "киев",
+}
+
+// ResolveAreas - поиск регионов hh.ru по названиям или идентификаторам
+// Названия сравниваются без учета регистра, в кириллице и латинице, с допуском опечаток
+func (r *hhRepository) ResolveAreas(ctx context.Context, names []string) ([]string, error) {
+	catalog, err := r.loadAreas(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids, problems []string
+	seen := make(map[string]bool)
+
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		node, err := catalog.lookup(name)
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+
+		if areaKey(name) != node.key && !isNumeric(name) {
+			r.logger.Warn("Регион найден по приблизительному совпадению", map[string]interface{}{
+				"input":   name,
+				"area":    node.Name,
+				"area_id": node.ID,
+			})
+		}
+
+		if !seen[node.ID] {
+			seen[node.ID] = true
+			ids = append(ids, node.ID)
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("%w: %s", repositories.ErrUnknownArea, strings.Join(problems, "; "))
+	}
+
+	return ids, nil
+}
+
+// resolveCriteriaAreas - заполнение AreaIDs по названию города из критериев
+func (r *hhRepository) resolveCriteriaAreas(ctx context.Context, criteria repositories.SearchCriteria) (repositories.SearchCriteria, error) {
+	if len(criteria.AreaIDs) > 0 || strings.TrimSpace(criteria.City) == "" {
+		return criteria, nil
+	}
+
+	r.areasMu.Lock()
+	ids, ok := r.resolvedAreas[criteria.City]
+	r.areasMu.Unlock()
+
+	if !ok {
+		var err error
+		ids, err = r.ResolveAreas(ctx, SplitAreaList(criteria.City))
+		if err != nil {
+			return criteria, err
+		}
+
+		r.areasMu.Lock()
+		r.resolvedAreas[criteria.City] = ids
+		r.areasMu.Unlock()
+	}
+
+	criteria.AreaIDs = ids
+	return criteria, nil
+}
+
+// splitByArea - деление критериев по регионам
+// Несколько регионов делятся по одному, один регион - по вложенным регионам
+func (r *hhRepository) splitByArea(criteria repositories.SearchCriteria) []repositories.SearchCriteria {
+	r.areasMu.Lock()
+	catalog := r.areas
+	r.areasMu.Unlock()
+
+	if catalog == nil {
+		return nil
+	}
+
+	var ids []string
+	switch len(criteria.AreaIDs) {
+	case 0:
+		ids = catalog.roots
+	case 1:
+		if node, ok := catalog.nodes[criteria.AreaIDs[0]]; ok {
+			ids = node.Children
+		}
+	default:
+		ids = criteria.AreaIDs
+	}
+
+	if len(ids) < 2 {
+		return nil
+	}
+
+	parts := make([]repositories.SearchCriteria, 0, len(ids))
+	for _, id := range ids {
+		part := criteria
+		part.AreaIDs = []string{id}
+		parts = append(parts, part)
+	}
+	return parts
+}
+
+// loadAreas - загрузка дерева регионов из кэша или API hh.ru
+func (r *hhRepository) loadAreas(ctx context.Context) (*areaCatalog, error) {
+	r.areasMu.Lock()
+	catalog := r.areas
+	r.areasMu.Unlock()
+
+	if catalog != nil {
+		return catalog, nil
+	}
+
+	var data []byte
+	if r.cache != nil {
+		cached, err := r.cache.Get(ctx, areasCacheKey)
+		if err == nil {
+			data = cached
+		} else if !errors.Is(err, repositories.ErrCacheMiss) {
+			r.logger.Warn("Ошибка чтения кэша регионов", map[string]interface{}{"error": err.Error()})
+		}
+	}
+
+	fromCache := data != nil
+	if !fromCache {
+		resp, err := r.makeAPIRequest(ctx, r.baseURL+"/areas")
+		if err != nil {
+			return nil, fmt.Errorf("ошибка загрузки дерева регионов: %w", err)
+		}
+		defer resp.Body.Close()
+
+		var raw json.RawMessage
+		if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+			return nil, fmt.Errorf("ошибка парсинга дерева регионов: %w", err)
+		}
+		data = raw
+	}
+
+	var areas []HHArea
+	if err := json.Unmarshal(data, &areas); err != nil {
+		return nil, fmt.Errorf("ошибка парсинга дерева регионов: %w", err)
+	}
+
+	catalog = newAreaCatalog(areas)
+
+	if !fromCache && r.cache != nil {
+		ttl := int(r.config.Cache.AreasTTL.Seconds())
+		if err := r.cache.Set(ctx, areasCacheKey, data, ttl); err != nil {
+			r.logger.Warn("Не удалось сохранить дерево регионов в кэш", map[string]interface{}{"error": err.Error()})
+		}
+	}
+
+	r.logger.Info("Загружено дерево регионов", map[string]interface{}{
+		"areas":      len(catalog.nodes),
+		"from_cache": fromCache,
+	})
+
+	r.areasMu.Lock()
+	r.areas = catalog
+	r.areasMu.Unlock()
+
+	return catalog, nil
+}
+
+// newAreaCatalog - построение индекса по дереву регионов
+func newAreaCatalog(areas []HHArea) *areaCatalog {
+	catalog := &areaCatalog{
+		nodes: make(map[string]*areaNode),
+		byKey: make(map[string][]*areaNode),
+	}
+
+	var walk func(items []HHArea, parentID string, depth int)
+	walk = func(items []HHArea, parentID string, depth int) {
+		for _, item := range items {
+			node := &areaNode{
+				ID:       item.ID,
+				Name:     item.Name,
+				ParentID: parentID,
+				Depth:    depth,
+				key:      areaKey(item.Name),
+			}
+			for _, child := range item.Areas {
+				node.Children = append(node.Children, child.ID)
+			}
+
+			catalog.nodes[node.ID] = node
+			catalog.byKey[node.key] = append(catalog.byKey[node.key], node)
+			if depth == 0 {
+				catalog.roots = append(catalog.roots, node.ID)
+			}
+
+			walk(item.Areas, item.ID, depth+1)
+		}
+	}
+	walk(areas, "", 0)
+
+	// При совпадении названий предпочитаем регионы верхнего уровня и меньшие ID
+	for _, nodes := range catalog.byKey {
+		sort.Slice(nodes, func(i, j int) bool {
+			if nodes[i].Depth != nodes[j].Depth {
+				return nodes[i].Depth < nodes[j].Depth
+			}
+			return compareAreaIDs(nodes[i].ID, nodes[j].ID)
+		})
+	}
+
+	return catalog
+}
+
+// lookup - поиск региона по названию или идентификатору
+func (c *areaCatalog) lookup(name string) (*areaNode, error) {
+	if isNumeric(name) {
+		if node, ok := c.nodes[name]; ok {
+			return node, nil
+		}
+		return nil, fmt.Errorf("регион с ID %s не найден", name)
+	}
+
+	key := areaKey(name)
+	if key == "" {
+		return nil, fmt.Errorf("пустое название региона %q", name)
+	}
+
+	if nodes := c.byKey[key]; len(nodes) > 0 {
+		return nodes[0], nil
+	}
+
+	// Поиск ближайшего названия с допуском опечаток
+	maxDistance := len([]rune(key)) / 5
+	if maxDistance > 3 {
+		maxDistance = 3
+	}
+
+	type candidate struct {
+		key      string
+		distance int
+	}
+	var candidates []candidate
+	for candidateKey := range c.byKey {
+		distance := levenshtein(key, candidateKey)
+		if distance <= maxDistance+2 {
+			candidates = append(candidates, candidate{key: candidateKey, distance: distance})
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].key < candidates[j].key
+	})
+
+	// Принимаем совпадение, только если оно однозначно
+	if len(candidates) > 0 && candidates[0].distance <= maxDistance && maxDistance > 0 &&
+		(len(candidates) == 1 || candidates[1].distance > candidates[0].distance) {
+		return c.byKey[candidates[0].key][0], nil
+	}
+
+	var suggestions []string
+	for i := 0; i < len(candidates) && i < 3; i++ {
+		suggestions = append(suggestions, c.byKey[candidates[i].key][0].Name)
+	}
+	if len(suggestions) > 0 {
+		return nil, fmt.Errorf("%q (возможно: %s)", name, strings.Join(suggestions, ", "))
+	}
+	return nil, fmt.Errorf("%q", name)
+}
+
+// SplitAreaList - разбиение строки со списком городов и регионов
+func SplitAreaList(value string) []string {
+	fields := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ';'
+	})
+
+	items := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if field = strings.TrimSpace(field); field != "" {
+			items = append(items, field)
+		}
+	}
+	return items
+}
+
+// areaKey - нормализация названия региона для сравнения
+// Приводит к нижнему регистру, убирает пунктуацию и переводит кириллицу в латиницу
+func areaKey(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.ReplaceAll(name, "ё", "е")
+	name = strings.TrimPrefix(name, "г. ")
+	name = strings.TrimPrefix(name, "город ")
+
+	if alias, ok := areaAliases[name]; ok {
+		name = alias
+	}
+
+	var b strings.Builder
+	space := false
+	for _, r := range name {
+		if r == '-' || r == '_' || r == '.' || r == ' ' || r == '(' || r == ')' {
+			space = b.Len() > 0
+			continue
+		}
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+		if latin, ok := translitTable[r]; ok {
+			b.WriteString(latin)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// translitTable - транслитерация кириллицы в латиницу
+var translitTable = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n",
+	'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f",
+	'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y",
+	'ь': "", 'э': "e", 'ю': "yu", 'я': "ya", 'і': "i", 'ї': "yi", 'є': "ye",
+	'ў': "u", 'қ': "k", 'ғ': "g", 'ң': "n", 'ү': "u", 'ұ': "u", 'ә': "a", 'ө': "o", 'һ': "h",
+}
+
+// levenshtein - расстояние редактирования между строками
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+// isNumeric - проверка, что строка состоит только из цифр
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	_, err := strconv.Atoi(s)
+	return err == nil && !strings.HasPrefix(s, "-") && !strings.HasPrefix(s, "+")
+}
+
+// compareAreaIDs - сравнение числовых идентификаторов регионов
+func compareAreaIDs(a, b string) bool {
+	ai, errA := strconv.Atoi(a)
+	bi, errB := strconv.Atoi(b)
+	if errA == nil && errB == nil {
+		return ai < bi
+	}
+	return a < b
+}
@@ -0,0 +1,187 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"hh-resume-parser/internal/domain/repositories"
+)
+
+// dictionariesCacheKey - ключ справочников в локальном кэше
+const dictionariesCacheKey = "hh:dictionaries"
+
+// filterDictionaries - соответствие фильтров поиска справочникам /dictionaries
+var filterDictionaries = map[string]string{
+	"experience":        "experience",
+	"education_level":   "education_level",
+	"employment":        "employment",
+	"schedule":          "schedule",
+	"currency":          "currency",
+	"gender":            "gender",
+	"order_by":          "resume_search_order",
+	"text_field":        "resume_search_fields",
+	"text_period":       "resume_search_experience_period",
+	"relocation":        "resume_search_relocation",
+	"job_search_status": "job_search_statuses",
+	"language_level":    "language_level",
+}
+
+// FilterNames - фильтры поиска, значения которых проверяются по справочникам
+func FilterNames() []string {
+	filters := make([]string, 0, len(filterDictionaries))
+	for filter := range filterDictionaries {
+		filters = append(filters, filter)
+	}
+	sort.Strings(filters)
+	return filters
+}
+
+// hhDictionaryItem - элемент справочника hh.ru
+// У валют вместо идентификатора используется код
+type hhDictionaryItem struct {
+	ID   string `json:"id"`
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// Dictionaries - допустимые значения фильтров поиска из справочников hh.ru
+// Справочники загружаются один раз и кэшируются на диске на Cache.DictionariesTTL
+func (r *hhRepository) Dictionaries(ctx context.Context) (map[string][]repositories.DictionaryItem, error) {
+	r.dictMu.Lock()
+	defer r.dictMu.Unlock()
+
+	if r.dictionaries != nil {
+		return r.dictionaries, nil
+	}
+
+	var data []byte
+	if r.cache != nil {
+		cached, err := r.cache.Get(ctx, dictionariesCacheKey)
+		if err == nil {
+			data = cached
+		} else if !errors.Is(err, repositories.ErrCacheMiss) {
+			r.logger.Warn("Ошибка чтения кэша справочников", map[string]interface{}{"error": err.Error()})
+		}
+	}
+
+	fromCache := data != nil
+	if !fromCache {
+		resp, err := r.makeAPIRequest(ctx, r.baseURL+"/dictionaries")
+		if err != nil {
+			return nil, fmt.Errorf("ошибка загрузки справочников: %w", err)
+		}
+		defer resp.Body.Close()
+
+		var raw json.RawMessage
+		if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+			return nil, fmt.Errorf("ошибка парсинга справочников: %w", err)
+		}
+		data = raw
+	}
+
+	// Справочники разнородны, поэтому разбираются только нужные
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("ошибка парсинга справочников: %w", err)
+	}
+
+	dictionaries := make(map[string][]repositories.DictionaryItem, len(filterDictionaries))
+	for filter, key := range filterDictionaries {
+		var items []hhDictionaryItem
+		if err := json.Unmarshal(raw[key], &items); err != nil || len(items) == 0 {
+			r.logger.Warn("Справочник отсутствует в ответе API", map[string]interface{}{"dictionary": key})
+			continue
+		}
+
+		for _, item := range items {
+			id := item.ID
+			if id == "" {
+				id = item.Code
+			}
+			dictionaries[filter] = append(dictionaries[filter], repositories.DictionaryItem{ID: id, Name: item.Name})
+		}
+	}
+
+	if !fromCache && r.cache != nil {
+		ttl := int(r.config.Cache.DictionariesTTL.Seconds())
+		if err := r.cache.Set(ctx, dictionariesCacheKey, data, ttl); err != nil {
+			r.logger.Warn("Не удалось сохранить справочники в кэш", map[string]interface{}{"error": err.Error()})
+		}
+	}
+
+	r.logger.Info("Загружены справочники", map[string]interface{}{
+		"dictionaries": len(dictionaries),
+		"from_cache":   fromCache,
+	})
+
+	r.dictionaries = dictionaries
+	return dictionaries, nil
+}
+
+// ValidateFilter - проверка значения фильтра по справочнику hh.ru
+// Значение должно совпадать с идентификатором; при ошибке предлагаются ближайшие варианты
+func (r *hhRepository) ValidateFilter(ctx context.Context, filter, value string) error {
+	if value == "" {
+		return nil
+	}
+
+	dictionaries, err := r.Dictionaries(ctx)
+	if err != nil {
+		return err
+	}
+
+	items, ok := dictionaries[filter]
+	if !ok {
+		// Справочник недоступен - проверить значение нечем
+		return nil
+	}
+
+	for _, item := range items {
+		if item.ID == value {
+			return nil
+		}
+	}
+
+	suggestions := closestItems(items, value, 3)
+	if len(suggestions) == 0 {
+		return fmt.Errorf("%w: недопустимое значение %q фильтра %s", repositories.ErrBadArgument, value, filter)
+	}
+	return fmt.Errorf("%w: недопустимое значение %q фильтра %s (возможно: %s)",
+		repositories.ErrBadArgument, value, filter, strings.Join(suggestions, ", "))
+}
+
+// closestItems - ближайшие по написанию значения справочника
+// Сравниваются и идентификаторы, и названия без учета регистра
+func closestItems(items []repositories.DictionaryItem, value string, limit int) []string {
+	key := strings.ToLower(strings.TrimSpace(value))
+	maxDistance := max(2, len([]rune(key))/3)
+
+	type candidate struct {
+		item     repositories.DictionaryItem
+		distance int
+	}
+	var candidates []candidate
+	for _, item := range items {
+		distance := min(
+			levenshtein(key, strings.ToLower(item.ID)),
+			levenshtein(key, strings.ToLower(item.Name)),
+		)
+		if distance <= maxDistance {
+			candidates = append(candidates, candidate{item: item, distance: distance})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+
+	var suggestions []string
+	for i := 0; i < len(candidates) && i < limit; i++ {
+		suggestions = append(suggestions, fmt.Sprintf("%s (%s)", candidates[i].item.ID, candidates[i].item.Name))
+	}
+	return suggestions
+}
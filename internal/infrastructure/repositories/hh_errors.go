@@ -0,0 +1,163 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"hh-resume-parser/internal/domain/repositories"
+)
+
+// maxErrorBodySize - максимальный размер тела ответа с ошибкой, который читается целиком
+const maxErrorBodySize = 64 * 1024
+
+// hhErrorResponse - тело ответа API hh.ru с описанием ошибки
+type hhErrorResponse struct {
+	Errors    []repositories.APIErrorDetail `json:"errors"`     // Список ошибок
+	RequestID string                        `json:"request_id"` // Идентификатор запроса
+}
+
+// parseAPIError - преобразование ответа с ошибкой в типизированную доменную ошибку
+func parseAPIError(resp *http.Response) error {
+	apiErr := &repositories.APIError{
+		StatusCode: resp.StatusCode,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+
+	var errResp hhErrorResponse
+	if err := json.Unmarshal(body, &errResp); err == nil {
+		apiErr.Details = errResp.Errors
+		apiErr.RequestID = errResp.RequestID
+	}
+
+	apiErr.Kind = classifyAPIError(resp.StatusCode, apiErr.Details)
+
+	return apiErr
+}
+
+// classifyAPIError - определение категории ошибки по статусу и типам ошибок hh.ru
+func classifyAPIError(status int, details []repositories.APIErrorDetail) error {
+	// Тип ошибки из тела ответа точнее HTTP статуса
+	for _, detail := range details {
+		switch detail.Type {
+		case "oauth":
+			return repositories.ErrUnauthorized
+		case "too_many_requests":
+			return repositories.ErrRateLimited
+		case "captcha_required":
+			// Капчу приложение пройти не может, повтор запроса ее не снимет
+			return repositories.ErrForbidden
+		case "bad_argument", "bad_arguments", "bad_json_data":
+			return repositories.ErrBadArgument
+		case "not_found":
+			return repositories.ErrNotFound
+		case "forbidden":
+			return repositories.ErrForbidden
+		case "negotiations":
+			// Отказ в действии с откликом относится к конкретному соискателю, а не к правам доступа
+			if detail.Value == "already_applied" || detail.Value == "already_invited" {
+				return repositories.ErrAlreadyInvited
+			}
+			return repositories.ErrBadArgument
+		}
+	}
+
+	switch {
+	case status == http.StatusUnauthorized:
+		return repositories.ErrUnauthorized
+	case status == http.StatusForbidden:
+		return repositories.ErrForbidden
+	case status == http.StatusTooManyRequests:
+		return repositories.ErrRateLimited
+	case status == http.StatusNotFound:
+		return repositories.ErrNotFound
+	case status == http.StatusBadRequest:
+		return repositories.ErrBadArgument
+	case status >= 500:
+		return repositories.ErrServer
+	default:
+		return repositories.ErrUnexpected
+	}
+}
+
+// parseRetryAfter - разбор заголовка Retry-After (секунды или HTTP дата)
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(date); delay > 0 {
+			return delay
+		}
+	}
+
+	return 0
+}
+
+// shouldRetry - проверка, нужно ли повторять запрос после ошибки
+// Повторяются ответы 429 и 5xx, а также сетевые ошибки без HTTP ответа, включая тайм-аут клиента.
+// Отмену запроса вызывающий код определяет по своему контексту до вызова
+func (r *hhRepository) shouldRetry(err error) bool {
+	var apiErr *repositories.APIError
+	if errors.As(err, &apiErr) {
+		return repositories.IsRetryable(apiErr)
+	}
+
+	return true
+}
+
+// retryDelay - задержка перед очередным повтором
+// Экспоненциальный откат с джиттером; Retry-After от сервера имеет приоритет,
+// но, как и откат, не превышает RetryMaxDelay
+func (r *hhRepository) retryDelay(attempt int, err error) time.Duration {
+	maxDelay := r.config.API.RetryMaxDelay
+
+	var apiErr *repositories.APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		if maxDelay > 0 && apiErr.RetryAfter > maxDelay {
+			return maxDelay
+		}
+		return apiErr.RetryAfter
+	}
+
+	delay := r.config.API.RetryBaseDelay << uint(attempt)
+	if maxDelay > 0 && (delay > maxDelay || delay <= 0) {
+		delay = maxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	// Джиттер в диапазоне [delay/2, delay) разносит повторы параллельных запросов
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+}
+
+// sleepContext - пауза с учетом отмены контекста
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+)
+
+// invitationEndpoint - действие API hh.ru «пригласить соискателя на вакансию»
+// Создает отклик в состоянии «приглашение» от имени работодателя
+const invitationEndpoint = "/negotiations/phone_interview"
+
+// Invite - приглашение соискателя на вакансию через API откликов hh.ru
+// Требует авторизации работодателя; идентификатор отклика берется из заголовка Location
+func (r *hhRepository) Invite(ctx context.Context, vacancyID, resumeID, message string) (string, error) {
+	form := url.Values{}
+	form.Set("vacancy_id", vacancyID)
+	form.Set("resume_id", resumeID)
+	if message != "" {
+		form.Set("message", message)
+	}
+
+	r.logger.Debug("Приглашаем соискателя на вакансию", map[string]interface{}{
+		"resume_id":  resumeID,
+		"vacancy_id": vacancyID,
+	})
+
+	resp, err := r.sendAPIRequest(ctx, http.MethodPost, r.baseURL+invitationEndpoint, form)
+	if err != nil {
+		return "", fmt.Errorf("ошибка приглашения резюме %s на вакансию %s: %w", resumeID, vacancyID, err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	negotiationID := ""
+	if location := resp.Header.Get("Location"); location != "" {
+		negotiationID = path.Base(location)
+	}
+
+	return negotiationID, nil
+}
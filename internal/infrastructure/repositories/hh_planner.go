@@ -0,0 +1,184 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"hh-resume-parser/internal/domain/repositories"
+)
+
+const (
+	// hhTimeLayout - формат дат, принимаемый API hh.ru
+	hhTimeLayout = "2006-01-02T15:04:05-0700"
+
+	// defaultSplitWindow - окно дат для разбиения запроса без фильтра по дате обновления
+	defaultSplitWindow = 3 * 365 * 24 * time.Hour
+
+	// minSplitWindow - минимальное окно дат, которое еще имеет смысл делить
+	minSplitWindow = time.Hour
+)
+
+// experienceBuckets - значения фильтра опыта, на которые делится запрос
+var experienceBuckets = []string{"noExperience", "between1And3", "between3And6", "moreThan6"}
+
+// PlanSearch - разбиение запроса на части, укладывающиеся в лимит глубины выдачи hh.ru
+// Запрос делится по опыту работы, затем по вложенным регионам и окнам даты обновления
+func (r *hhRepository) PlanSearch(ctx context.Context, criteria repositories.SearchCriteria) (*repositories.SearchPlan, error) {
+	return r.planSearch(ctx, resumesEndpoint, criteria)
+}
+
+// PlanVacancySearch - разбиение поиска вакансий по тем же правилам, что и поиска резюме
+// Окно дат для вакансий относится к дате публикации
+func (r *hhRepository) PlanVacancySearch(ctx context.Context, criteria repositories.SearchCriteria) (*repositories.SearchPlan, error) {
+	return r.planSearch(ctx, vacanciesEndpoint, criteria)
+}
+
+// planSearch - построение плана поиска для указанного пути API
+func (r *hhRepository) planSearch(ctx context.Context, endpoint string, criteria repositories.SearchCriteria) (*repositories.SearchPlan, error) {
+	criteria, err := r.resolveCriteriaAreas(ctx, criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := r.countResults(ctx, endpoint, criteria)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка оценки объема выдачи: %w", err)
+	}
+
+	plan := &repositories.SearchPlan{
+		TotalFound: found,
+		DepthLimit: r.config.API.DepthLimit,
+	}
+
+	if !r.config.Search.SplitQueries || plan.DepthLimit <= 0 {
+		if found > 0 {
+			plan.Slices = append(plan.Slices, repositories.QuerySlice{
+				Criteria:  criteria,
+				Found:     found,
+				Truncated: plan.DepthLimit > 0 && found > plan.DepthLimit,
+			})
+		}
+	} else if err := r.splitQuery(ctx, endpoint, criteria, found, plan); err != nil {
+		return nil, err
+	}
+
+	for _, slice := range plan.Slices {
+		reachable := slice.Found
+		if plan.DepthLimit > 0 && reachable > plan.DepthLimit {
+			reachable = plan.DepthLimit
+		}
+		plan.Reachable += reachable
+	}
+
+	r.logger.Info("Построен план поиска", map[string]interface{}{
+		"endpoint":    endpoint,
+		"total_found": plan.TotalFound,
+		"reachable":   plan.Reachable,
+		"slices":      len(plan.Slices),
+		"coverage":    fmt.Sprintf("%.1f%%", plan.Coverage()*100),
+	})
+
+	return plan, nil
+}
+
+// splitQuery - рекурсивное разбиение запроса до тех пор, пока каждая часть не уложится в лимит
+func (r *hhRepository) splitQuery(ctx context.Context, endpoint string, criteria repositories.SearchCriteria, found int, plan *repositories.SearchPlan) error {
+	if found == 0 {
+		return nil
+	}
+
+	if found <= plan.DepthLimit {
+		plan.Slices = append(plan.Slices, repositories.QuerySlice{Criteria: criteria, Found: found})
+		return nil
+	}
+
+	parts := r.splitCriteria(criteria)
+	if len(parts) == 0 {
+		r.logger.Warn("Часть запроса превышает лимит глубины и не может быть разбита", map[string]interface{}{
+			"found":      found,
+			"experience": criteria.Experience,
+			"areas":      criteria.AreaIDs,
+			"date_from":  criteria.DateFrom.Format(hhTimeLayout),
+			"date_to":    criteria.DateTo.Format(hhTimeLayout),
+		})
+		plan.Slices = append(plan.Slices, repositories.QuerySlice{Criteria: criteria, Found: found, Truncated: true})
+		return nil
+	}
+
+	for _, part := range parts {
+		partFound, err := r.countResults(ctx, endpoint, part)
+		if err != nil {
+			return fmt.Errorf("ошибка оценки объема части запроса: %w", err)
+		}
+		if err := r.splitQuery(ctx, endpoint, part, partFound, plan); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// splitCriteria - деление критериев на непересекающиеся части
+// Возвращает nil, если делить дальше нельзя
+func (r *hhRepository) splitCriteria(criteria repositories.SearchCriteria) []repositories.SearchCriteria {
+	// Деление по опыту работы
+	if criteria.Experience == "" {
+		parts := make([]repositories.SearchCriteria, 0, len(experienceBuckets))
+		for _, bucket := range experienceBuckets {
+			part := criteria
+			part.Experience = bucket
+			parts = append(parts, part)
+		}
+		return parts
+	}
+
+	// Деление по регионам
+	if parts := r.splitByArea(criteria); len(parts) > 0 {
+		return parts
+	}
+
+	// Деление окна по дате обновления пополам
+	from, to := criteria.DateFrom, criteria.DateTo
+	if to.IsZero() {
+		// Округление вверх, чтобы в окно попали резюме, обновленные в текущую минуту
+		to = time.Now().Add(time.Minute).Truncate(time.Minute)
+	}
+	if from.IsZero() {
+		window := defaultSplitWindow
+		if criteria.UpdateDays > 0 {
+			window = time.Duration(criteria.UpdateDays) * 24 * time.Hour
+		}
+		from = to.Add(-window)
+	}
+
+	if to.Sub(from) <= minSplitWindow {
+		return nil
+	}
+
+	// Границы окна в API включаются в выдачу, а даты передаются с точностью до секунды,
+	// поэтому правая половина начинается через секунду после левой: резюме, обновленное
+	// ровно в middle, попадает только в левую половину
+	middle := from.Add(to.Sub(from) / 2).Truncate(time.Second)
+
+	left, right := criteria, criteria
+	left.DateFrom, left.DateTo = from, middle
+	right.DateFrom, right.DateTo = middle.Add(time.Second), to
+
+	return []repositories.SearchCriteria{left, right}
+}
+
+// countResults - получение количества результатов по критериям без загрузки выдачи
+func (r *hhRepository) countResults(ctx context.Context, endpoint string, criteria repositories.SearchCriteria) (int, error) {
+	criteria.Page = 0
+	criteria.PerPage = 1
+
+	var apiResponse struct {
+		Found int `json:"found"`
+	}
+	if err := r.fetchSearchPage(ctx, endpoint, criteria, &apiResponse); err != nil {
+		return 0, err
+	}
+
+	return apiResponse.Found, nil
+}
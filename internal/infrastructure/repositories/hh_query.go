@@ -0,0 +1,177 @@
+package repositories
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"hh-resume-parser/internal/domain/repositories"
+)
+
+// Значения уточнений текста по умолчанию для поиска резюме
+const (
+	defaultTextField  = "everywhere"
+	defaultTextPeriod = "all_time"
+)
+
+// vacancySearchFields - префиксы языка запросов для полей поиска резюме
+// Поиск вакансий не поддерживает text.field, поэтому поле задается в самом запросе.
+// Навыки в вакансии перечисляются в описании; остальные поля ищутся везде
+var vacancySearchFields = map[string]string{
+	"title":  "NAME",
+	"skills": "DESCRIPTION",
+}
+
+// addTextParams - добавление текстового запроса в параметры поиска
+// Резюме ищутся по набору text с уточнениями text.logic, text.field и text.period,
+// вакансии - по одному text на языке запросов hh.ru
+func addTextParams(params url.Values, endpoint string, criteria repositories.SearchCriteria) {
+	clauses := criteria.Clauses()
+	if len(clauses) == 0 {
+		return
+	}
+
+	// Одно условие без уточнений передается как раньше - словами через пробел
+	if len(clauses) == 1 && isPlainClause(clauses[0]) {
+		params.Add("text", strings.Join(clauses[0].Terms, " "))
+		return
+	}
+
+	if endpoint == vacanciesEndpoint {
+		params.Add("text", vacancyQueryText(clauses))
+		return
+	}
+
+	// Уточнения сопоставляются с text по порядку, поэтому задаются для каждого условия
+	for _, clause := range clauses {
+		field := clause.Field
+		if field == "" {
+			field = defaultTextField
+		}
+		period := clause.Period
+		if period == "" {
+			period = defaultTextPeriod
+		}
+
+		texts := []string{strings.Join(clause.Terms, " ")}
+		if clause.Logic == repositories.LogicPhrase {
+			// Каждая фраза ищется отдельно, все фразы обязательны
+			texts = clause.Terms
+		}
+
+		for _, text := range texts {
+			params.Add("text", text)
+			params.Add("text.logic", string(logicOrDefault(clause.Logic)))
+			params.Add("text.field", field)
+			params.Add("text.period", period)
+		}
+	}
+}
+
+// isPlainClause - условие без уточнений: все слова, везде, за все время
+func isPlainClause(clause repositories.TextClause) bool {
+	return logicOrDefault(clause.Logic) == repositories.LogicAll && clause.Field == "" && clause.Period == ""
+}
+
+// logicOrDefault - способ объединения слов с учетом значения по умолчанию
+func logicOrDefault(logic repositories.TextLogic) repositories.TextLogic {
+	if logic == "" {
+		return repositories.LogicAll
+	}
+	return logic
+}
+
+// vacancyQueryText - условия запроса на языке запросов hh.ru
+// Период опыта к вакансиям неприменим и не учитывается
+func vacancyQueryText(clauses []repositories.TextClause) string {
+	parts := make([]string, 0, len(clauses))
+	for _, clause := range clauses {
+		if part := vacancyClauseText(clause); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, " AND ")
+}
+
+// vacancyClauseText - одно условие на языке запросов hh.ru
+func vacancyClauseText(clause repositories.TextClause) string {
+	prefix := vacancySearchFields[clause.Field]
+	scoped := func(expr string) string {
+		if prefix == "" {
+			return expr
+		}
+		return prefix + ":(" + expr + ")"
+	}
+
+	terms := make([]string, 0, len(clause.Terms))
+	for _, term := range clause.Terms {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		if clause.Logic == repositories.LogicPhrase {
+			term = `"` + strings.Trim(term, `"`) + `"`
+		} else if strings.ContainsAny(term, " \t") && !strings.HasPrefix(term, "(") && !strings.HasPrefix(term, `"`) {
+			term = "(" + term + ")"
+		}
+		terms = append(terms, term)
+	}
+	if len(terms) == 0 {
+		return ""
+	}
+
+	switch clause.Logic {
+	case repositories.LogicAny:
+		return scoped("(" + strings.Join(terms, " OR ") + ")")
+	case repositories.LogicExcept:
+		for i, term := range terms {
+			terms[i] = "NOT " + scoped(term)
+		}
+		return strings.Join(terms, " ")
+	default:
+		return scoped(strings.Join(terms, " AND "))
+	}
+}
+
+// addFilterParams - добавление фильтров поиска в параметры запроса
+// Возраст, пол, переезд, языки и статус поиска работы есть только у резюме;
+// для вакансий нижняя граница зарплаты передается параметром salary
+func addFilterParams(params url.Values, endpoint string, filters repositories.SearchFilters) {
+	addInt := func(key string, value int) {
+		if value > 0 {
+			params.Add(key, strconv.Itoa(value))
+		}
+	}
+	addString := func(key, value string) {
+		if value != "" {
+			params.Add(key, value)
+		}
+	}
+	addList := func(key string, values []string) {
+		for _, value := range values {
+			addString(key, value)
+		}
+	}
+
+	addString("currency", filters.Currency)
+	addList("employment", filters.Employment)
+	addList("schedule", filters.Schedule)
+	addString("order_by", filters.OrderBy)
+
+	if endpoint == vacanciesEndpoint {
+		addInt("salary", filters.SalaryFrom)
+		return
+	}
+
+	addInt("salary_from", filters.SalaryFrom)
+	addInt("salary_to", filters.SalaryTo)
+	addInt("age_from", filters.AgeFrom)
+	addInt("age_to", filters.AgeTo)
+	addString("gender", filters.Gender)
+	addList("education_level", filters.EducationLevels)
+	addString("relocation", filters.Relocation)
+	for _, language := range filters.Languages {
+		addString("language", language.String())
+	}
+	addList("job_search_status", filters.JobSearchStatus)
+}
@@ -1,68 +1,154 @@
 package repositories
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	httpadapter "hh-resume-parser/internal/adapters/http"
 	"hh-resume-parser/internal/config"
 	"hh-resume-parser/internal/domain/entities"
 	"hh-resume-parser/internal/domain/repositories"
+	"hh-resume-parser/internal/infrastructure/auth"
+	"hh-resume-parser/internal/infrastructure/httpcache"
 	"hh-resume-parser/internal/infrastructure/logger"
+	"hh-resume-parser/internal/infrastructure/ratelimit"
+)
+
+// Пути поиска API hh.ru
+const (
+	resumesEndpoint   = "/resumes"
+	vacanciesEndpoint = "/vacancies"
 )
 
 // hhRepository - реализация репозитория для работы с API hh.ru
 type hhRepository struct {
-	client    *http.Client     // HTTP клиент для запросов
-	config    *config.Config   // Конфигурация приложения
-	logger    logger.Logger    // Логгер
-	rateLimit time.Duration    // Ограничение скорости запросов
-	lastCall  time.Time        // Время последнего вызова API
+	client  *http.Client                 // HTTP клиент для запросов
+	config  *config.Config               // Конфигурация приложения
+	logger  logger.Logger                // Логгер
+	cache   repositories.CacheRepository // Кэш справочных данных (может отсутствовать)
+	tokens  auth.TokenSource             // Источник токенов доступа (если пул не задан)
+	pool    *auth.TokenPool              // Пул токенов, между которыми распределяются запросы
+	baseURL string                       // Базовый адрес API без завершающего слэша
+	limiter *ratelimit.Limiter           // Ограничитель скорости запросов (общий с другими клиентами API)
+	http    *httpcache.Cache             // Кэш HTTP ответов (может отсутствовать)
+
+	areasMu       sync.Mutex          // Защита дерева регионов
+	areas         *areaCatalog        // Дерево регионов (загружается при первом обращении)
+	resolvedAreas map[string][]string // Результаты поиска регионов по строке города
+
+	dictMu       sync.Mutex                               // Защита справочников
+	dictionaries map[string][]repositories.DictionaryItem // Справочники фильтров (загружаются при первом обращении)
+}
+
+// Option - дополнительная настройка репозитория hh.ru
+type Option func(*hhRepository)
+
+// WithHTTPClient - использование готового HTTP клиента
+func WithHTTPClient(client *http.Client) Option {
+	return func(r *hhRepository) {
+		r.client = client
+	}
+}
+
+// WithTransport - использование собственного транспорта HTTP запросов
+func WithTransport(transport http.RoundTripper) Option {
+	return func(r *hhRepository) {
+		r.client = &http.Client{Timeout: r.config.API.Timeout, Transport: transport}
+	}
+}
+
+// WithBaseURL - использование другого адреса API (зеркало или тестовый сервер)
+func WithBaseURL(baseURL string) Option {
+	return func(r *hhRepository) {
+		r.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithTokenSource - использование собственного источника токенов
+func WithTokenSource(tokens auth.TokenSource) Option {
+	return func(r *hhRepository) {
+		r.tokens = tokens
+	}
+}
+
+// WithTokenPool - распределение запросов между токенами нескольких аккаунтов
+func WithTokenPool(pool *auth.TokenPool) Option {
+	return func(r *hhRepository) {
+		r.pool = pool
+	}
+}
+
+// WithHTTPCache - кэширование ответов API с перепроверкой по ETag и Last-Modified
+func WithHTTPCache(cache *httpcache.Cache) Option {
+	return func(r *hhRepository) {
+		r.http = cache
+	}
+}
+
+// WithLimiter - использование общего ограничителя скорости запросов
+func WithLimiter(limiter *ratelimit.Limiter) Option {
+	return func(r *hhRepository) {
+		r.limiter = limiter
+	}
 }
 
 // NewHHRepository - создание нового репозитория для hh.ru
-func NewHHRepository(cfg *config.Config, logger logger.Logger) repositories.ResumeRepository {
-	return &hhRepository{
-		client: &http.Client{
-			Timeout: cfg.API.Timeout,
-		},
-		config:    cfg,
-		logger:    logger,
-		rateLimit: cfg.API.RateLimit,
-		lastCall:  time.Time{},
+// Кэш используется для хранения справочников и может быть nil.
+// Адрес API, прокси, TLS и транспорт берутся из конфигурации и могут быть переопределены опциями
+func NewHHRepository(cfg *config.Config, logger logger.Logger, cache repositories.CacheRepository, opts ...Option) repositories.ResumeRepository {
+	r := &hhRepository{
+		config:        cfg,
+		logger:        logger,
+		cache:         cache,
+		baseURL:       strings.TrimRight(cfg.API.BaseURL, "/"),
+		resolvedAreas: make(map[string][]string),
+	}
+
+	client, err := httpadapter.NewHTTPClient(cfg.API)
+	if err != nil {
+		logger.Error("Ошибка настройки транспорта, используем транспорт по умолчанию", err)
+		client = &http.Client{Timeout: cfg.API.Timeout}
 	}
+	r.client = client
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	switch {
+	case r.pool != nil:
+	case r.tokens != nil:
+		r.pool = auth.NewTokenPool([]auth.PoolToken{{Name: "default", Source: r.tokens}}, logger)
+	default:
+		r.pool = auth.NewConfigTokenPool(cfg.API, r.client, logger)
+	}
+	if r.limiter == nil {
+		r.limiter = ratelimit.New(cfg.API)
+	}
+
+	return r
 }
 
 // SearchResumes - поиск резюме по критериям через API hh.ru
 func (r *hhRepository) SearchResumes(ctx context.Context, criteria repositories.SearchCriteria) ([]entities.Resume, error) {
-	// Применение ограничения скорости
-	r.applyRateLimit()
-
-	// Построение URL для поиска
-	searchURL := r.buildSearchURL(criteria)
-	
-	r.logger.Info("Выполняем запрос к API hh.ru", map[string]interface{}{
-		"url":  searchURL,
-		"page": criteria.Page,
-	})
-
-	// Выполнение HTTP запроса
-	resp, err := r.makeAPIRequest(ctx, searchURL)
+	criteria, err := r.resolveCriteriaAreas(ctx, criteria)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка запроса к API: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	// Парсинг ответа
 	var apiResponse HHAPIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
-		return nil, fmt.Errorf("ошибка парсинга ответа API: %w", err)
+	if err := r.fetchSearchPage(ctx, resumesEndpoint, criteria, &apiResponse); err != nil {
+		return nil, err
 	}
 
 	// Конвертация в доменные сущности
@@ -82,16 +168,54 @@ func (r *hhRepository) SearchResumes(ctx context.Context, criteria repositories.
 	return resumes, nil
 }
 
-// GetResumeByID - получение детального резюме по ID
+// fetchSearchPage - запрос одной страницы поиска резюме или вакансий
+// Ответ декодируется в dst
+func (r *hhRepository) fetchSearchPage(ctx context.Context, endpoint string, criteria repositories.SearchCriteria, dst interface{}) error {
+	// Построение URL для поиска
+	searchURL := r.buildSearchURL(endpoint, criteria)
+
+	r.logger.Info("Выполняем запрос к API hh.ru", map[string]interface{}{
+		"url":  searchURL,
+		"page": criteria.Page,
+	})
+
+	// Выполнение HTTP запроса
+	resp, err := r.makeAPIRequest(ctx, searchURL)
+	if err != nil {
+		return fmt.Errorf("ошибка запроса к API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Парсинг ответа
+	if err := json.NewDecoder(resp.Body).Decode(dst); err != nil {
+		return fmt.Errorf("ошибка парсинга ответа API: %w", err)
+	}
+
+	return nil
+}
+
+// GetResumeByID - получение детального резюме по ID с контактами соискателя
+// Если контакты скрыты, их открытие расходует платный просмотр работодателя
 func (r *hhRepository) GetResumeByID(ctx context.Context, id string) (*entities.Resume, error) {
-	// Применение ограничения скорости
-	r.applyRateLimit()
+	return r.getResume(ctx, id, true)
+}
+
+// GetResumeWithoutContacts - получение детального резюме по ID без открытия контактов
+func (r *hhRepository) GetResumeWithoutContacts(ctx context.Context, id string) (*entities.Resume, error) {
+	return r.getResume(ctx, id, false)
+}
+
+// getResume - получение детального резюме; withContacts - открыть контакты соискателя
+func (r *hhRepository) getResume(ctx context.Context, id string, withContacts bool) (*entities.Resume, error) {
+	detailURL := fmt.Sprintf("%s/resumes/%s", r.baseURL, url.PathEscape(id))
+	if withContacts {
+		detailURL += "?with_contact=true"
+	}
 
-	detailURL := fmt.Sprintf("https://api.hh.ru/resumes/%s", id)
-	
 	r.logger.Debug("Получаем детальную информацию о резюме", map[string]interface{}{
-		"resume_id": id,
-		"url":       detailURL,
+		"resume_id":     id,
+		"url":           detailURL,
+		"with_contacts": withContacts,
 	})
 
 	// Выполнение HTTP запроса
@@ -109,36 +233,147 @@ func (r *hhRepository) GetResumeByID(ctx context.Context, id string) (*entities.
 
 	// Конвертация в доменную сущность
 	resume := r.convertToResume(apiItem)
-	
+
 	return &resume, nil
 }
 
-// applyRateLimit - применение ограничения скорости запросов
-func (r *hhRepository) applyRateLimit() {
-	if !r.lastCall.IsZero() {
-		elapsed := time.Since(r.lastCall)
-		if elapsed < r.rateLimit {
-			sleepTime := r.rateLimit - elapsed
-			r.logger.Debug("Применяем ограничение скорости", map[string]interface{}{
-				"sleep_duration": sleepTime.String(),
+// applyRateLimit - ожидание разрешения ограничителя скорости на запрос
+// Бюджет учитывается по конечной точке API (первому сегменту пути)
+func (r *hhRepository) applyRateLimit(ctx context.Context, requestURL string) error {
+	endpoint := r.endpoint(requestURL)
+
+	waited, err := r.limiter.Wait(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+
+	if waited > 0 {
+		r.logger.Debug("Применяем ограничение скорости", map[string]interface{}{
+			"endpoint":       endpoint,
+			"sleep_duration": waited.String(),
+		})
+	}
+	return nil
+}
+
+// endpoint - конечная точка API для учета бюджета запросов
+func (r *hhRepository) endpoint(requestURL string) string {
+	return ratelimit.Endpoint(strings.TrimPrefix(requestURL, r.baseURL))
+}
+
+// makeAPIRequest - выполнение GET запроса к API
+// Повторяет запрос при ошибках 429, 5xx и сетевых сбоях с экспоненциальной задержкой.
+// Свежий ответ из HTTP кэша возвращается без запроса, устаревший перепроверяется условным запросом
+func (r *hhRepository) makeAPIRequest(ctx context.Context, requestURL string) (*http.Response, error) {
+	return r.sendAPIRequest(ctx, http.MethodGet, requestURL, nil)
+}
+
+// sendAPIRequest - выполнение HTTP запроса к API с телом формы form (может отсутствовать)
+// Запросы, изменяющие данные, не кэшируются и повторяются только после 429:
+// после ошибки сервера или сбоя сети неизвестно, выполнено ли действие
+func (r *hhRepository) sendAPIRequest(ctx context.Context, method, requestURL string, form url.Values) (*http.Response, error) {
+	var lastErr error
+
+	var cached *httpcache.Entry
+	if r.http != nil && method == http.MethodGet {
+		entry, fresh := r.http.Lookup(requestURL)
+		if fresh {
+			r.logger.Debug("Ответ взят из HTTP кэша", map[string]interface{}{"url": requestURL})
+			return cachedResponse(entry), nil
+		}
+		cached = entry
+	}
+
+	endpoint := r.endpoint(requestURL)
+	for attempt := 0; ; attempt++ {
+		lease, err := r.pool.Acquire(ctx, endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка получения токена: %w", err)
+		}
+
+		// Применение ограничения скорости
+		if err := r.applyRateLimit(ctx, requestURL); err != nil {
+			return nil, err
+		}
+
+		resp, err := r.doAPIRequest(ctx, method, requestURL, lease, form, cached)
+		if err == nil {
+			r.logger.Debug("Запрос к API выполнен", map[string]interface{}{
+				"url":   requestURL,
+				"token": lease.Label(),
 			})
-			time.Sleep(sleepTime)
+			return resp, nil
 		}
+		lastErr = err
+
+		// Истекший или отозванный токен пробуем обновить, иначе повторяем запрос с другим токеном
+		if errors.Is(err, repositories.ErrUnauthorized) && r.pool.Unauthorized(ctx, lease) {
+			attempt--
+			continue
+		}
+
+		// Токен, упершийся в лимит запросов, уступает очередь другим токенам пула
+		var apiErr *repositories.APIError
+		if errors.Is(err, repositories.ErrRateLimited) && errors.As(err, &apiErr) &&
+			r.pool.RateLimited(lease, apiErr.RetryAfter, err) {
+			attempt--
+			continue
+		}
+
+		// Отмена контекста и ошибки, которые не исправятся повтором, возвращаем сразу
+		if ctx.Err() != nil || !r.shouldRetry(err) || attempt >= r.config.API.MaxRetries {
+			break
+		}
+		if method != http.MethodGet && !errors.Is(err, repositories.ErrRateLimited) {
+			break
+		}
+
+		delay := r.retryDelay(attempt, err)
+		r.logger.Warn("Повтор запроса к API", map[string]interface{}{
+			"url":     requestURL,
+			"attempt": attempt + 1,
+			"delay":   delay.String(),
+			"error":   err.Error(),
+		})
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+
+	var apiErr *repositories.APIError
+	if errors.As(lastErr, &apiErr) {
+		r.logger.Error("API вернул ошибку", lastErr)
 	}
-	r.lastCall = time.Now()
+
+	return nil, lastErr
 }
 
-// makeAPIRequest - выполнение HTTP запроса к API
-func (r *hhRepository) makeAPIRequest(ctx context.Context, requestURL string) (*http.Response, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", requestURL, nil)
+// doAPIRequest - однократное выполнение HTTP запроса к API
+// form - тело запроса в формате формы, cached - запись HTTP кэша для условного запроса (могут отсутствовать)
+func (r *hhRepository) doAPIRequest(ctx context.Context, method, requestURL string, lease *auth.Lease, form url.Values, cached *httpcache.Entry) (*http.Response, error) {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, body)
 	if err != nil {
 		return nil, fmt.Errorf("ошибка создания запроса: %w", err)
 	}
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
 
 	// Установка заголовков
-	req.Header.Set("Authorization", "Bearer "+r.config.API.Token)
+	if lease.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+lease.Token)
+	}
 	req.Header.Set("User-Agent", r.config.API.UserAgent)
 	req.Header.Set("Accept", "application/json")
+	if cached != nil {
+		cached.SetConditionalHeaders(req.Header)
+	}
 
 	// Выполнение запроса
 	resp, err := r.client.Do(req)
@@ -146,39 +381,64 @@ func (r *hhRepository) makeAPIRequest(ctx context.Context, requestURL string) (*
 		return nil, fmt.Errorf("ошибка выполнения запроса: %w", err)
 	}
 
-	// Проверка статуса ответа
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
+	// Данные не изменились: тело берется из кэша, бюджет запроса возвращается
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
 		resp.Body.Close()
-		
-		r.logger.Error("API вернул ошибку", fmt.Errorf("статус %d", resp.StatusCode))
-		
-		return nil, fmt.Errorf("API вернул статус %d: %s", resp.StatusCode, string(body))
+		r.http.Revalidated(cached, resp.Header)
+		endpoint := r.endpoint(requestURL)
+		r.limiter.Refund(endpoint)
+		r.pool.Refund(lease, endpoint)
+		return cachedResponse(cached), nil
+	}
+
+	// Проверка статуса ответа: действия с откликами отвечают 201 Created
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		defer resp.Body.Close()
+		return nil, parseAPIError(resp)
+	}
+
+	if r.http != nil && method == http.MethodGet {
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("ошибка чтения ответа: %w", err)
+		}
+		r.http.Store(requestURL, resp.Header, body)
+		resp.Body = io.NopCloser(bytes.NewReader(body))
 	}
 
 	return resp, nil
 }
 
-// buildSearchURL - построение URL для поиска резюме
-func (r *hhRepository) buildSearchURL(criteria repositories.SearchCriteria) string {
-	baseURL := "https://api.hh.ru/resumes"
+// cachedResponse - ответ API, восстановленный из HTTP кэша
+func cachedResponse(entry *httpcache.Entry) *http.Response {
+	header := make(http.Header)
+	if entry.ContentType != "" {
+		header.Set("Content-Type", entry.ContentType)
+	}
+	return &http.Response{
+		StatusCode:    http.StatusOK,
+		Status:        "200 OK",
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(entry.Body)),
+		ContentLength: int64(len(entry.Body)),
+	}
+}
+
+// buildSearchURL - построение URL для поиска резюме или вакансий
+// endpoint - путь поиска (resumesEndpoint или vacanciesEndpoint)
+func (r *hhRepository) buildSearchURL(endpoint string, criteria repositories.SearchCriteria) string {
 	params := url.Values{}
 
 	// Добавление номера страницы
 	params.Add("page", strconv.Itoa(criteria.Page))
 
-	// Добавление ключевых слов
-	if len(criteria.Keywords) > 0 {
-		text := strings.Join(criteria.Keywords, " ")
-		params.Add("text", text)
-	}
+	// Добавление текстового запроса
+	addTextParams(params, endpoint, criteria)
 
-	// Добавление города
-	if criteria.City != "" {
-		areaID := r.getCityAreaID(criteria.City)
-		if areaID != "" {
-			params.Add("area", areaID)
-		}
+	// Добавление регионов
+	for _, areaID := range criteria.AreaIDs {
+		params.Add("area", areaID)
 	}
 
 	// Добавление фильтра по опыту
@@ -186,8 +446,19 @@ func (r *hhRepository) buildSearchURL(criteria repositories.SearchCriteria) stri
 		params.Add("experience", criteria.Experience)
 	}
 
+	// Добавление остальных фильтров
+	addFilterParams(params, endpoint, criteria.Filters)
+
 	// Добавление фильтра по дате обновления
-	if criteria.UpdateDays > 0 {
+	// Окно дат задается при разбиении запроса и не совместимо с period
+	if !criteria.DateFrom.IsZero() || !criteria.DateTo.IsZero() {
+		if !criteria.DateFrom.IsZero() {
+			params.Add("date_from", criteria.DateFrom.Format(hhTimeLayout))
+		}
+		if !criteria.DateTo.IsZero() {
+			params.Add("date_to", criteria.DateTo.Format(hhTimeLayout))
+		}
+	} else if criteria.UpdateDays > 0 {
 		params.Add("period", strconv.Itoa(criteria.UpdateDays))
 	}
 
@@ -195,71 +466,21 @@ func (r *hhRepository) buildSearchURL(criteria repositories.SearchCriteria) stri
 	if criteria.PerPage > 0 {
 		params.Add("per_page", strconv.Itoa(criteria.PerPage))
 	} else {
-		params.Add("per_page", "20") // Значение по умолчанию
-	}
-
-	return baseURL + "?" + params.Encode()
-}
-
-// getCityAreaID - получение ID региона по названию города
-func (r *hhRepository) getCityAreaID(cityName string) string {
-	// Маппинг популярных городов к их ID в API hh.ru
-	cityMap := map[string]string{
-		"москва":        "1",
-		"moscow":        "1",
-		"санкт-петербург": "2",
-		"спб":           "2",
-		"saint petersburg": "2",
-		"екатеринбург":   "3",
-		"ekaterinburg":  "3",
-		"новосибирск":   "4",
-		"novosibirsk":   "4",
-		"нижний новгород": "5",
-		"nizhny novgorod": "5",
-		"казань":        "88",
-		"kazan":         "88",
-		"челябинск":     "91",
-		"chelyabinsk":   "91",
-		"омск":          "95",
-		"omsk":          "95",
-		"самара":        "78",
-		"samara":        "78",
-		"ростов-на-дону": "76",
-		"rostov-on-don": "76",
-		"уфа":           "99",
-		"ufa":           "99",
-		"красноярск":    "26",
-		"krasnoyarsk":   "26",
-		"воронеж":       "193",
-		"voronezh":      "193",
-		"пермь":         "75",
-		"perm":          "75",
-		"волгоград":     "24",
-		"volgograd":     "24",
-		"краснодар":     "53",
-		"krasnodar":     "53",
-	}
-
-	// Нормализация названия города
-	normalizedCity := strings.ToLower(strings.TrimSpace(cityName))
-	
-	if areaID, exists := cityMap[normalizedCity]; exists {
-		return areaID
-	}
-
-	r.logger.Warn("Неизвестный город, используем поиск без фильтра по региону", map[string]interface{}{
-		"city": cityName,
-	})
-	
-	return ""
+		params.Add("per_page", strconv.Itoa(repositories.DefaultPerPage))
+	}
+
+	return r.baseURL + endpoint + "?" + params.Encode()
 }
 
 // convertToResume - конвертация данных API в доменную сущность
 func (r *hhRepository) convertToResume(apiItem HHResumeItem) entities.Resume {
 	resume := entities.Resume{
-		ID:    apiItem.ID,
-		Title: apiItem.Title,
-		URL:   apiItem.URL,
+		ID:       apiItem.ID,
+		Title:    apiItem.Title,
+		URL:      apiItem.URL,
+		AreaID:   apiItem.Area.ID,
+		Location: apiItem.Area.Name,
+		About:    strings.TrimSpace(apiItem.Skills),
 	}
 
 	// Формирование полного имени
@@ -269,16 +490,21 @@ func (r *hhRepository) convertToResume(apiItem HHResumeItem) entities.Resume {
 
 	// Парсинг даты последнего обновления
 	if apiItem.UpdatedAt != "" {
-		if updatedTime, err := time.Parse(time.RFC3339, apiItem.UpdatedAt); err == nil {
+		if updatedTime, err := parseHHTime(apiItem.UpdatedAt); err == nil {
 			resume.LastUpdate = updatedTime
+		} else {
+			r.logger.Debug("Некорректная дата обновления резюме", map[string]interface{}{
+				"resume_id":  apiItem.ID,
+				"updated_at": apiItem.UpdatedAt,
+			})
 		}
 	}
 
-	// Конвертация навыков
-	resume.Skills = make([]string, 0, len(apiItem.Skills))
-	for _, skill := range apiItem.Skills {
-		if skill.Name != "" {
-			resume.Skills = append(resume.Skills, skill.Name)
+	// Ключевые навыки; свободный текст блока «Обо мне» хранится отдельно в About
+	resume.Skills = make([]string, 0, len(apiItem.SkillSet))
+	for _, skill := range apiItem.SkillSet {
+		if skill = strings.TrimSpace(skill); skill != "" {
+			resume.Skills = append(resume.Skills, skill)
 		}
 	}
 
@@ -286,53 +512,92 @@ func (r *hhRepository) convertToResume(apiItem HHResumeItem) entities.Resume {
 	resume.Experience = make([]entities.Job, 0, len(apiItem.Experience))
 	for _, exp := range apiItem.Experience {
 		job := entities.Job{
-			Company:     exp.Company.Name,
+			Company:     exp.Company,
 			Position:    exp.Position,
 			Description: exp.Description,
+			StartDate:   exp.Start,
+			EndDate:     exp.End,
+			Industry:    strings.Join(names(exp.Industries), "; "),
 		}
-		
-		// Парсинг дат работы
-		if exp.Start != "" {
-			job.StartDate = exp.Start
+		if job.Industry == "" && exp.Industry != nil {
+			job.Industry = exp.Industry.Name
 		}
-		if exp.End != "" {
-			job.EndDate = exp.End
-		}
-		
+
 		resume.Experience = append(resume.Experience, job)
 	}
 
+	if apiItem.TotalExperience != nil {
+		resume.TotalExperience = apiItem.TotalExperience.Months
+	}
+
 	// Конвертация образования
-	resume.Education = make([]entities.Edu, 0, len(apiItem.Education))
-	for _, edu := range apiItem.Education {
-		education := entities.Edu{
-			Institution: edu.Name,
-			Faculty:     edu.Organization,
-			Specialty:   edu.Result,
-			Year:        strconv.Itoa(edu.Year),
-		}
-		resume.Education = append(resume.Education, education)
+	resume.EducationLevel = apiItem.Education.Level.Name
+	resume.Education = make([]entities.Edu, 0, len(apiItem.Education.Primary)+len(apiItem.Education.Additional))
+	for _, edu := range apiItem.Education.Primary {
+		resume.Education = append(resume.Education, edu.toEdu(apiItem.Education.Level.Name))
+	}
+	for _, edu := range apiItem.Education.Additional {
+		resume.Education = append(resume.Education, edu.toEdu(hhAdditionalEducation))
 	}
 
-	// Конвертация контактной информации
-	if apiItem.Contact.Phone.Formatted != "" || apiItem.Contact.Email.Email != "" {
-		resume.Contact = entities.Contact{
-			Phone: apiItem.Contact.Phone.Formatted,
-			Email: apiItem.Contact.Email.Email,
+	// Профессиональные роли, специализации, гражданство, разрешение на работу
+	resume.ProfessionalRoles = names(apiItem.ProfessionalRoles)
+	resume.Specializations = names(apiItem.Specialization)
+	resume.Citizenship = names(apiItem.Citizenship)
+	resume.WorkTicket = names(apiItem.WorkTicket)
+
+	// Знание языков
+	for _, lang := range apiItem.Language {
+		resume.Languages = append(resume.Languages, entities.Language{
+			Name:  lang.Name,
+			Level: lang.Level.Name,
+		})
+	}
+
+	// Занятость и график: старые поля API содержат одно значение
+	resume.Employment = names(apiItem.Employments)
+	if len(resume.Employment) == 0 && apiItem.Employment != nil {
+		resume.Employment = []string{apiItem.Employment.Name}
+	}
+	resume.Schedule = names(apiItem.Schedules)
+	if len(resume.Schedule) == 0 && apiItem.Schedule != nil {
+		resume.Schedule = []string{apiItem.Schedule.Name}
+	}
+
+	if apiItem.Relocation != nil && apiItem.Relocation.Type.Name != "" {
+		resume.Relocation = &entities.Relocation{
+			Type:  apiItem.Relocation.Type.Name,
+			Areas: names(apiItem.Relocation.Area),
 		}
 	}
 
+	if apiItem.BusinessTripReadiness != nil {
+		resume.BusinessTripReadiness = apiItem.BusinessTripReadiness.Name
+	}
+
+	for _, cert := range apiItem.Certificate {
+		resume.Certificates = append(resume.Certificates, entities.Certificate{
+			Title:      cert.Title,
+			Type:       cert.Type,
+			AchievedAt: cert.AchievedAt,
+			URL:        cert.URL,
+		})
+	}
+
+	// Конвертация контактной информации
+	resume.Contact = convertContacts(apiItem.Contact, apiItem.Site)
+
 	// Дополнительная информация
-	if apiItem.Age != 0 {
-		resume.Age = apiItem.Age
+	if apiItem.Age != nil {
+		resume.Age = *apiItem.Age
 	}
-	
-	if apiItem.Gender.Name != "" {
+
+	if apiItem.Gender != nil {
 		resume.Gender = apiItem.Gender.Name
 	}
 
 	// Информация о зарплате
-	if apiItem.Salary.Amount != 0 {
+	if apiItem.Salary != nil && apiItem.Salary.Amount != 0 {
 		resume.Salary = &entities.Salary{
 			Amount:   apiItem.Salary.Amount,
 			Currency: apiItem.Salary.Currency,
@@ -343,6 +608,71 @@ func (r *hhRepository) convertToResume(apiItem HHResumeItem) entities.Resume {
 	return resume
 }
 
+// hhAdditionalEducation - уровень для курсов и повышения квалификации
+const hhAdditionalEducation = "Повышение квалификации, курсы"
+
+// parseHHTime - разбор даты из ответа API
+// hh.ru отдает смещение без двоеточия ("+0300"), поэтому RFC3339 используется как запасной вариант
+func parseHHTime(value string) (time.Time, error) {
+	t, err := time.Parse(hhTimeLayout, value)
+	if err == nil {
+		return t, nil
+	}
+	if t, rfcErr := time.Parse(time.RFC3339, value); rfcErr == nil {
+		return t, nil
+	}
+	return time.Time{}, err
+}
+
+// convertContacts - конвертация контактов и сайтов соискателя
+func convertContacts(contacts []HHContact, sites []HHSite) entities.Contact {
+	var contact entities.Contact
+
+	for _, item := range contacts {
+		switch item.Type.ID {
+		case "email":
+			var email string
+			if json.Unmarshal(item.Value, &email) == nil && contact.Email == "" {
+				contact.Email = email
+			}
+		case "cell", "home", "work":
+			var phone struct {
+				Formatted string `json:"formatted"`
+			}
+			// Предпочтительный номер заменяет найденный ранее
+			if json.Unmarshal(item.Value, &phone) == nil && phone.Formatted != "" && (contact.Phone == "" || item.Preferred) {
+				contact.Phone = phone.Formatted
+			}
+		}
+	}
+
+	for _, site := range sites {
+		switch site.Type.ID {
+		case "skype":
+			contact.Skype = site.URL
+		case "telegram":
+			contact.Telegram = site.URL
+		default:
+			if site.URL != "" {
+				contact.Social = append(contact.Social, entities.Social{Type: site.Type.Name, URL: site.URL})
+			}
+		}
+	}
+
+	return contact
+}
+
+// names - названия элементов справочника
+func names(items []HHNamed) []string {
+	var result []string
+	for _, item := range items {
+		if item.Name != "" {
+			result = append(result, item.Name)
+		}
+	}
+	return result
+}
+
 // HHAPIResponse - структура ответа API поиска резюме
 type HHAPIResponse struct {
 	Items []HHResumeItem `json:"items"` // Список резюме
@@ -351,59 +681,129 @@ type HHAPIResponse struct {
 	Page  int            `json:"page"`  // Текущая страница
 }
 
+// HHNamed - элемент справочника hh.ru
+type HHNamed struct {
+	ID   string `json:"id"`   // Идентификатор
+	Name string `json:"name"` // Название
+}
+
 // HHResumeItem - структура элемента резюме из API hh.ru
+// Большинство полей могут отсутствовать в выдаче поиска или быть null
 type HHResumeItem struct {
-	ID        string `json:"id"`         // Идентификатор резюме
-	Title     string `json:"title"`      // Заголовок резюме  
-	FirstName string `json:"first_name"` // Имя
-	LastName  string `json:"last_name"`  // Фамилия
-	UpdatedAt string `json:"updated_at"` // Дата обновления
-	URL       string `json:"url"`        // Ссылка на резюме
-	Age       int    `json:"age"`        // Возраст
-
-	// Навыки
-	Skills []struct {
-		Name string `json:"name"` // Название навыка
-	} `json:"skills"`
+	ID        string  `json:"id"`         // Идентификатор резюме
+	Title     string  `json:"title"`      // Заголовок резюме
+	FirstName string  `json:"first_name"` // Имя
+	LastName  string  `json:"last_name"`  // Фамилия
+	UpdatedAt string  `json:"updated_at"` // Дата обновления
+	URL       string  `json:"url"`        // Ссылка на резюме
+	Age       *int    `json:"age"`        // Возраст
+	Area      HHNamed `json:"area"`       // Регион проживания
+
+	// Навыки: ключевые навыки списком и свободный текст «Обо мне»
+	SkillSet []string `json:"skill_set"`
+	Skills   string   `json:"skills"`
+
+	// Общий стаж
+	TotalExperience *struct {
+		Months int `json:"months"` // Стаж в месяцах
+	} `json:"total_experience"`
 
 	// Опыт работы
 	Experience []struct {
-		Company struct {
-			Name string `json:"name"` // Название компании
-		} `json:"company"`
-		Position    string `json:"position"`    // Должность
-		Start       string `json:"start"`       // Дата начала
-		End         string `json:"end"`         // Дата окончания  
-		Description string `json:"description"` // Описание
+		Company     string    `json:"company"`     // Название компании
+		Position    string    `json:"position"`    // Должность
+		Start       string    `json:"start"`       // Дата начала
+		End         string    `json:"end"`         // Дата окончания
+		Description string    `json:"description"` // Описание
+		Industries  []HHNamed `json:"industries"`  // Отрасли компании
+		Industry    *HHNamed  `json:"industry"`    // Отрасль (устаревшее поле)
 	} `json:"experience"`
 
 	// Образование
-	Education []struct {
-		Name         string `json:"name"`         // Название учебного заведения
-		Organization string `json:"organization"` // Факультет/организация
-		Result       string `json:"result"`       // Специальность/результат
-		Year         int    `json:"year"`         // Год окончания
+	Education struct {
+		Level      HHNamed           `json:"level"`      // Уровень образования
+		Primary    []HHEducationItem `json:"primary"`    // Основное образование
+		Additional []HHEducationItem `json:"additional"` // Курсы и повышение квалификации
 	} `json:"education"`
 
-	// Контакты
-	Contact struct {
-		Phone struct {
-			Formatted string `json:"formatted"` // Отформатированный номер
-		} `json:"phone"`
-		Email struct {
-			Email string `json:"email"` // Email адрес
-		} `json:"email"`
-	} `json:"contact"`
+	ProfessionalRoles []HHNamed `json:"professional_roles"` // Профессиональные роли
+	Specialization    []HHNamed `json:"specialization"`     // Специализации
+	Citizenship       []HHNamed `json:"citizenship"`        // Гражданство
+	WorkTicket        []HHNamed `json:"work_ticket"`        // Разрешение на работу
+
+	// Знание языков
+	Language []struct {
+		Name  string  `json:"name"`  // Язык
+		Level HHNamed `json:"level"` // Уровень владения
+	} `json:"language"`
+
+	// Готовность к переезду
+	Relocation *struct {
+		Type HHNamed   `json:"type"` // Отношение к переезду
+		Area []HHNamed `json:"area"` // Регионы для переезда
+	} `json:"relocation"`
+
+	Employment            *HHNamed  `json:"employment"`              // Тип занятости (устаревшее поле)
+	Employments           []HHNamed `json:"employments"`             // Типы занятости
+	Schedule              *HHNamed  `json:"schedule"`                // График работы (устаревшее поле)
+	Schedules             []HHNamed `json:"schedules"`               // Графики работы
+	BusinessTripReadiness *HHNamed  `json:"business_trip_readiness"` // Готовность к командировкам
+
+	// Сертификаты
+	Certificate []struct {
+		Title      string `json:"title"`       // Название
+		Type       string `json:"type"`        // Тип
+		AchievedAt string `json:"achieved_at"` // Дата получения
+		URL        string `json:"url"`         // Ссылка
+	} `json:"certificate"`
+
+	// Контакты (доступны, если контакты резюме открыты)
+	Contact []HHContact `json:"contact"`
+	Site    []HHSite    `json:"site"`
 
 	// Пол
-	Gender struct {
-		Name string `json:"name"` // Пол (мужской/женский)
-	} `json:"gender"`
+	Gender *HHNamed `json:"gender"`
 
 	// Зарплата
-	Salary struct {
+	Salary *struct {
 		Amount   int    `json:"amount"`   // Сумма
 		Currency string `json:"currency"` // Валюта
 		Gross    bool   `json:"gross"`    // До налогов
 	} `json:"salary"`
 }
+
+// HHEducationItem - учебное заведение или курс
+type HHEducationItem struct {
+	Name         string `json:"name"`         // Название учебного заведения
+	Organization string `json:"organization"` // Факультет/организация
+	Result       string `json:"result"`       // Специальность/результат
+	Year         int    `json:"year"`         // Год окончания
+}
+
+// toEdu - конвертация в доменную сущность с указанным уровнем образования
+func (e HHEducationItem) toEdu(level string) entities.Edu {
+	edu := entities.Edu{
+		Institution: e.Name,
+		Faculty:     e.Organization,
+		Specialty:   e.Result,
+		Level:       level,
+	}
+	if e.Year != 0 {
+		edu.Year = strconv.Itoa(e.Year)
+	}
+	return edu
+}
+
+// HHContact - контакт соискателя
+// Значение - строка для email и объект для телефонов
+type HHContact struct {
+	Type      HHNamed         `json:"type"`      // Тип контакта (cell, home, work, email)
+	Value     json.RawMessage `json:"value"`     // Значение
+	Preferred bool            `json:"preferred"` // Предпочтительный способ связи
+}
+
+// HHSite - профиль соискателя на внешнем сайте
+type HHSite struct {
+	Type HHNamed `json:"type"` // Тип сайта (skype, linkedin, personal, ...)
+	URL  string  `json:"url"`  // Адрес или логин
+}
@@ -0,0 +1,191 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"hh-resume-parser/internal/domain/entities"
+	"hh-resume-parser/internal/domain/repositories"
+)
+
+// SearchVacancies - поиск вакансий по критериям через API hh.ru
+func (r *hhRepository) SearchVacancies(ctx context.Context, criteria repositories.SearchCriteria) ([]entities.Vacancy, error) {
+	criteria, err := r.resolveCriteriaAreas(ctx, criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResponse HHVacancyResponse
+	if err := r.fetchSearchPage(ctx, vacanciesEndpoint, criteria, &apiResponse); err != nil {
+		return nil, err
+	}
+
+	vacancies := make([]entities.Vacancy, 0, len(apiResponse.Items))
+	for _, item := range apiResponse.Items {
+		vacancies = append(vacancies, r.convertToVacancy(item))
+	}
+
+	r.logger.Info("Получены вакансии из API", map[string]interface{}{
+		"count":       len(vacancies),
+		"total_found": apiResponse.Found,
+		"page":        apiResponse.Page,
+		"total_pages": apiResponse.Pages,
+	})
+
+	return vacancies, nil
+}
+
+// GetVacancyByID - получение полной информации о вакансии по ID
+func (r *hhRepository) GetVacancyByID(ctx context.Context, id string) (*entities.Vacancy, error) {
+	detailURL := fmt.Sprintf("%s%s/%s", r.baseURL, vacanciesEndpoint, url.PathEscape(id))
+
+	r.logger.Debug("Получаем детальную информацию о вакансии", map[string]interface{}{
+		"vacancy_id": id,
+		"url":        detailURL,
+	})
+
+	resp, err := r.makeAPIRequest(ctx, detailURL)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения вакансии %s: %w", id, err)
+	}
+	defer resp.Body.Close()
+
+	var apiItem HHVacancyItem
+	if err := json.NewDecoder(resp.Body).Decode(&apiItem); err != nil {
+		return nil, fmt.Errorf("ошибка парсинга вакансии %s: %w", id, err)
+	}
+
+	vacancy := r.convertToVacancy(apiItem)
+	return &vacancy, nil
+}
+
+// convertToVacancy - конвертация данных API в доменную сущность
+func (r *hhRepository) convertToVacancy(apiItem HHVacancyItem) entities.Vacancy {
+	vacancy := entities.Vacancy{
+		ID:       apiItem.ID,
+		Name:     apiItem.Name,
+		URL:      apiItem.AlternateURL,
+		AreaID:   apiItem.Area.ID,
+		Location: apiItem.Area.Name,
+		Employer: entities.Employer{
+			ID:   apiItem.Employer.ID,
+			Name: apiItem.Employer.Name,
+			URL:  apiItem.Employer.AlternateURL,
+		},
+		ProfessionalRoles: names(apiItem.ProfessionalRoles),
+		Requirement:       stripHTML(apiItem.Snippet.Requirement),
+		Responsibility:    stripHTML(apiItem.Snippet.Responsibility),
+		Description:       stripHTML(apiItem.Description),
+		Archived:          apiItem.Archived,
+	}
+
+	if apiItem.PublishedAt != "" {
+		if publishedAt, err := parseHHTime(apiItem.PublishedAt); err == nil {
+			vacancy.PublishedAt = publishedAt
+		}
+	}
+
+	if apiItem.Experience != nil {
+		vacancy.Experience = apiItem.Experience.Name
+	}
+	if apiItem.Employment != nil {
+		vacancy.Employment = apiItem.Employment.Name
+	}
+	if apiItem.Schedule != nil {
+		vacancy.Schedule = apiItem.Schedule.Name
+	}
+
+	for _, skill := range apiItem.KeySkills {
+		if skill.Name != "" {
+			vacancy.KeySkills = append(vacancy.KeySkills, skill.Name)
+		}
+	}
+
+	if apiItem.Salary != nil && (apiItem.Salary.From != nil || apiItem.Salary.To != nil) {
+		vacancy.Salary = &entities.SalaryRange{
+			Currency: apiItem.Salary.Currency,
+			Gross:    apiItem.Salary.Gross,
+		}
+		if apiItem.Salary.From != nil {
+			vacancy.Salary.From = *apiItem.Salary.From
+		}
+		if apiItem.Salary.To != nil {
+			vacancy.Salary.To = *apiItem.Salary.To
+		}
+	}
+
+	return vacancy
+}
+
+var (
+	// htmlTagPattern - HTML теги в описании вакансии и подсветка в сниппетах
+	htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+	// spacePattern - последовательности пробелов после удаления тегов
+	spacePattern = regexp.MustCompile(`\s+`)
+)
+
+// stripHTML - текст без HTML разметки
+func stripHTML(s string) string {
+	if s == "" {
+		return ""
+	}
+	s = htmlTagPattern.ReplaceAllString(s, " ")
+	s = html.UnescapeString(s)
+	return strings.TrimSpace(spacePattern.ReplaceAllString(s, " "))
+}
+
+// HHVacancyResponse - структура ответа API поиска вакансий
+type HHVacancyResponse struct {
+	Items []HHVacancyItem `json:"items"` // Список вакансий
+	Found int             `json:"found"` // Общее количество найденных
+	Pages int             `json:"pages"` // Количество страниц
+	Page  int             `json:"page"`  // Текущая страница
+}
+
+// HHVacancyItem - структура вакансии из API hh.ru
+// Описание и ключевые навыки есть только в полной версии вакансии
+type HHVacancyItem struct {
+	ID           string  `json:"id"`            // Идентификатор вакансии
+	Name         string  `json:"name"`          // Название
+	Area         HHNamed `json:"area"`          // Регион
+	AlternateURL string  `json:"alternate_url"` // Ссылка на вакансию на сайте
+	PublishedAt  string  `json:"published_at"`  // Дата публикации
+	Archived     bool    `json:"archived"`      // Вакансия в архиве
+	Description  string  `json:"description"`   // Описание (HTML)
+
+	// Работодатель
+	Employer struct {
+		ID           string `json:"id"`            // Идентификатор
+		Name         string `json:"name"`          // Название
+		AlternateURL string `json:"alternate_url"` // Ссылка на страницу работодателя
+	} `json:"employer"`
+
+	// Зарплата; границы могут отсутствовать
+	Salary *struct {
+		From     *int   `json:"from"`     // Нижняя граница
+		To       *int   `json:"to"`       // Верхняя граница
+		Currency string `json:"currency"` // Валюта
+		Gross    bool   `json:"gross"`    // До налогов
+	} `json:"salary"`
+
+	// Краткие требования и обязанности из выдачи поиска
+	Snippet struct {
+		Requirement    string `json:"requirement"`
+		Responsibility string `json:"responsibility"`
+	} `json:"snippet"`
+
+	Experience        *HHNamed  `json:"experience"`         // Требуемый опыт
+	Employment        *HHNamed  `json:"employment"`         // Тип занятости
+	Schedule          *HHNamed  `json:"schedule"`           // График работы
+	ProfessionalRoles []HHNamed `json:"professional_roles"` // Профессиональные роли
+
+	// Ключевые навыки
+	KeySkills []struct {
+		Name string `json:"name"`
+	} `json:"key_skills"`
+}
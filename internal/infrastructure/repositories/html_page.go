@@ -0,0 +1,347 @@
+package repositories
+
+import (
+	"html"
+	"strings"
+)
+
+// htmlNode - элемент или текст разобранной HTML страницы
+// Для текстового узла tag пустой, а содержимое хранится в text
+type htmlNode struct {
+	tag      string
+	attrs    map[string]string
+	text     string
+	parent   *htmlNode
+	children []*htmlNode
+}
+
+// htmlVoidTags - элементы без закрывающего тега
+var htmlVoidTags = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "source": true, "track": true, "wbr": true,
+}
+
+// htmlRawTags - элементы, содержимое которых не разбирается как разметка
+// Содержимое script и style отбрасывается
+var htmlRawTags = map[string]bool{"script": true, "style": true, "textarea": true, "title": true, "noscript": true}
+
+// htmlBlockTags - элементы, границы которых разделяют строки текста
+var htmlBlockTags = map[string]bool{
+	"address": true, "article": true, "blockquote": true, "br": true, "dd": true, "div": true, "dl": true,
+	"dt": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true, "header": true,
+	"hr": true, "li": true, "ol": true, "p": true, "pre": true, "section": true, "table": true,
+	"td": true, "th": true, "tr": true, "ul": true,
+}
+
+// parseHTML - разбор HTML страницы в дерево элементов
+// Разбор нестрогий, как в браузере: незакрытые элементы закрываются вместе с родителем,
+// лишние закрывающие теги пропускаются. Сохраненные браузером страницы hh.ru разбираются целиком
+func parseHTML(page string) *htmlNode {
+	root := &htmlNode{tag: "#document"}
+	current := root
+
+	appendText := func(text string) {
+		if text == "" {
+			return
+		}
+		current.children = append(current.children, &htmlNode{text: html.UnescapeString(text), parent: current})
+	}
+
+	for len(page) > 0 {
+		start := strings.IndexByte(page, '<')
+		if start < 0 {
+			appendText(page)
+			break
+		}
+		appendText(page[:start])
+		page = page[start:]
+
+		switch {
+		case strings.HasPrefix(page, "<!--"):
+			end := strings.Index(page, "-->")
+			if end < 0 {
+				return root
+			}
+			page = page[end+3:]
+			continue
+		case strings.HasPrefix(page, "<!"), strings.HasPrefix(page, "<?"):
+			end := strings.IndexByte(page, '>')
+			if end < 0 {
+				return root
+			}
+			page = page[end+1:]
+			continue
+		case strings.HasPrefix(page, "</"):
+			end := strings.IndexByte(page, '>')
+			if end < 0 {
+				return root
+			}
+			current = closeHTMLElement(current, strings.ToLower(strings.TrimSpace(page[2:end])))
+			page = page[end+1:]
+			continue
+		}
+
+		tag, attrs, selfClosing, rest, ok := parseHTMLTag(page)
+		if !ok {
+			// Одиночный "<" в тексте
+			appendText("<")
+			page = page[1:]
+			continue
+		}
+		page = rest
+
+		current = implicitlyClose(current, tag)
+		node := &htmlNode{tag: tag, attrs: attrs, parent: current}
+		current.children = append(current.children, node)
+
+		if htmlRawTags[tag] {
+			end := indexFold(page, "</"+tag)
+			if end < 0 {
+				end = len(page)
+			}
+			if tag != "script" && tag != "style" {
+				node.children = append(node.children, &htmlNode{text: html.UnescapeString(page[:end]), parent: node})
+			}
+			page = page[end:]
+			if close := strings.IndexByte(page, '>'); close >= 0 {
+				page = page[close+1:]
+			}
+			continue
+		}
+
+		if !selfClosing && !htmlVoidTags[tag] {
+			current = node
+		}
+	}
+
+	return root
+}
+
+// parseHTMLTag - разбор открывающего тега в начале page
+// Возвращает имя, атрибуты, признак "/>" и остаток страницы после тега
+func parseHTMLTag(page string) (tag string, attrs map[string]string, selfClosing bool, rest string, ok bool) {
+	i := 1
+	for i < len(page) && isHTMLNameChar(page[i]) {
+		i++
+	}
+	if i == 1 {
+		return "", nil, false, page, false
+	}
+	tag = strings.ToLower(page[1:i])
+	attrs = make(map[string]string)
+
+	for i < len(page) {
+		for i < len(page) && isHTMLSpace(page[i]) {
+			i++
+		}
+		if i >= len(page) {
+			break
+		}
+
+		switch page[i] {
+		case '>':
+			return tag, attrs, selfClosing, page[i+1:], true
+		case '/':
+			selfClosing = true
+			i++
+			continue
+		}
+		selfClosing = false
+
+		nameStart := i
+		for i < len(page) && !isHTMLSpace(page[i]) && page[i] != '=' && page[i] != '>' && page[i] != '/' {
+			i++
+		}
+		name := strings.ToLower(page[nameStart:i])
+		if name == "" {
+			i++
+			continue
+		}
+
+		for i < len(page) && isHTMLSpace(page[i]) {
+			i++
+		}
+		if i >= len(page) || page[i] != '=' {
+			attrs[name] = ""
+			continue
+		}
+		i++
+		for i < len(page) && isHTMLSpace(page[i]) {
+			i++
+		}
+
+		var value string
+		if i < len(page) && (page[i] == '"' || page[i] == '\'') {
+			quote := page[i]
+			end := strings.IndexByte(page[i+1:], quote)
+			if end < 0 {
+				return "", nil, false, page, false
+			}
+			value = page[i+1 : i+1+end]
+			i += end + 2
+		} else {
+			valueStart := i
+			for i < len(page) && !isHTMLSpace(page[i]) && page[i] != '>' {
+				i++
+			}
+			value = page[valueStart:i]
+		}
+		attrs[name] = html.UnescapeString(value)
+	}
+
+	return "", nil, false, page, false
+}
+
+// closeHTMLElement - закрытие элемента tag и всех незакрытых внутри него
+// Закрывающий тег без открытого элемента пропускается
+func closeHTMLElement(current *htmlNode, tag string) *htmlNode {
+	for node := current; node.parent != nil; node = node.parent {
+		if node.tag == tag {
+			return node.parent
+		}
+	}
+	return current
+}
+
+// implicitlyClose - закрытие элементов, которые HTML закрывает без тега:
+// абзаца перед блочным элементом и пункта списка перед следующим пунктом
+func implicitlyClose(current *htmlNode, tag string) *htmlNode {
+	if current.tag == "p" && htmlBlockTags[tag] && tag != "br" {
+		return current.parent
+	}
+	if tag == "li" || tag == "dt" || tag == "dd" || tag == "tr" || tag == "td" || tag == "th" || tag == "option" {
+		for node := current; node.parent != nil; node = node.parent {
+			if node.tag == tag {
+				return node.parent
+			}
+			if node.tag == "ul" || node.tag == "ol" || node.tag == "dl" || node.tag == "table" || node.tag == "select" {
+				break
+			}
+		}
+	}
+	return current
+}
+
+func isHTMLNameChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == ':' || c == '_'
+}
+
+func isHTMLSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+// indexFold - позиция substr в s без учета регистра ASCII
+func indexFold(s, substr string) int {
+	return strings.Index(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// attr - значение атрибута элемента
+func (n *htmlNode) attr(name string) string {
+	return n.attrs[name]
+}
+
+// hasClass - есть ли у элемента CSS класс
+func (n *htmlNode) hasClass(class string) bool {
+	for _, c := range strings.Fields(n.attrs["class"]) {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+// findAll - элементы поддерева (без самого элемента), подходящие под условие, в порядке документа
+func (n *htmlNode) findAll(match func(*htmlNode) bool) []*htmlNode {
+	var found []*htmlNode
+	for _, child := range n.children {
+		if child.tag == "" {
+			continue
+		}
+		if match(child) {
+			found = append(found, child)
+		}
+		found = append(found, child.findAll(match)...)
+	}
+	return found
+}
+
+// find - первый элемент поддерева, подходящий под условие, или nil
+func (n *htmlNode) find(match func(*htmlNode) bool) *htmlNode {
+	for _, child := range n.children {
+		if child.tag == "" {
+			continue
+		}
+		if match(child) {
+			return child
+		}
+		if found := child.find(match); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// byQA - условие поиска элемента по атрибуту data-qa, которым hh.ru размечает блоки страницы
+func byQA(name string) func(*htmlNode) bool {
+	return func(n *htmlNode) bool { return n.attrs["data-qa"] == name }
+}
+
+// byClass - условие поиска элемента по CSS классу
+func byClass(class string) func(*htmlNode) bool {
+	return func(n *htmlNode) bool { return n.hasClass(class) }
+}
+
+// byTag - условие поиска элемента по имени тега
+func byTag(tag string) func(*htmlNode) bool {
+	return func(n *htmlNode) bool { return n.tag == tag }
+}
+
+// textOf - текст найденного элемента или пустая строка
+func textOf(n *htmlNode) string {
+	if n == nil {
+		return ""
+	}
+	return n.textContent()
+}
+
+// textContent - текст элемента в одну строку с нормализованными пробелами
+func (n *htmlNode) textContent() string {
+	return strings.Join(strings.Fields(n.multilineText()), " ")
+}
+
+// multilineText - текст элемента, в котором блочные элементы разделены переводами строк
+// Пробелы внутри строк нормализуются, пустые строки отбрасываются
+func (n *htmlNode) multilineText() string {
+	var b strings.Builder
+	n.writeText(&b)
+
+	var lines []string
+	for _, line := range strings.Split(b.String(), "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// writeText - сбор текста поддерева
+func (n *htmlNode) writeText(b *strings.Builder) {
+	if n.tag == "" {
+		b.WriteString(strings.ReplaceAll(n.text, "\n", " "))
+		return
+	}
+	if n.tag == "script" || n.tag == "style" {
+		return
+	}
+
+	block := htmlBlockTags[n.tag]
+	if block {
+		b.WriteByte('\n')
+	}
+	for _, child := range n.children {
+		child.writeText(b)
+	}
+	if block {
+		b.WriteByte('\n')
+	}
+}
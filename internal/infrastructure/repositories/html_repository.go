@@ -0,0 +1,365 @@
+package repositories
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"hh-resume-parser/internal/domain/entities"
+	"hh-resume-parser/internal/domain/repositories"
+	"hh-resume-parser/internal/infrastructure/logger"
+)
+
+// NewHTMLRepository - создание источника резюме из каталога сохраненных страниц hh.ru
+// Читаются файлы *.html и *.htm, в том числе во вложенных каталогах; поля извлекаются по разметке
+// data-qa, которой hh.ru помечает блоки резюме, и совпадают с полями, получаемыми из API.
+// Страницы, в которых не найдено резюме, пропускаются с предупреждением
+func NewHTMLRepository(dir string, logger logger.Logger) repositories.ResumeRepository {
+	return &localRepository{
+		path:   dir,
+		format: "html",
+		exts:   []string{".html", ".htm"},
+		parse: func(file string, data []byte) ([]entities.Resume, error) {
+			resume, err := parseResumePage(string(data), strings.TrimSuffix(filepath.Base(file), filepath.Ext(file)))
+			if err != nil {
+				return nil, err
+			}
+			return []entities.Resume{resume}, nil
+		},
+		logger: logger,
+	}
+}
+
+// parseResumePage - извлечение резюме из сохраненной страницы hh.ru
+// fallbackID используется, если на странице нет ссылки на резюме
+func parseResumePage(page, fallbackID string) (entities.Resume, error) {
+	doc := parseHTML(page)
+
+	resume := entities.Resume{
+		Name:     textOf(doc.find(byQA("resume-personal-name"))),
+		Title:    textOf(doc.find(byQA("resume-block-title-position"))),
+		Gender:   textOf(doc.find(byQA("resume-personal-gender"))),
+		Location: textOf(doc.find(byQA("resume-personal-address"))),
+		About:    multilineTextOf(doc.find(byQA("resume-block-skills-content"))),
+	}
+	if resume.Name == "" && resume.Title == "" {
+		return entities.Resume{}, fmt.Errorf("на странице не найдено резюме hh.ru")
+	}
+
+	resume.URL = pageURL(doc)
+	resume.ID = resumeIDFromURL(resume.URL)
+	if resume.ID == "" {
+		resume.ID = fallbackID
+	}
+
+	resume.Age = leadingNumber(textOf(doc.find(byQA("resume-personal-age"))))
+	resume.LastUpdate = parseRussianDate(textOf(doc.find(byQA("resume-update-date"))))
+	resume.Salary = parseSalaryText(textOf(doc.find(byQA("resume-block-salary"))))
+
+	// Ключевые навыки
+	if skills := doc.find(byQA("skills-table")); skills != nil {
+		for _, tag := range skills.findAll(byQA("bloko-tag__text")) {
+			if skill := tag.textContent(); skill != "" {
+				resume.Skills = append(resume.Skills, skill)
+			}
+		}
+	}
+
+	// Профессиональные роли, занятость и график
+	for _, role := range doc.findAll(byQA("resume-block-position-specialization")) {
+		resume.ProfessionalRoles = append(resume.ProfessionalRoles, role.textContent())
+	}
+	for _, p := range doc.findAll(byTag("p")) {
+		text := p.textContent()
+		if value, ok := strings.CutPrefix(text, "Занятость:"); ok {
+			resume.Employment = splitPageList(value)
+		} else if value, ok := strings.CutPrefix(text, "График работы:"); ok {
+			resume.Schedule = splitPageList(value)
+		}
+	}
+
+	// Опыт работы
+	if block := doc.find(byQA("resume-block-experience")); block != nil {
+		resume.TotalExperience = parseExperienceMonths(textOf(block.find(byClass("resume-block__title-text_sub"))))
+		for _, item := range block.findAll(byClass("resume-block-item-gap")) {
+			if job, ok := parseExperienceItem(item); ok {
+				resume.Experience = append(resume.Experience, job)
+			}
+		}
+	}
+
+	// Образование: основное и курсы
+	if block := doc.find(byQA("resume-block-education")); block != nil {
+		resume.EducationLevel = strings.TrimSuffix(textOf(block.find(byClass("resume-block__title-text_sub"))), " образование")
+		resume.Education = append(resume.Education, parseEducationItems(block, resume.EducationLevel)...)
+	}
+	if block := doc.find(byQA("resume-block-additional-education")); block != nil {
+		resume.Education = append(resume.Education, parseEducationItems(block, hhAdditionalEducation)...)
+	}
+
+	// Знание языков: "Английский — B2 — Средне-продвинутый"
+	for _, item := range doc.findAll(byQA("resume-block-language-item")) {
+		name, level, _ := strings.Cut(item.textContent(), " — ")
+		resume.Languages = append(resume.Languages, entities.Language{Name: name, Level: level})
+	}
+
+	resume.Contact = parsePageContacts(doc)
+
+	return resume, nil
+}
+
+// multilineTextOf - текст найденного элемента с переводами строк или пустая строка
+func multilineTextOf(n *htmlNode) string {
+	if n == nil {
+		return ""
+	}
+	return n.multilineText()
+}
+
+// pageURL - адрес резюме из ссылки canonical или метатега og:url
+func pageURL(doc *htmlNode) string {
+	link := doc.find(func(n *htmlNode) bool { return n.tag == "link" && n.attr("rel") == "canonical" })
+	if link != nil && link.attr("href") != "" {
+		return link.attr("href")
+	}
+	meta := doc.find(func(n *htmlNode) bool { return n.tag == "meta" && n.attr("property") == "og:url" })
+	if meta != nil {
+		return meta.attr("content")
+	}
+	return ""
+}
+
+// resumeIDFromURL - идентификатор резюме из адреса вида https://hh.ru/resume/<id>
+func resumeIDFromURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || !strings.Contains(u.Path, "/resume/") {
+		return ""
+	}
+	return path.Base(u.Path)
+}
+
+// parseExperienceItem - место работы из блока опыта
+func parseExperienceItem(item *htmlNode) (entities.Job, bool) {
+	position := item.find(byQA("resume-block-experience-position"))
+	if position == nil {
+		return entities.Job{}, false
+	}
+
+	job := entities.Job{
+		Position:    position.textContent(),
+		Description: multilineTextOf(item.find(byQA("resume-block-experience-description"))),
+	}
+
+	// Название компании размечено не во всех версиях страницы: без data-qa это первый выделенный текст
+	company := item.find(byQA("resume-experience-company-title"))
+	if company == nil {
+		company = item.find(func(n *htmlNode) bool { return n.hasClass("bloko-text_strong") && n != position })
+	}
+	job.Company = textOf(company)
+
+	if industries := item.find(byClass("resume-block__experience-industries")); industries != nil {
+		var names []string
+		for _, li := range industries.findAll(byTag("li")) {
+			names = append(names, li.textContent())
+		}
+		if len(names) == 0 {
+			names = []string{industries.textContent()}
+		}
+		job.Industry = strings.Join(names, "; ")
+	}
+
+	// Период: "Январь 2020 — по настоящее время", под ним длительность
+	if interval := item.find(byClass("resume-block__experience-timeinterval")); interval != nil {
+		period, _, _ := strings.Cut(interval.multilineText(), "\n")
+		start, end, _ := strings.Cut(period, "—")
+		job.StartDate = parseMonthYear(start)
+		job.EndDate = parseMonthYear(end)
+	}
+
+	return job, true
+}
+
+// parseEducationItems - учебные заведения блока образования
+func parseEducationItems(block *htmlNode, level string) []entities.Edu {
+	var education []entities.Edu
+	for _, item := range block.findAll(byClass("resume-block-item-gap")) {
+		name := item.find(byQA("resume-block-education-name"))
+		if name == nil {
+			continue
+		}
+
+		edu := entities.Edu{Institution: name.textContent(), Level: level}
+
+		// "Факультет, Специальность"
+		organization := textOf(item.find(byQA("resume-block-education-organization")))
+		if faculty, specialty, ok := strings.Cut(organization, ", "); ok {
+			edu.Faculty, edu.Specialty = faculty, specialty
+		} else {
+			edu.Faculty = organization
+		}
+
+		// Год окончания - отдельная колонка с четырьмя цифрами
+		for _, column := range item.findAll(byClass("bloko-column")) {
+			if text := column.textContent(); yearPattern.MatchString(text) {
+				edu.Year = text
+				break
+			}
+		}
+
+		education = append(education, edu)
+	}
+	return education
+}
+
+// parsePageContacts - контакты соискателя, если они открыты на странице
+func parsePageContacts(doc *htmlNode) entities.Contact {
+	var contact entities.Contact
+
+	if phone := doc.find(byQA("resume-contacts-phone")); phone != nil {
+		contact.Phone = strings.TrimSpace(phonePattern.FindString(phone.textContent()))
+	}
+
+	if email := doc.find(byQA("resume-contact-email")); email != nil {
+		if link := email.find(byTag("a")); link != nil && strings.HasPrefix(link.attr("href"), "mailto:") {
+			contact.Email = strings.TrimPrefix(link.attr("href"), "mailto:")
+		} else {
+			contact.Email = email.textContent()
+		}
+	}
+
+	// Сайты: data-qa="resume-personalsite-<тип>"
+	for _, site := range doc.findAll(func(n *htmlNode) bool { return strings.HasPrefix(n.attr("data-qa"), "resume-personalsite-") }) {
+		kind := strings.TrimPrefix(site.attr("data-qa"), "resume-personalsite-")
+		value := site.attr("href")
+		if value == "" {
+			value = site.textContent()
+		}
+		switch kind {
+		case "skype":
+			contact.Skype = value
+		case "telegram":
+			contact.Telegram = value
+		default:
+			if value != "" {
+				contact.Social = append(contact.Social, entities.Social{Type: kind, URL: value})
+			}
+		}
+	}
+
+	return contact
+}
+
+var (
+	yearPattern      = regexp.MustCompile(`^\d{4}$`)
+	numberPattern    = regexp.MustCompile(`\d+`)
+	phonePattern     = regexp.MustCompile(`\+?\d[\d\s()\-]{6,}\d`)
+	dayMonthPattern  = regexp.MustCompile(`(\d{1,2})\s+(\p{L}+)\s+(\d{4})(?:\s+в\s+(\d{1,2}):(\d{2}))?`)
+	monthYearPattern = regexp.MustCompile(`(\p{L}+)\s+(\d{4})`)
+	yearsPattern     = regexp.MustCompile(`(\d+)\s*(?:год|года|лет)`)
+	monthsPattern    = regexp.MustCompile(`(\d+)\s*месяц`)
+)
+
+// moscowTime - часовой пояс дат на страницах hh.ru
+var moscowTime = time.FixedZone("MSK", 3*60*60)
+
+// russianMonth - номер месяца по названию в любом падеже ("январь", "января", "мая")
+func russianMonth(name string) time.Month {
+	name = strings.ToLower(name)
+	prefixes := []string{"янв", "фев", "мар", "апр", "ма", "июн", "июл", "авг", "сен", "окт", "ноя", "дек"}
+	for i, prefix := range prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return time.Month(i + 1)
+		}
+	}
+	return 0
+}
+
+// parseRussianDate - дата вида "Резюме обновлено 12 марта 2024 в 10:00"
+func parseRussianDate(text string) time.Time {
+	match := dayMonthPattern.FindStringSubmatch(text)
+	if match == nil {
+		return time.Time{}
+	}
+	month := russianMonth(match[2])
+	if month == 0 {
+		return time.Time{}
+	}
+
+	day, _ := strconv.Atoi(match[1])
+	year, _ := strconv.Atoi(match[3])
+	hour, _ := strconv.Atoi(match[4])
+	minute, _ := strconv.Atoi(match[5])
+	return time.Date(year, month, day, hour, minute, 0, 0, moscowTime)
+}
+
+// parseMonthYear - дата начала месяца в формате API ("2020-01-01") из "Январь 2020"
+// Для "по настоящее время" возвращается пустая строка
+func parseMonthYear(text string) string {
+	match := monthYearPattern.FindStringSubmatch(text)
+	if match == nil {
+		return ""
+	}
+	month := russianMonth(match[1])
+	if month == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s-%02d-01", match[2], int(month))
+}
+
+// parseExperienceMonths - общий стаж в месяцах из "Опыт работы 5 лет 3 месяца"
+func parseExperienceMonths(text string) int {
+	months := 0
+	if match := yearsPattern.FindStringSubmatch(text); match != nil {
+		years, _ := strconv.Atoi(match[1])
+		months += years * 12
+	}
+	if match := monthsPattern.FindStringSubmatch(text); match != nil {
+		m, _ := strconv.Atoi(match[1])
+		months += m
+	}
+	return months
+}
+
+// parseSalaryText - зарплата из "250 000 ₽ на руку" или "3 000 $ до вычета налогов"
+func parseSalaryText(text string) *entities.Salary {
+	// Разряды разделены пробелами, неразрывные пробелы уже заменены обычными
+	amount := leadingNumber(strings.ReplaceAll(text, " ", ""))
+	if amount == 0 {
+		return nil
+	}
+
+	salary := &entities.Salary{Amount: amount, Gross: strings.Contains(text, "до вычета")}
+	switch {
+	case strings.Contains(text, "$") || strings.Contains(text, "USD"):
+		salary.Currency = "USD"
+	case strings.Contains(text, "€") || strings.Contains(text, "EUR"):
+		salary.Currency = "EUR"
+	case strings.Contains(text, "₸") || strings.Contains(text, "KZT"):
+		salary.Currency = "KZT"
+	default:
+		salary.Currency = "RUR"
+	}
+	return salary
+}
+
+// leadingNumber - первое число в тексте или 0
+func leadingNumber(text string) int {
+	n, _ := strconv.Atoi(numberPattern.FindString(text))
+	return n
+}
+
+// splitPageList - значения через запятую
+func splitPageList(text string) []string {
+	var items []string
+	for _, item := range strings.Split(text, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,282 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"hh-resume-parser/internal/domain/entities"
+	"hh-resume-parser/internal/domain/repositories"
+	"hh-resume-parser/internal/infrastructure/logger"
+)
+
+// localRepository - источник резюме из локальных файлов
+// Файлы читаются при первом обращении; поиск, фильтрация и пагинация выполняются в памяти
+type localRepository struct {
+	path   string   // Каталог или файл
+	format string   // Формат файлов для логов
+	exts   []string // Расширения читаемых файлов
+
+	// parse - разбор одного файла; ошибка означает, что файл пропускается
+	parse func(file string, data []byte) ([]entities.Resume, error)
+
+	logger logger.Logger
+
+	mu      sync.Mutex
+	loaded  bool
+	resumes []entities.Resume // Резюме в порядке убывания даты обновления
+	byID    map[string]int    // Номер резюме в resumes по идентификатору
+}
+
+// NewJSONRepository - создание источника резюме из JSON выгрузок
+// path - файл или каталог с файлами *.json в формате вывода парсера (массив резюме).
+// Позволяет повторно использовать результаты прошлых запусков вместе с другими источниками
+func NewJSONRepository(path string, logger logger.Logger) repositories.ResumeRepository {
+	return &localRepository{
+		path:   path,
+		format: "json",
+		exts:   []string{".json"},
+		parse: func(file string, data []byte) ([]entities.Resume, error) {
+			var resumes []entities.Resume
+			if err := json.Unmarshal(data, &resumes); err != nil {
+				return nil, fmt.Errorf("некорректный JSON: %w", err)
+			}
+
+			valid := resumes[:0]
+			for _, resume := range resumes {
+				if resume.ID != "" {
+					valid = append(valid, resume)
+				}
+			}
+			return valid, nil
+		},
+		logger: logger,
+	}
+}
+
+// SearchResumes - поиск среди локальных резюме
+// Учитываются ключевые слова, город, опыт работы и дата обновления; остальные фильтры
+// API к локальным резюме не применяются
+func (r *localRepository) SearchResumes(ctx context.Context, criteria repositories.SearchCriteria) ([]entities.Resume, error) {
+	if err := r.load(ctx); err != nil {
+		return nil, err
+	}
+
+	var matched []entities.Resume
+	for _, resume := range r.resumes {
+		if matchesLocalCriteria(&resume, criteria) {
+			matched = append(matched, resume)
+		}
+	}
+
+	perPage := criteria.PerPage
+	if perPage <= 0 {
+		perPage = repositories.DefaultPerPage
+	}
+	start := criteria.Page * perPage
+	if start >= len(matched) {
+		return nil, nil
+	}
+	end := min(start+perPage, len(matched))
+
+	return matched[start:end], nil
+}
+
+// GetResumeByID - локальное резюме по идентификатору
+func (r *localRepository) GetResumeByID(ctx context.Context, id string) (*entities.Resume, error) {
+	if err := r.load(ctx); err != nil {
+		return nil, err
+	}
+
+	i, ok := r.byID[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: резюме %s нет в %s", repositories.ErrNotFound, id, r.path)
+	}
+	resume := r.resumes[i]
+	return &resume, nil
+}
+
+// GetResumeWithoutContacts - локальное резюме без контактов соискателя
+func (r *localRepository) GetResumeWithoutContacts(ctx context.Context, id string) (*entities.Resume, error) {
+	resume, err := r.GetResumeByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	resume.Contact = entities.Contact{}
+	return resume, nil
+}
+
+// load - чтение и разбор файлов при первом обращении
+func (r *localRepository) load(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.loaded {
+		return nil
+	}
+
+	byID := make(map[string]int)
+	var resumes []entities.Resume
+
+	err := filepath.WalkDir(r.path, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if entry.IsDir() || !r.readable(file) {
+			return nil
+		}
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("ошибка чтения файла %s: %w", file, err)
+		}
+
+		parsed, err := r.parse(file, data)
+		if err != nil {
+			r.logger.Warn("Файл пропущен", map[string]interface{}{"file": file, "error": err.Error()})
+			return nil
+		}
+
+		// Одно резюме, сохраненное несколько раз, берется в самой свежей версии
+		for _, resume := range parsed {
+			if i, ok := byID[resume.ID]; ok {
+				if resume.LastUpdate.After(resumes[i].LastUpdate) {
+					resumes[i] = resume
+				}
+				continue
+			}
+			byID[resume.ID] = len(resumes)
+			resumes = append(resumes, resume)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("ошибка чтения %s: %w", r.path, err)
+	}
+
+	// Как и в выдаче hh.ru, свежие резюме идут первыми
+	sort.SliceStable(resumes, func(i, j int) bool {
+		return resumes[i].LastUpdate.After(resumes[j].LastUpdate)
+	})
+	for i, resume := range resumes {
+		byID[resume.ID] = i
+	}
+
+	r.logger.Info("Загружены локальные резюме", map[string]interface{}{
+		"path":    r.path,
+		"format":  r.format,
+		"resumes": len(resumes),
+	})
+
+	r.resumes = resumes
+	r.byID = byID
+	r.loaded = true
+	return nil
+}
+
+// readable - читается ли файл источником (по расширению)
+// Файл, указанный явно, читается независимо от расширения
+func (r *localRepository) readable(file string) bool {
+	if file == r.path {
+		return true
+	}
+	ext := strings.ToLower(filepath.Ext(file))
+	for _, e := range r.exts {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesLocalCriteria - подходит ли локальное резюме под критерии поиска
+func matchesLocalCriteria(resume *entities.Resume, criteria repositories.SearchCriteria) bool {
+	if !matchesLocalKeywords(resume, criteria.Keywords, criteria.KeywordLogic) {
+		return false
+	}
+
+	if criteria.City != "" {
+		found := false
+		for _, city := range SplitAreaList(criteria.City) {
+			if city == resume.AreaID || strings.Contains(strings.ToLower(resume.Location), strings.ToLower(city)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if criteria.Experience != "" && experienceBucket(resume.TotalExperience) != criteria.Experience {
+		return false
+	}
+
+	switch {
+	case !criteria.DateFrom.IsZero() || !criteria.DateTo.IsZero():
+		if !criteria.DateFrom.IsZero() && resume.LastUpdate.Before(criteria.DateFrom) {
+			return false
+		}
+		if !criteria.DateTo.IsZero() && !resume.LastUpdate.Before(criteria.DateTo) {
+			return false
+		}
+	case criteria.UpdateDays > 0:
+		if resume.LastUpdate.Before(time.Now().AddDate(0, 0, -criteria.UpdateDays)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchesLocalKeywords - поиск ключевых слов в тексте резюме без учета регистра
+func matchesLocalKeywords(resume *entities.Resume, keywords []string, logic repositories.TextLogic) bool {
+	if len(keywords) == 0 {
+		return true
+	}
+
+	parts := []string{resume.Title, resume.About, strings.Join(resume.Skills, "\n")}
+	for _, job := range resume.Experience {
+		parts = append(parts, job.Position, job.Description)
+	}
+	text := strings.ToLower(strings.Join(parts, "\n"))
+
+	if logic == repositories.LogicPhrase {
+		return strings.Contains(text, strings.ToLower(strings.Join(keywords, " ")))
+	}
+
+	for _, keyword := range keywords {
+		found := strings.Contains(text, strings.ToLower(strings.TrimSpace(keyword)))
+		if logic == repositories.LogicAny && found {
+			return true
+		}
+		if logic != repositories.LogicAny && !found {
+			return false
+		}
+	}
+	return logic != repositories.LogicAny
+}
+
+// experienceBucket - значение фильтра опыта работы hh.ru для стажа в месяцах
+func experienceBucket(months int) string {
+	switch {
+	case months < 12:
+		return "noExperience"
+	case months < 36:
+		return "between1And3"
+	case months < 72:
+		return "between3And6"
+	default:
+		return "moreThan6"
+	}
+}
@@ -0,0 +1,516 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"hh-resume-parser/internal/app"
+	"hh-resume-parser/internal/config"
+	"hh-resume-parser/internal/domain/entities"
+	"hh-resume-parser/internal/infrastructure/logger"
+)
+
+// readJSONResumes читает резюме из JSON файла вывода
+func readJSONResumes(t *testing.T, path string) []entities.Resume {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Не удалось прочитать файл вывода: %v", err)
+	}
+
+	var resumes []entities.Resume
+	if err := json.Unmarshal(data, &resumes); err != nil {
+		t.Fatalf("Некорректный JSON в файле вывода: %v", err)
+	}
+	return resumes
+}
+
+func TestApplicationEndToEnd(t *testing.T) {
+	fake := NewFakeHHServer(60)
+	defer fake.Close()
+
+	cfg := fake.Config(t.TempDir())
+	cfg.Search.City = "Москва, Санкт-Петербург"
+
+	if err := app.New(cfg, logger.NewConsole()).Run(); err != nil {
+		t.Fatalf("Ошибка выполнения: %v", err)
+	}
+
+	resumes := readJSONResumes(t, cfg.Output.File)
+	if len(resumes) != 60 {
+		t.Errorf("Сохранено %d резюме, ожидалось 60", len(resumes))
+	}
+	if fake.PathRequests("/areas") != 1 {
+		t.Errorf("Дерево регионов запрошено %d раз, ожидался 1", fake.PathRequests("/areas"))
+	}
+
+	// Повторный запуск берет дерево регионов из кэша и не дублирует резюме
+	if err := app.New(cfg, logger.NewConsole()).Run(); err != nil {
+		t.Fatalf("Ошибка повторного выполнения: %v", err)
+	}
+	if fake.PathRequests("/areas") != 1 {
+		t.Errorf("Дерево регионов должно браться из кэша при повторном запуске")
+	}
+}
+
+func TestDepthLimitSplitting(t *testing.T) {
+	fake := NewFakeHHServer(300)
+	fake.DepthLimit = 40
+	defer fake.Close()
+
+	cfg := fake.Config(t.TempDir())
+	cfg.API.DepthLimit = 40
+	cfg.Search.City = ""
+
+	if err := app.New(cfg, logger.NewConsole()).Run(); err != nil {
+		t.Fatalf("Ошибка выполнения: %v", err)
+	}
+
+	// Без разбиения было бы получено не более 40 резюме
+	resumes := readJSONResumes(t, cfg.Output.File)
+	if len(resumes) != 300 {
+		t.Errorf("Сохранено %d резюме, ожидалось 300", len(resumes))
+	}
+
+	seen := make(map[string]bool)
+	for _, resume := range resumes {
+		if seen[resume.ID] {
+			t.Errorf("Резюме %s сохранено повторно", resume.ID)
+		}
+		seen[resume.ID] = true
+	}
+}
+
+func TestRetriesTransientErrors(t *testing.T) {
+	fake := NewFakeHHServer(20)
+	defer fake.Close()
+
+	cfg := fake.Config(t.TempDir())
+	fake.FailNext(http.StatusServiceUnavailable, http.StatusTooManyRequests)
+
+	if err := app.New(cfg, logger.NewConsole()).Run(); err != nil {
+		t.Fatalf("Временные ошибки должны повторяться: %v", err)
+	}
+
+	if resumes := readJSONResumes(t, cfg.Output.File); len(resumes) == 0 {
+		t.Error("Резюме не сохранены после повторов")
+	}
+}
+
+func TestRetryAfterIsCapped(t *testing.T) {
+	fake := NewFakeHHServer(20)
+	defer fake.Close()
+
+	cfg := fake.Config(t.TempDir())
+	fake.FailNextWith(http.StatusTooManyRequests, "too_many_requests", "3600")
+
+	// Пауза из Retry-After ограничена api.retry_max_delay, а не ждет час
+	started := time.Now()
+	if err := app.New(cfg, logger.NewConsole()).Run(); err != nil {
+		t.Fatalf("Ошибка выполнения: %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > 5*time.Second {
+		t.Errorf("Повтор после Retry-After занял %s", elapsed)
+	}
+	if resumes := readJSONResumes(t, cfg.Output.File); len(resumes) == 0 {
+		t.Error("Резюме не сохранены после повтора")
+	}
+}
+
+func TestCaptchaIsNotRetried(t *testing.T) {
+	fake := NewFakeHHServer(20)
+	defer fake.Close()
+
+	cfg := fake.Config(t.TempDir())
+	fake.FailNextWith(http.StatusForbidden, "captcha_required", "")
+
+	if err := app.New(cfg, logger.NewConsole()).Run(); err != nil {
+		t.Fatalf("Ошибка выполнения: %v", err)
+	}
+
+	// Капча не снимается повтором: справочники, на которые она пришла, больше не запрашиваются
+	if requests := fake.PathRequests("/dictionaries"); requests != 1 {
+		t.Errorf("Справочники запрошены %d раз после капчи, ожидался 1", requests)
+	}
+}
+
+func TestRetriesClientTimeout(t *testing.T) {
+	fake := NewFakeHHServer(20)
+	defer fake.Close()
+
+	cfg := fake.Config(t.TempDir())
+	cfg.API.Timeout = 200 * time.Millisecond
+	fake.StallNext(5 * time.Second)
+
+	if err := app.New(cfg, logger.NewConsole()).Run(); err != nil {
+		t.Fatalf("Ошибка выполнения: %v", err)
+	}
+
+	// Первым запрашиваются справочники: запрос, прерванный по тайм-ауту, повторяется
+	if requests := fake.PathRequests("/dictionaries"); requests != 2 {
+		t.Errorf("Справочники запрошены %d раз, ожидался повтор после тайм-аута", requests)
+	}
+	if resumes := readJSONResumes(t, cfg.Output.File); len(resumes) == 0 {
+		t.Error("Резюме не сохранены")
+	}
+}
+
+func TestFetchResumeDetails(t *testing.T) {
+	fake := NewFakeHHServer(30)
+	defer fake.Close()
+	fake.HideDetails("fake00007")
+
+	cfg := fake.Config(t.TempDir())
+	cfg.Search.City = ""
+	cfg.Search.FetchDetails = true
+	cfg.Search.DetailWorkers = 4
+
+	if err := app.New(cfg, logger.NewConsole()).Run(); err != nil {
+		t.Fatalf("Ошибка выполнения: %v", err)
+	}
+
+	resumes := readJSONResumes(t, cfg.Output.File)
+	if len(resumes) != 30 {
+		t.Fatalf("Сохранено %d резюме, ожидалось 30", len(resumes))
+	}
+	if fake.PathRequests("/resumes/fake00000") != 1 {
+		t.Errorf("Полное резюме должно запрашиваться один раз")
+	}
+
+	for _, resume := range resumes {
+		switch {
+		case resume.ID == "fake00007" && resume.Gender != "":
+			t.Errorf("Недоступное резюме %s должно сохраниться с данными из выдачи", resume.ID)
+		case resume.ID != "fake00007" && resume.Gender == "":
+			t.Errorf("Резюме %s сохранено без данных полной версии", resume.ID)
+		}
+	}
+}
+
+func TestCollectResumesAndVacancies(t *testing.T) {
+	fake := NewFakeHHServer(25)
+	defer fake.Close()
+	fake.HideDetails("vac00003")
+
+	cfg := fake.Config(t.TempDir())
+	cfg.Search.City = ""
+	cfg.Search.Mode = config.ModeBoth
+	cfg.Search.FetchDetails = true
+
+	if err := app.New(cfg, logger.NewConsole()).Run(); err != nil {
+		t.Fatalf("Ошибка выполнения: %v", err)
+	}
+
+	if resumes := readJSONResumes(t, cfg.Output.File); len(resumes) != 25 {
+		t.Fatalf("Сохранено %d резюме, ожидалось 25", len(resumes))
+	}
+
+	data, err := os.ReadFile(filepath.Join(filepath.Dir(cfg.Output.File), "vacancies.json"))
+	if err != nil {
+		t.Fatalf("Файл вакансий не создан: %v", err)
+	}
+	var vacancies []entities.Vacancy
+	if err := json.Unmarshal(data, &vacancies); err != nil {
+		t.Fatalf("Некорректный JSON вакансий: %v", err)
+	}
+	if len(vacancies) != 25 {
+		t.Fatalf("Сохранено %d вакансий, ожидалось 25", len(vacancies))
+	}
+
+	for _, vacancy := range vacancies {
+		if vacancy.Employer.Name == "" || vacancy.Salary == nil || vacancy.Requirement == "" {
+			t.Errorf("Вакансия %s сохранена без данных выдачи: %+v", vacancy.ID, vacancy)
+		}
+		if strings.Contains(vacancy.Requirement, "<") {
+			t.Errorf("В сниппете вакансии %s осталась разметка: %q", vacancy.ID, vacancy.Requirement)
+		}
+		switch {
+		case vacancy.ID == "vac00003" && vacancy.Description != "":
+			t.Errorf("Недоступная вакансия %s должна сохраниться с данными из выдачи", vacancy.ID)
+		case vacancy.ID != "vac00003" && (len(vacancy.KeySkills) != 3 || strings.Contains(vacancy.Description, "<")):
+			t.Errorf("Вакансия %s сохранена без данных полной версии: %+v", vacancy.ID, vacancy)
+		}
+	}
+
+	// Повторный запуск не загружает сохраненные вакансии заново
+	detailRequests := fake.PathRequests("/vacancies/vac00000")
+	if err := app.New(cfg, logger.NewConsole()).Run(); err != nil {
+		t.Fatalf("Ошибка повторного выполнения: %v", err)
+	}
+	if fake.PathRequests("/vacancies/vac00000") != detailRequests {
+		t.Errorf("Сохраненная вакансия загружена повторно")
+	}
+}
+
+func TestQueryClauses(t *testing.T) {
+	fake := NewFakeHHServer(10)
+	defer fake.Close()
+
+	cfg := fake.Config(t.TempDir())
+	cfg.Search.City = ""
+	cfg.Search.Mode = config.ModeBoth
+	cfg.Search.Keywords = []string{"Go", "Golang"}
+	cfg.Search.KeywordLogic = "any"
+	cfg.Search.TextField = "skills"
+	cfg.Search.Exclude = []string{"Kafka"}
+
+	if err := app.New(cfg, logger.NewConsole()).Run(); err != nil {
+		t.Fatalf("Ошибка выполнения: %v", err)
+	}
+
+	// Исключенное слово отсекает резюме с Kafka
+	resumes := readJSONResumes(t, cfg.Output.File)
+	if len(resumes) != 5 {
+		t.Fatalf("Сохранено %d резюме, ожидалось 5", len(resumes))
+	}
+
+	query := fake.Queries("/resumes")[0]
+	if got := query["text"]; len(got) != 2 || got[0] != "Go Golang" || got[1] != "Kafka" {
+		t.Errorf("Неверные условия запроса резюме: %v", got)
+	}
+	if got := query["text.logic"]; len(got) != 2 || got[0] != "any" || got[1] != "except" {
+		t.Errorf("Неверные text.logic: %v", got)
+	}
+	if got := query["text.field"]; len(got) != 2 || got[0] != "skills" || got[1] != "everywhere" {
+		t.Errorf("Неверные text.field: %v", got)
+	}
+
+	want := "DESCRIPTION:((Go OR Golang)) AND NOT Kafka"
+	if got := fake.Queries("/vacancies")[0].Get("text"); got != want {
+		t.Errorf("Неверный запрос вакансий: %q, ожидалось %q", got, want)
+	}
+}
+
+func TestPerKeywordSearch(t *testing.T) {
+	fake := NewFakeHHServer(10)
+	defer fake.Close()
+
+	cfg := fake.Config(t.TempDir())
+	cfg.Search.City = ""
+	cfg.Search.Keywords = []string{"Kafka", "PostgreSQL"}
+	cfg.Search.KeywordMode = config.KeywordModeEach
+
+	if err := app.New(cfg, logger.NewConsole()).Run(); err != nil {
+		t.Fatalf("Ошибка выполнения: %v", err)
+	}
+
+	resumes := readJSONResumes(t, cfg.Output.File)
+	if len(resumes) != 10 {
+		t.Fatalf("Сохранено %d резюме, ожидалось 10", len(resumes))
+	}
+
+	for _, resume := range resumes {
+		want := "PostgreSQL"
+		if len(resume.Skills) == 3 {
+			want = "Kafka,PostgreSQL"
+		}
+		if got := strings.Join(resume.MatchedKeywords, ","); got != want {
+			t.Errorf("Резюме %s: совпавшие слова %q, ожидалось %q", resume.ID, got, want)
+		}
+	}
+}
+
+func TestResumeSchemaMapping(t *testing.T) {
+	fake := NewFakeHHServer(3)
+	defer fake.Close()
+
+	cfg := fake.Config(t.TempDir())
+	cfg.Search.City = ""
+	cfg.Search.FetchDetails = true
+	cfg.Contacts.Open = true
+
+	if err := app.New(cfg, logger.NewConsole()).Run(); err != nil {
+		t.Fatalf("Ошибка выполнения: %v", err)
+	}
+
+	resumes := readJSONResumes(t, cfg.Output.File)
+	if len(resumes) != 3 {
+		t.Fatalf("Сохранено %d резюме, ожидалось 3", len(resumes))
+	}
+
+	resume := resumes[0]
+	checks := map[string]bool{
+		"дата обновления":       !resume.LastUpdate.IsZero(),
+		"регион":                resume.Location != "" && resume.AreaID != "",
+		"ключевые навыки":       len(resume.Skills) == 2 && resume.Skills[0] == "Go",
+		"блок «Обо мне»":        resume.About != "",
+		"общий стаж":            resume.TotalExperience > 0,
+		"отрасль":               len(resume.Experience) == 1 && resume.Experience[0].Industry != "",
+		"уровень образования":   resume.EducationLevel == "Высшее" && len(resume.Education) == 1,
+		"профессиональные роли": len(resume.ProfessionalRoles) == 1,
+		"языки":                 len(resume.Languages) == 2 && resume.Languages[1].Level != "",
+		"гражданство":           len(resume.Citizenship) == 1 && len(resume.WorkTicket) == 1,
+		"переезд":               resume.Relocation != nil && len(resume.Relocation.Areas) == 1,
+		"занятость и график":    len(resume.Employment) == 1 && len(resume.Schedule) == 1,
+		"командировки":          resume.BusinessTripReadiness != "",
+		"сертификаты":           len(resume.Certificates) == 1,
+		"контакты":              resume.Contact.Phone != "" && resume.Contact.Email != "",
+	}
+	for field, ok := range checks {
+		if !ok {
+			t.Errorf("Поле не заполнено: %s", field)
+		}
+	}
+
+	// Новые поля попадают во все форматы вывода
+	for _, format := range []string{"csv", "sql"} {
+		dir := t.TempDir()
+		cfg.Output.Format = format
+		cfg.Output.File = filepath.Join(dir, "resumes."+format)
+
+		if err := app.New(cfg, logger.NewConsole()).Run(); err != nil {
+			t.Fatalf("Ошибка выполнения в формате %s: %v", format, err)
+		}
+
+		data, err := os.ReadFile(cfg.Output.File)
+		if err != nil {
+			t.Fatalf("Не удалось прочитать файл %s: %v", format, err)
+		}
+		for _, value := range []string{"Английский", "Программист, разработчик", "Go Certified", "Удаленная работа", "Разработка программного обеспечения"} {
+			if !strings.Contains(string(data), value) {
+				t.Errorf("Формат %s не содержит значение %q", format, value)
+			}
+		}
+	}
+}
+
+func TestFilterValidatedAgainstDictionaries(t *testing.T) {
+	fake := NewFakeHHServer(20)
+	defer fake.Close()
+
+	cfg := fake.Config(t.TempDir())
+	cfg.Search.Experience = "between1and3"
+
+	err := app.New(cfg, logger.NewConsole()).Run()
+	if err == nil || !strings.Contains(err.Error(), "between1And3") {
+		t.Fatalf("Ожидалась ошибка с подсказкой between1And3, получено: %v", err)
+	}
+	if fake.PathRequests("/resumes") != 0 {
+		t.Error("Поиск не должен выполняться при недопустимом значении фильтра")
+	}
+
+	// Корректное значение принимается, справочники берутся из кэша
+	cfg.Search.Experience = "between1And3"
+	if err := app.New(cfg, logger.NewConsole()).Run(); err != nil {
+		t.Fatalf("Ошибка выполнения с корректным фильтром: %v", err)
+	}
+	if fake.PathRequests("/dictionaries") != 1 {
+		t.Errorf("Справочники запрошены %d раз, ожидался 1", fake.PathRequests("/dictionaries"))
+	}
+
+	var out strings.Builder
+	if err := app.New(cfg, logger.NewConsole()).PrintDictionaries(&out, []string{"currency", "order_by"}); err != nil {
+		t.Fatalf("Ошибка вывода справочников: %v", err)
+	}
+	for _, value := range []string{"currency:", "USD", "order_by:", "salary_desc"} {
+		if !strings.Contains(out.String(), value) {
+			t.Errorf("Вывод справочников не содержит %q:\n%s", value, out.String())
+		}
+	}
+}
+
+func TestSearchFiltersEncoded(t *testing.T) {
+	fake := NewFakeHHServer(5)
+	defer fake.Close()
+
+	cfg := fake.Config(t.TempDir())
+	cfg.Search.City = ""
+	cfg.Search.Mode = config.ModeBoth
+	cfg.Search.SalaryFrom = 150000
+	cfg.Search.SalaryTo = 300000
+	cfg.Search.Currency = "RUR"
+	cfg.Search.AgeFrom = 25
+	cfg.Search.AgeTo = 45
+	cfg.Search.Gender = "female"
+	cfg.Search.EducationLevel = []string{"higher", "master"}
+	cfg.Search.Employment = []string{"full", "part"}
+	cfg.Search.Schedule = []string{"remote"}
+	cfg.Search.Relocation = "living_or_relocation"
+	cfg.Search.Languages = []string{"eng.b2"}
+	cfg.Search.JobSearchStatus = []string{"active_search", "looking_for_offers"}
+	cfg.Search.OrderBy = "salary_desc"
+
+	if err := app.New(cfg, logger.NewConsole()).Run(); err != nil {
+		t.Fatalf("Ошибка выполнения: %v", err)
+	}
+
+	query := fake.Queries("/resumes")[0]
+	want := map[string]string{
+		"salary_from":       "150000",
+		"salary_to":         "300000",
+		"currency":          "RUR",
+		"age_from":          "25",
+		"age_to":            "45",
+		"gender":            "female",
+		"education_level":   "higher,master",
+		"employment":        "full,part",
+		"schedule":          "remote",
+		"relocation":        "living_or_relocation",
+		"language":          "eng.b2",
+		"job_search_status": "active_search,looking_for_offers",
+		"order_by":          "salary_desc",
+	}
+	for key, value := range want {
+		if got := strings.Join(query[key], ","); got != value {
+			t.Errorf("Параметр %s = %q, ожидалось %q", key, got, value)
+		}
+	}
+
+	// Фильтры соискателя к вакансиям не применяются
+	vacancyQuery := fake.Queries("/vacancies")[0]
+	if vacancyQuery.Get("salary") != "150000" || vacancyQuery.Get("age_from") != "" || vacancyQuery.Get("gender") != "" {
+		t.Errorf("Неверные фильтры вакансий: %v", vacancyQuery)
+	}
+}
+
+func TestSearchFiltersValidation(t *testing.T) {
+	fake := NewFakeHHServer(5)
+	defer fake.Close()
+
+	cases := map[string]func(cfg *config.Config){
+		"зарплата":   func(cfg *config.Config) { cfg.Search.SalaryFrom, cfg.Search.SalaryTo = 300000, 100000 },
+		"возраст":    func(cfg *config.Config) { cfg.Search.AgeFrom = 5 },
+		"занятость":  func(cfg *config.Config) { cfg.Search.Employment = []string{"ful"} },
+		"статус":     func(cfg *config.Config) { cfg.Search.JobSearchStatus = []string{"activ_search"} },
+		"уровень":    func(cfg *config.Config) { cfg.Search.Languages = []string{"eng.b9"} },
+		"без языка":  func(cfg *config.Config) { cfg.Search.Languages = []string{".b2"} },
+		"сортировка": func(cfg *config.Config) { cfg.Search.OrderBy = "salary" },
+		"переезд":    func(cfg *config.Config) { cfg.Search.Relocation = "anywhere" },
+	}
+
+	for name, modify := range cases {
+		cfg := fake.Config(t.TempDir())
+		cfg.Search.City = ""
+		modify(cfg)
+
+		before := fake.PathRequests("/resumes")
+		if err := app.New(cfg, logger.NewConsole()).Run(); err == nil {
+			t.Errorf("%s: недопустимое значение фильтра должно быть ошибкой", name)
+		}
+		if fake.PathRequests("/resumes") != before {
+			t.Errorf("%s: поиск не должен выполняться с недопустимым фильтром", name)
+		}
+	}
+}
+
+func TestUnknownCityIsConfigError(t *testing.T) {
+	fake := NewFakeHHServer(20)
+	defer fake.Close()
+
+	cfg := fake.Config(t.TempDir())
+	cfg.Search.City = "Москва, Атлантида"
+
+	err := app.New(cfg, logger.NewConsole()).Run()
+	if err == nil || !strings.Contains(err.Error(), "Атлантида") {
+		t.Fatalf("Ожидалась ошибка конфигурации для неизвестного города, получено: %v", err)
+	}
+	if fake.PathRequests("/resumes") != 0 {
+		t.Error("Поиск не должен выполняться при неизвестном городе")
+	}
+}
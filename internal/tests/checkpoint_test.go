@@ -0,0 +1,128 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"hh-resume-parser/internal/adapters/storage"
+	"hh-resume-parser/internal/domain/entities"
+	"hh-resume-parser/internal/domain/repositories"
+	"hh-resume-parser/internal/domain/usecases"
+	"hh-resume-parser/internal/infrastructure/logger"
+)
+
+// interruptedRepository - выдача из pages страниц, запрос страницы failAt один раз завершается
+// ошибкой авторизации; запрошенные страницы запоминаются
+type interruptedRepository struct {
+	pages     int
+	failAt    int
+	requested []int
+}
+
+func (r *interruptedRepository) SearchResumes(ctx context.Context, criteria repositories.SearchCriteria) ([]entities.Resume, error) {
+	r.requested = append(r.requested, criteria.Page)
+	if criteria.Page == r.failAt {
+		r.failAt = -1
+		return nil, fmt.Errorf("%w: квота исчерпана", repositories.ErrUnauthorized)
+	}
+	if criteria.Page >= r.pages {
+		return nil, nil
+	}
+
+	resumes := make([]entities.Resume, 0, criteria.PerPage)
+	for i := 0; i < criteria.PerPage; i++ {
+		resumes = append(resumes, entities.Resume{
+			ID:         fmt.Sprintf("page%d-%d", criteria.Page, i),
+			Title:      "Go Developer",
+			Skills:     []string{"Go"},
+			LastUpdate: time.Now(),
+		})
+	}
+	return resumes, nil
+}
+
+func (r *interruptedRepository) GetResumeByID(ctx context.Context, id string) (*entities.Resume, error) {
+	return nil, repositories.ErrNotFound
+}
+
+func TestResumeInterruptedRun(t *testing.T) {
+	const perPage = 5
+	criteria := repositories.SearchCriteria{Keywords: []string{"Go"}, PerPage: perPage}
+
+	for _, format := range []string{"json", "csv", "sql"} {
+		t.Run(format, func(t *testing.T) {
+			dir := t.TempDir()
+			file := filepath.Join(dir, "resumes."+format)
+			checkpointFile := file + ".checkpoint.json"
+			log := logger.NewConsole()
+
+			newUseCase := func(repo repositories.ResumeRepository, resume bool) *usecases.ResumeUseCase {
+				var fileStorage repositories.StorageRepository
+				switch format {
+				case "csv":
+					fileStorage = storage.NewCSVStorage(file, log)
+				case "sql":
+					fileStorage = storage.NewSQLStorage(file, log)
+				default:
+					fileStorage = storage.NewFileStorage(format, file, log)
+				}
+				return usecases.NewResumeUseCase(repo, fileStorage, nil, log,
+					usecases.WithCheckpoint(storage.NewCheckpointStore(checkpointFile, log), resume))
+			}
+
+			// Первый запуск прерывается на третьей странице
+			repo := &interruptedRepository{pages: 4, failAt: 2}
+			if _, err := newUseCase(repo, false).ParseResumesByCriteria(context.Background(), criteria); !errors.Is(err, repositories.ErrUnauthorized) {
+				t.Fatalf("Ожидалось прерывание парсинга, получено %v", err)
+			}
+			if _, err := os.Stat(checkpointFile); err != nil {
+				t.Fatalf("Контрольная точка не сохранена: %v", err)
+			}
+
+			// Другие критерии поиска не могут продолжить чужой запуск
+			other := criteria
+			other.Keywords = []string{"Python"}
+			if _, err := newUseCase(repo, true).ParseResumesByCriteria(context.Background(), other); err == nil ||
+				!strings.Contains(err.Error(), "контрольная точка") {
+				t.Errorf("Контрольная точка для других критериев должна отклоняться, получено %v", err)
+			}
+
+			// Продолжение начинается с непройденной страницы и дописывает резюме в тот же файл
+			repo.requested = nil
+			result, err := newUseCase(repo, true).ParseResumesByCriteria(context.Background(), criteria)
+			if err != nil {
+				t.Fatalf("Ошибка продолжения запуска: %v", err)
+			}
+			if !reflect.DeepEqual(repo.requested, []int{2, 3, 4}) {
+				t.Errorf("При продолжении запрошены страницы %v, ожидались [2 3 4]", repo.requested)
+			}
+			if result.SavedCount != 2*perPage {
+				t.Errorf("При продолжении сохранено %d резюме, ожидалось %d", result.SavedCount, 2*perPage)
+			}
+			if saved := countSaved(t, format, file); saved != 4*perPage {
+				t.Errorf("В файле %d резюме, ожидалось %d", saved, 4*perPage)
+			}
+			if format == "json" {
+				seen := make(map[string]bool)
+				for _, resume := range readJSONResumes(t, file) {
+					if seen[resume.ID] {
+						t.Errorf("Резюме %s сохранено дважды", resume.ID)
+					}
+					seen[resume.ID] = true
+				}
+			}
+
+			// Завершенный запуск удаляет контрольную точку
+			if _, err := os.Stat(checkpointFile); !os.IsNotExist(err) {
+				t.Errorf("Контрольная точка должна удаляться после завершения запуска: %v", err)
+			}
+		})
+	}
+}
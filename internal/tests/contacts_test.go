@@ -0,0 +1,131 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"hh-resume-parser/internal/app"
+	"hh-resume-parser/internal/config"
+	"hh-resume-parser/internal/domain/entities"
+	"hh-resume-parser/internal/infrastructure/logger"
+)
+
+// contactsConfig возвращает конфигурацию загрузки полных резюме с бюджетом открытий контактов
+func contactsConfig(fake *FakeHHServer, dir string, daily, monthly int, onExhausted string) *config.Config {
+	cfg := fake.Config(dir)
+	cfg.Search.City = ""
+	cfg.Search.FetchDetails = true
+	cfg.Search.DetailWorkers = 4
+	cfg.Contacts.Open = true
+	cfg.Contacts.DailyLimit = daily
+	cfg.Contacts.MonthlyLimit = monthly
+	cfg.Contacts.OnExhausted = onExhausted
+	return cfg
+}
+
+func TestContactBudgetDowngradesDetails(t *testing.T) {
+	fake := NewFakeHHServer(20)
+	defer fake.Close()
+
+	cfg := contactsConfig(fake, t.TempDir(), 5, 0, config.ContactsDowngrade)
+	if err := app.New(cfg, logger.NewConsole()).Run(); err != nil {
+		t.Fatalf("Ошибка выполнения: %v", err)
+	}
+
+	if fake.ContactOpenings() != 5 {
+		t.Errorf("Открыто %d контактов при бюджете 5", fake.ContactOpenings())
+	}
+
+	// Сверх бюджета полные резюме загружаются без контактов
+	withContacts := 0
+	for _, resume := range readJSONResumes(t, cfg.Output.File) {
+		if resume.Gender == "" {
+			t.Errorf("Резюме %s сохранено без данных полной версии", resume.ID)
+		}
+		if resume.Contact.Phone != "" {
+			withContacts++
+		}
+	}
+	if withContacts != 5 {
+		t.Errorf("Сохранено %d резюме с контактами, ожидалось 5", withContacts)
+	}
+
+	var out bytes.Buffer
+	if err := app.New(cfg, logger.NewConsole()).PrintContactReport(&out, ""); err != nil {
+		t.Fatalf("Ошибка отчета: %v", err)
+	}
+	if !strings.Contains(out.String(), "Итого за месяц: 5") || !strings.Contains(out.String(), "5 в сутки") {
+		t.Errorf("Отчет об открытиях неверен:\n%s", out.String())
+	}
+}
+
+func TestContactBudgetBlocksDetails(t *testing.T) {
+	fake := NewFakeHHServer(10)
+	defer fake.Close()
+
+	dir := t.TempDir()
+	cfg := contactsConfig(fake, dir, 0, 3, config.ContactsBlock)
+
+	// Одно открытие в этом месяце уже сделано предыдущим запуском
+	ledger, _ := json.Marshal([]entities.ContactOpening{
+		{ResumeID: "fake00004", OpenedAt: time.Now()},
+		{ResumeID: "old", OpenedAt: time.Now().AddDate(0, -2, 0)},
+	})
+	if err := os.WriteFile(cfg.Contacts.LedgerFile, ledger, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.New(cfg, logger.NewConsole()).Run(); err != nil {
+		t.Fatalf("Ошибка выполнения: %v", err)
+	}
+
+	// Уже открытые контакты бюджет не расходуют
+	if fake.ContactOpenings() != 3 {
+		t.Errorf("Запрошено %d резюме с контактами, ожидалось 3 (2 новых и 1 уже открытое)", fake.ContactOpenings())
+	}
+
+	detailed := 0
+	for _, resume := range readJSONResumes(t, cfg.Output.File) {
+		if resume.Gender != "" {
+			detailed++
+		}
+	}
+	if detailed != 3 {
+		t.Errorf("Полная версия загружена для %d резюме, ожидалось 3", detailed)
+	}
+
+	var openings []entities.ContactOpening
+	data, _ := os.ReadFile(cfg.Contacts.LedgerFile)
+	if err := json.Unmarshal(data, &openings); err != nil || len(openings) != 4 {
+		t.Errorf("В журнале %d открытий, ожидалось 4: %v", len(openings), err)
+	}
+}
+
+func TestDetailsWithoutContacts(t *testing.T) {
+	fake := NewFakeHHServer(10)
+	defer fake.Close()
+
+	// Без явного -contacts полные резюме загружаются без открытия контактов
+	cfg := fake.Config(t.TempDir())
+	cfg.Search.City = ""
+	cfg.Search.FetchDetails = true
+	if err := app.New(cfg, logger.NewConsole()).Run(); err != nil {
+		t.Fatalf("Ошибка выполнения: %v", err)
+	}
+
+	if fake.ContactOpenings() != 0 {
+		t.Errorf("Контакты открыты %d раз при отключенном открытии", fake.ContactOpenings())
+	}
+	for _, resume := range readJSONResumes(t, cfg.Output.File) {
+		if resume.Gender == "" || resume.Contact.Phone != "" {
+			t.Errorf("Резюме %s должно быть загружено полностью, но без контактов", resume.ID)
+		}
+	}
+	if _, err := os.Stat(cfg.Contacts.LedgerFile); !os.IsNotExist(err) {
+		t.Errorf("Журнал открытий не должен создаваться")
+	}
+}
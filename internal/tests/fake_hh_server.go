@@ -0,0 +1,701 @@
+package tests
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"hh-resume-parser/internal/config"
+)
+
+// FakeHHToken - токен, который принимает тестовый сервер
+const FakeHHToken = "test-token"
+
+// fakeHHTimeLayout - формат дат в ответах API hh.ru
+const fakeHHTimeLayout = "2006-01-02T15:04:05-0700"
+
+// FakeHHServer имитирует API hh.ru для тестов без доступа к сети
+// Поддерживает поиск и получение резюме и вакансий, дерево регионов, лимит глубины выдачи
+// и внедрение временных ошибок
+type FakeHHServer struct {
+	Server     *httptest.Server
+	DepthLimit int // Лимит глубины выдачи (0 - без лимита)
+	MaxAge     int // max-age полных резюме и вакансий в секундах (0 - без Cache-Control)
+
+	resumes     []map[string]interface{} // Резюме в формате API
+	vacancies   []map[string]interface{} // Вакансии в формате API
+	requests    atomic.Int32             // Количество запросов к API
+	notModified atomic.Int32             // Количество ответов 304
+	openings    atomic.Int32             // Количество открытий контактов (with_contact=true)
+
+	mu       sync.Mutex
+	failures []fakeFailure           // Ошибки, которые вернутся на ближайшие запросы
+	stalls   []time.Duration         // Задержки ответа на ближайшие запросы
+	paths    map[string]int          // Количество запросов по путям
+	queries  map[string][]url.Values // Параметры запросов по путям
+	hidden   map[string]bool         // Резюме и вакансии, полная версия которых недоступна
+
+	invitations []url.Values // Параметры отправленных приглашений
+
+	tokens map[string]*fakeToken // Принимаемые токены
+}
+
+// fakeToken - токен аккаунта на тестовом сервере
+type fakeToken struct {
+	quota    int  // Количество запросов до ответа 429 (0 - без ограничения)
+	requests int  // Количество принятых запросов
+	revoked  bool // Токен отозван
+}
+
+// fakeFailure - внедренная ошибка тестового сервера
+type fakeFailure struct {
+	status     int    // HTTP статус
+	errType    string // Тип ошибки в теле ответа
+	retryAfter string // Заголовок Retry-After (может отсутствовать)
+}
+
+// NewFakeHHServer создает тестовый TLS сервер с указанным количеством резюме и вакансий
+// Записи распределены по опыту работы, двум городам и времени обновления
+func NewFakeHHServer(total int) *FakeHHServer {
+	f := &FakeHHServer{
+		paths:   make(map[string]int),
+		queries: make(map[string][]url.Values),
+		hidden:  make(map[string]bool),
+		tokens:  map[string]*fakeToken{FakeHHToken: {}},
+	}
+
+	experience := []string{"noExperience", "between1And3", "between3And6", "moreThan6"}
+	now := time.Now()
+
+	for i := 0; i < total; i++ {
+		area := map[string]string{"id": "1", "name": "Москва"}
+		if i%3 == 0 {
+			area = map[string]string{"id": "2", "name": "Санкт-Петербург"}
+		}
+
+		// У каждого второго соискателя есть Kafka
+		skills := []string{"Go", "PostgreSQL"}
+		if i%2 == 1 {
+			skills = append(skills, "Kafka")
+		}
+
+		f.resumes = append(f.resumes, map[string]interface{}{
+			"id":               fmt.Sprintf("fake%05d", i),
+			"title":            "Go Developer",
+			"first_name":       "Иван",
+			"last_name":        fmt.Sprintf("Тестов-%d", i),
+			"updated_at":       now.Add(-time.Duration(i) * 10 * time.Minute).Format(fakeHHTimeLayout),
+			"url":              fmt.Sprintf("https://hh.ru/resume/fake%05d", i),
+			"age":              25 + i%20,
+			"area":             area,
+			"skill_set":        skills,
+			"skills":           "Разрабатываю backend сервисы",
+			"total_experience": map[string]int{"months": 12 + i%60},
+			"experience": []map[string]interface{}{{
+				"company":     "Tech Corp",
+				"position":    "Backend Developer",
+				"start":       "2020-01-01",
+				"end":         nil,
+				"description": "Разработка сервисов на Go",
+				"industries":  []map[string]string{{"id": "7.540", "name": "Разработка программного обеспечения"}},
+			}},
+			"education": map[string]interface{}{
+				"level": map[string]string{"id": "higher", "name": "Высшее"},
+				"primary": []map[string]interface{}{{
+					"name": "МГТУ им. Баумана", "organization": "ИУ", "result": "Программная инженерия", "year": 2015,
+				}},
+			},
+			"experience_bucket": experience[i%len(experience)],
+		})
+
+		salaryFrom := 100000 + i*1000
+		f.vacancies = append(f.vacancies, map[string]interface{}{
+			"id":            fmt.Sprintf("vac%05d", i),
+			"name":          "Go Developer",
+			"area":          area,
+			"alternate_url": fmt.Sprintf("https://hh.ru/vacancy/vac%05d", i),
+			"published_at":  now.Add(-time.Duration(i) * 10 * time.Minute).Format(fakeHHTimeLayout),
+			"updated_at":    now.Add(-time.Duration(i) * 10 * time.Minute).Format(fakeHHTimeLayout),
+			"archived":      false,
+			"employer": map[string]string{
+				"id": fmt.Sprintf("%d", 1000+i%5), "name": fmt.Sprintf("Employer %d", i%5), "alternate_url": "https://hh.ru/employer/1000",
+			},
+			"salary": map[string]interface{}{"from": salaryFrom, "to": nil, "currency": "RUR", "gross": true},
+			"snippet": map[string]string{
+				"requirement":    "Опыт разработки на <highlighttext>Go</highlighttext> от 3 лет",
+				"responsibility": "Разработка микросервисов",
+			},
+			"experience":         map[string]string{"id": experience[i%len(experience)], "name": experience[i%len(experience)]},
+			"schedule":           map[string]string{"id": "remote", "name": "Удаленная работа"},
+			"employment":         map[string]string{"id": "full", "name": "Полная занятость"},
+			"professional_roles": []map[string]string{{"id": "96", "name": "Программист, разработчик"}},
+			"experience_bucket":  experience[i%len(experience)],
+		})
+	}
+
+	f.Server = httptest.NewTLSServer(http.HandlerFunc(f.handle))
+	return f
+}
+
+// Close останавливает сервер
+func (f *FakeHHServer) Close() {
+	f.Server.Close()
+}
+
+// Requests возвращает количество запросов к серверу
+func (f *FakeHHServer) Requests() int {
+	return int(f.requests.Load())
+}
+
+// NotModified возвращает количество ответов 304 на условные запросы
+func (f *FakeHHServer) NotModified() int {
+	return int(f.notModified.Load())
+}
+
+// ContactOpenings возвращает количество запросов полных резюме с открытием контактов
+func (f *FakeHHServer) ContactOpenings() int {
+	return int(f.openings.Load())
+}
+
+// PathRequests возвращает количество запросов по пути
+func (f *FakeHHServer) PathRequests(path string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.paths[path]
+}
+
+// Queries возвращает параметры запросов по пути
+func (f *FakeHHServer) Queries(path string) []url.Values {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]url.Values(nil), f.queries[path]...)
+}
+
+// Invitations возвращает параметры принятых приглашений
+func (f *FakeHHServer) Invitations() []url.Values {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]url.Values(nil), f.invitations...)
+}
+
+// AddToken добавляет принимаемый токен; после quota запросов сервер отвечает 429 (0 - без ограничения)
+func (f *FakeHHServer) AddToken(token string, quota int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.tokens[token] = &fakeToken{quota: quota}
+}
+
+// RevokeToken отзывает токен: запросы с ним получают 403 bad_authorization
+func (f *FakeHHServer) RevokeToken(token string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if t, ok := f.tokens[token]; ok {
+		t.revoked = true
+	}
+}
+
+// TokenRequests возвращает количество принятых запросов с токеном
+func (f *FakeHHServer) TokenRequests(token string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if t, ok := f.tokens[token]; ok {
+		return t.requests
+	}
+	return 0
+}
+
+// FailNext заставляет сервер вернуть указанные статусы на ближайшие запросы
+func (f *FakeHHServer) FailNext(statuses ...int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, status := range statuses {
+		f.failures = append(f.failures, fakeFailure{status: status, errType: "server_error"})
+	}
+}
+
+// FailNextWith заставляет сервер вернуть на ближайший запрос ошибку указанного типа
+// и заголовок Retry-After, если он не пуст
+func (f *FakeHHServer) FailNextWith(status int, errType, retryAfter string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failures = append(f.failures, fakeFailure{status: status, errType: errType, retryAfter: retryAfter})
+}
+
+// StallNext задерживает ответы на ближайшие запросы на указанное время
+// Запрос, отмененный клиентом раньше, завершается без ответа
+func (f *FakeHHServer) StallNext(delays ...time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.stalls = append(f.stalls, delays...)
+}
+
+// HideDetails делает полную версию резюме недоступной (404), оставляя их в выдаче поиска
+func (f *FakeHHServer) HideDetails(ids ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, id := range ids {
+		f.hidden[id] = true
+	}
+}
+
+// SetUpdated меняет дату обновления резюме; вызывается до запросов к серверу
+func (f *FakeHHServer) SetUpdated(id string, updated time.Time) {
+	for _, resume := range f.resumes {
+		if resume["id"] == id {
+			resume["updated_at"] = updated.Format(fakeHHTimeLayout)
+		}
+	}
+}
+
+// Config возвращает конфигурацию приложения, настроенную на тестовый сервер
+// Все файлы создаются в каталоге dir
+func (f *FakeHHServer) Config(dir string) *config.Config {
+	cfg := config.GetDefaultConfig()
+	cfg.API.Token = FakeHHToken
+	cfg.API.BaseURL = f.Server.URL
+	cfg.API.Transport = f.Server.Client().Transport
+	cfg.API.RateLimit = 0
+	cfg.API.RetryBaseDelay = 10 * time.Millisecond
+	cfg.API.RetryMaxDelay = 50 * time.Millisecond
+	cfg.Search.Keywords = []string{"Go"}
+	cfg.Cache.Dir = filepath.Join(dir, "cache")
+	cfg.Output.File = filepath.Join(dir, "resumes.json")
+	cfg.Invite.LedgerFile = filepath.Join(dir, "invitations.json")
+	cfg.Contacts.LedgerFile = filepath.Join(dir, "contacts.json")
+	cfg.LogFile = filepath.Join(dir, "parser.log")
+	return cfg
+}
+
+// handle обрабатывает запросы к API
+func (f *FakeHHServer) handle(w http.ResponseWriter, r *http.Request) {
+	f.requests.Add(1)
+
+	f.mu.Lock()
+	f.paths[r.URL.Path]++
+	f.queries[r.URL.Path] = append(f.queries[r.URL.Path], r.URL.Query())
+	var failure fakeFailure
+	if len(f.failures) > 0 {
+		failure, f.failures = f.failures[0], f.failures[1:]
+	}
+	var stall time.Duration
+	if len(f.stalls) > 0 {
+		stall, f.stalls = f.stalls[0], f.stalls[1:]
+	}
+	f.mu.Unlock()
+
+	if stall > 0 {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-time.After(stall):
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if failure.status != 0 {
+		if failure.retryAfter != "" {
+			w.Header().Set("Retry-After", failure.retryAfter)
+		}
+		writeFakeError(w, failure.status, failure.errType, "")
+		return
+	}
+
+	// Дерево регионов и справочники доступны без авторизации, как и в настоящем API
+	switch r.URL.Path {
+	case "/areas":
+		json.NewEncoder(w).Encode(fakeAreas)
+		return
+	case "/dictionaries":
+		json.NewEncoder(w).Encode(fakeDictionaries)
+		return
+	}
+
+	if !f.authorize(w, r) {
+		return
+	}
+
+	switch {
+	case r.URL.Path == "/resumes":
+		f.handleSearch(w, r, f.filterResumeText(r.URL.Query()))
+	case strings.HasPrefix(r.URL.Path, "/resumes/"):
+		f.handleResume(w, r, strings.TrimPrefix(r.URL.Path, "/resumes/"))
+	case r.URL.Path == "/vacancies":
+		f.handleSearch(w, r, f.vacancies)
+	case strings.HasPrefix(r.URL.Path, "/vacancies/"):
+		f.handleVacancy(w, r, strings.TrimPrefix(r.URL.Path, "/vacancies/"))
+	case r.URL.Path == "/negotiations/phone_interview" && r.Method == http.MethodPost:
+		f.handleInvite(w, r)
+	default:
+		writeFakeError(w, http.StatusNotFound, "not_found", "")
+	}
+}
+
+// authorize проверяет токен запроса и учитывает его квоту
+// Если запрос отклонен, ошибка уже записана в ответ
+func (f *FakeHHServer) authorize(w http.ResponseWriter, r *http.Request) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	token, ok := f.tokens[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")]
+	switch {
+	case !ok || token.revoked:
+		writeFakeError(w, http.StatusForbidden, "oauth", "bad_authorization")
+		return false
+	case token.quota > 0 && token.requests >= token.quota:
+		writeFakeError(w, http.StatusTooManyRequests, "too_many_requests", "")
+		return false
+	}
+
+	token.requests++
+	return true
+}
+
+// handleSearch обрабатывает поиск резюме или вакансий с фильтрами area, experience, period и date_from/date_to
+func (f *FakeHHServer) handleSearch(w http.ResponseWriter, r *http.Request, items []map[string]interface{}) {
+	query := r.URL.Query()
+	page, _ := strconv.Atoi(query.Get("page"))
+	perPage, _ := strconv.Atoi(query.Get("per_page"))
+	if perPage <= 0 {
+		perPage = 20
+	}
+
+	if f.DepthLimit > 0 && page*perPage+perPage > f.DepthLimit {
+		writeFakeError(w, http.StatusBadRequest, "bad_argument", "page")
+		return
+	}
+
+	areas := query["area"]
+	var from, to time.Time
+	if value := query.Get("date_from"); value != "" {
+		from, _ = time.Parse(fakeHHTimeLayout, value)
+	}
+	if value := query.Get("date_to"); value != "" {
+		to, _ = time.Parse(fakeHHTimeLayout, value)
+	}
+	if days, _ := strconv.Atoi(query.Get("period")); days > 0 {
+		from = time.Now().Add(-time.Duration(days) * 24 * time.Hour)
+	}
+
+	var found []map[string]interface{}
+	for _, item := range items {
+		if experience := query.Get("experience"); experience != "" && item["experience_bucket"] != experience {
+			continue
+		}
+		if len(areas) > 0 && !containsString(areas, item["area"].(map[string]string)["id"]) {
+			continue
+		}
+		updated, _ := time.Parse(fakeHHTimeLayout, item["updated_at"].(string))
+		if !from.IsZero() && updated.Before(from) {
+			continue
+		}
+		if !to.IsZero() && updated.After(to) {
+			continue
+		}
+		found = append(found, item)
+	}
+
+	start := page * perPage
+	end := start + perPage
+	if start > len(found) {
+		start = len(found)
+	}
+	if end > len(found) {
+		end = len(found)
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"items":    found[start:end],
+		"found":    len(found),
+		"pages":    (len(found) + perPage - 1) / perPage,
+		"page":     page,
+		"per_page": perPage,
+	})
+}
+
+// filterResumeText отбирает резюме по text и text.logic
+// Слова ищутся в заголовке и ключевых навыках без учета регистра; язык запросов не поддерживается
+func (f *FakeHHServer) filterResumeText(query url.Values) []map[string]interface{} {
+	texts, logics := query["text"], query["text.logic"]
+
+	var found []map[string]interface{}
+	for _, resume := range f.resumes {
+		words := strings.Fields(strings.ToLower(resume["title"].(string)))
+		for _, skill := range resume["skill_set"].([]string) {
+			words = append(words, strings.ToLower(skill))
+		}
+		content := strings.Join(words, " ")
+
+		matched := true
+		for i, text := range texts {
+			logic := "all"
+			if i < len(logics) {
+				logic = logics[i]
+			}
+
+			terms := strings.Fields(strings.ToLower(text))
+			hits := 0
+			for _, term := range terms {
+				if containsString(words, term) {
+					hits++
+				}
+			}
+
+			switch logic {
+			case "any":
+				matched = matched && hits > 0
+			case "except":
+				matched = matched && hits == 0
+			case "phrase":
+				matched = matched && strings.Contains(content, strings.ToLower(text))
+			default:
+				matched = matched && hits == len(terms)
+			}
+		}
+
+		if matched {
+			found = append(found, resume)
+		}
+	}
+	return found
+}
+
+// handleResume возвращает полное резюме по идентификатору
+// В отличие от выдачи поиска полная версия содержит пол, языки, занятость и другие поля
+func (f *FakeHHServer) handleResume(w http.ResponseWriter, r *http.Request, id string) {
+	f.mu.Lock()
+	hidden := f.hidden[id]
+	f.mu.Unlock()
+
+	for _, resume := range f.resumes {
+		if resume["id"] == id && !hidden {
+			detail := make(map[string]interface{}, len(resume)+1)
+			for key, value := range resume {
+				detail[key] = value
+			}
+			detail["gender"] = map[string]string{"id": "male", "name": "Мужской"}
+			detail["language"] = []map[string]interface{}{
+				{"id": "rus", "name": "Русский", "level": map[string]string{"id": "l1", "name": "Родной"}},
+				{"id": "eng", "name": "Английский", "level": map[string]string{"id": "b2", "name": "B2 — Средне-продвинутый"}},
+			}
+			detail["professional_roles"] = []map[string]string{{"id": "96", "name": "Программист, разработчик"}}
+			detail["citizenship"] = []map[string]string{{"id": "113", "name": "Россия"}}
+			detail["work_ticket"] = []map[string]string{{"id": "113", "name": "Россия"}}
+			detail["relocation"] = map[string]interface{}{
+				"type": map[string]string{"id": "relocation_possible", "name": "могу переехать"},
+				"area": []map[string]string{{"id": "2", "name": "Санкт-Петербург"}},
+			}
+			detail["employments"] = []map[string]string{{"id": "full", "name": "Полная занятость"}}
+			detail["schedules"] = []map[string]string{{"id": "remote", "name": "Удаленная работа"}}
+			detail["business_trip_readiness"] = map[string]string{"id": "ready", "name": "готов к командировкам"}
+			detail["certificate"] = []map[string]string{{"title": "Go Certified", "type": "custom", "achieved_at": "2021-05-01"}}
+			// Контакты отдаются только при явном открытии, как и в настоящем API
+			if r.URL.Query().Get("with_contact") == "true" {
+				f.openings.Add(1)
+				detail["contact"] = []map[string]interface{}{
+					{"type": map[string]string{"id": "cell"}, "value": map[string]string{"formatted": "+7 (999) 123-45-67"}, "preferred": true},
+					{"type": map[string]string{"id": "email"}, "value": "ivan@example.com"},
+				}
+			}
+
+			f.writeCacheable(w, r, detail)
+			return
+		}
+	}
+	writeFakeError(w, http.StatusNotFound, "not_found", "")
+}
+
+// handleVacancy возвращает полную вакансию по идентификатору
+// Описание и ключевые навыки есть только в полной версии, сниппета в ней нет
+func (f *FakeHHServer) handleVacancy(w http.ResponseWriter, r *http.Request, id string) {
+	f.mu.Lock()
+	hidden := f.hidden[id]
+	f.mu.Unlock()
+
+	for _, vacancy := range f.vacancies {
+		if vacancy["id"] == id && !hidden {
+			detail := make(map[string]interface{}, len(vacancy)+1)
+			for key, value := range vacancy {
+				if key != "snippet" {
+					detail[key] = value
+				}
+			}
+			detail["description"] = "<p>Ищем <strong>Go</strong> разработчика&nbsp;в команду платежей.</p><ul><li>Kafka</li></ul>"
+			detail["key_skills"] = []map[string]string{{"name": "Go"}, {"name": "Kafka"}, {"name": "PostgreSQL"}}
+
+			f.writeCacheable(w, r, detail)
+			return
+		}
+	}
+	writeFakeError(w, http.StatusNotFound, "not_found", "")
+}
+
+// handleInvite создает приглашение соискателя на вакансию
+// Повторное приглашение того же резюме на ту же вакансию отклоняется, как и в настоящем API
+func (f *FakeHHServer) handleInvite(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeFakeError(w, http.StatusBadRequest, "bad_argument", "body")
+		return
+	}
+	vacancyID, resumeID := r.PostForm.Get("vacancy_id"), r.PostForm.Get("resume_id")
+
+	known := false
+	for _, resume := range f.resumes {
+		if resume["id"] == resumeID {
+			known = true
+		}
+	}
+	if !known {
+		writeFakeError(w, http.StatusForbidden, "negotiations", "resume_not_found")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, invitation := range f.invitations {
+		if invitation.Get("vacancy_id") == vacancyID && invitation.Get("resume_id") == resumeID {
+			writeFakeError(w, http.StatusForbidden, "negotiations", "already_applied")
+			return
+		}
+	}
+
+	f.invitations = append(f.invitations, r.PostForm)
+	w.Header().Set("Location", fmt.Sprintf("/negotiations/%d", len(f.invitations)))
+	w.WriteHeader(http.StatusCreated)
+}
+
+// writeCacheable записывает ответ с ETag и отвечает 304 на условный запрос с тем же ETag
+func (f *FakeHHServer) writeCacheable(w http.ResponseWriter, r *http.Request, payload interface{}) {
+	body, _ := json.Marshal(payload)
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+
+	w.Header().Set("ETag", etag)
+	if f.MaxAge > 0 {
+		w.Header().Set("Cache-Control", fmt.Sprintf("private, max-age=%d", f.MaxAge))
+	}
+
+	if r.Header.Get("If-None-Match") == etag {
+		f.notModified.Add(1)
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Write(body)
+}
+
+// writeFakeError записывает ошибку в формате API hh.ru
+func writeFakeError(w http.ResponseWriter, status int, errType, value string) {
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"errors":     []map[string]string{{"type": errType, "value": value}},
+		"request_id": "fake-request",
+	})
+}
+
+func containsString(items []string, value string) bool {
+	for _, item := range items {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// fakeDictionaries - фрагмент справочников hh.ru
+var fakeDictionaries = map[string]interface{}{
+	"experience": []map[string]string{
+		{"id": "noExperience", "name": "Нет опыта"},
+		{"id": "between1And3", "name": "От 1 года до 3 лет"},
+		{"id": "between3And6", "name": "От 3 до 6 лет"},
+		{"id": "moreThan6", "name": "Более 6 лет"},
+	},
+	"employment": []map[string]string{
+		{"id": "full", "name": "Полная занятость"},
+		{"id": "part", "name": "Частичная занятость"},
+		{"id": "project", "name": "Проектная работа"},
+	},
+	"schedule": []map[string]string{
+		{"id": "fullDay", "name": "Полный день"},
+		{"id": "remote", "name": "Удаленная работа"},
+	},
+	"education_level": []map[string]string{
+		{"id": "secondary", "name": "Среднее"},
+		{"id": "higher", "name": "Высшее"},
+		{"id": "bachelor", "name": "Бакалавр"},
+		{"id": "master", "name": "Магистр"},
+	},
+	"currency": []map[string]interface{}{
+		{"code": "RUR", "abbr": "₽", "name": "Рубли", "default": true, "rate": 1},
+		{"code": "USD", "abbr": "$", "name": "Доллары", "default": false, "rate": 0.011},
+		{"code": "EUR", "abbr": "€", "name": "Евро", "default": false, "rate": 0.01},
+	},
+	"gender": []map[string]string{
+		{"id": "male", "name": "Мужской"},
+		{"id": "female", "name": "Женский"},
+	},
+	"resume_search_order": []map[string]string{
+		{"id": "publication_time", "name": "по дате изменения"},
+		{"id": "salary_desc", "name": "по убыванию зарплаты"},
+		{"id": "salary_asc", "name": "по возрастанию зарплаты"},
+		{"id": "relevance", "name": "по соответствию"},
+	},
+	"resume_search_fields": []map[string]string{
+		{"id": "everywhere", "name": "Везде"},
+		{"id": "title", "name": "В названии резюме"},
+		{"id": "skills", "name": "В ключевых навыках"},
+		{"id": "experience", "name": "В опыте работы"},
+	},
+	"resume_search_relocation": []map[string]string{
+		{"id": "living", "name": "живут в указанном регионе"},
+		{"id": "living_but_relocation", "name": "живут в указанном регионе и готовы к переезду"},
+		{"id": "relocation", "name": "живут в другом регионе и готовы к переезду"},
+		{"id": "living_or_relocation", "name": "живут в указанном регионе или готовы к переезду в него"},
+	},
+	"job_search_statuses": []map[string]string{
+		{"id": "active_search", "name": "Активно ищет работу"},
+		{"id": "looking_for_offers", "name": "Рассматривает предложения"},
+		{"id": "not_looking_for_job", "name": "Не ищет работу"},
+	},
+	"language_level": []map[string]string{
+		{"id": "a1", "name": "A1 — Начальный"},
+		{"id": "b2", "name": "B2 — Средне-продвинутый"},
+		{"id": "c1", "name": "C1 — Продвинутый"},
+		{"id": "l1", "name": "Родной"},
+	},
+	"resume_search_experience_period": []map[string]string{
+		{"id": "all_time", "name": "За все время"},
+		{"id": "last_year", "name": "За последний год"},
+		{"id": "last_three_years", "name": "За последние три года"},
+	},
+}
+
+// fakeAreas - фрагмент дерева регионов hh.ru
+var fakeAreas = []map[string]interface{}{
+	{
+		"id": "113", "parent_id": nil, "name": "Россия",
+		"areas": []map[string]interface{}{
+			{"id": "1", "parent_id": "113", "name": "Москва", "areas": []interface{}{}},
+			{"id": "2", "parent_id": "113", "name": "Санкт-Петербург", "areas": []interface{}{}},
+			{"id": "1255", "parent_id": "113", "name": "Томская область", "areas": []map[string]interface{}{
+				{"id": "90", "parent_id": "1255", "name": "Томск", "areas": []interface{}{}},
+			}},
+		},
+	},
+	{
+		"id": "16", "parent_id": nil, "name": "Беларусь",
+		"areas": []map[string]interface{}{
+			{"id": "1002", "parent_id": "16", "name": "Минск", "areas": []interface{}{}},
+		},
+	},
+}
@@ -0,0 +1,139 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"hh-resume-parser/internal/adapters/storage"
+	"hh-resume-parser/internal/app"
+	"hh-resume-parser/internal/config"
+	"hh-resume-parser/internal/domain/entities"
+	"hh-resume-parser/internal/domain/repositories"
+	"hh-resume-parser/internal/domain/usecases"
+	"hh-resume-parser/internal/infrastructure/logger"
+)
+
+// listRepository - выдача из заданного списка резюме на одной странице
+type listRepository struct {
+	resumes []entities.Resume
+}
+
+func (r *listRepository) SearchResumes(ctx context.Context, criteria repositories.SearchCriteria) ([]entities.Resume, error) {
+	if criteria.Page > 0 {
+		return nil, nil
+	}
+	return r.resumes, nil
+}
+
+func (r *listRepository) GetResumeByID(ctx context.Context, id string) (*entities.Resume, error) {
+	return nil, repositories.ErrNotFound
+}
+
+func TestResumeUpdatesKeepVersionHistory(t *testing.T) {
+	dir := t.TempDir()
+	output := filepath.Join(dir, "resumes.json")
+	historyFile := output + ".history.jsonl"
+	log := logger.NewConsole()
+
+	updated := time.Now().Add(-48 * time.Hour).Truncate(time.Second)
+	resume := func(id string, salary int, skills ...string) entities.Resume {
+		return entities.Resume{
+			ID:         id,
+			Title:      "Go Developer",
+			Skills:     skills,
+			Salary:     &entities.Salary{Amount: salary, Currency: "RUR"},
+			LastUpdate: updated,
+		}
+	}
+
+	run := func(resumes ...entities.Resume) *usecases.ParseResult {
+		t.Helper()
+		useCase := usecases.NewResumeUseCase(&listRepository{resumes: resumes}, storage.NewFileStorage("json", output, log), nil, log,
+			usecases.WithResumeHistory(storage.NewResumeHistory(historyFile, log)))
+		result, err := useCase.ParseResumesByCriteria(context.Background(), repositories.SearchCriteria{
+			Keywords: []string{"Go"},
+			PerPage:  repositories.DefaultPerPage,
+		})
+		if err != nil {
+			t.Fatalf("Ошибка парсинга: %v", err)
+		}
+		return result
+	}
+
+	run(resume("a1", 250000, "Go"), resume("b1", 200000, "Go"))
+
+	// Соискатель a1 добавил навык и поднял зарплату, b1 только поднял резюме в выдаче
+	updated = updated.Add(24 * time.Hour)
+	result := run(resume("a1", 300000, "Go", "Kubernetes"), resume("b1", 200000, "Go"), resume("c1", 150000, "Go"))
+	if result.SavedCount != 2 || result.UpdatedCount != 1 {
+		t.Errorf("Сохранено %d резюме, из них обновленных %d; ожидалось 2 и 1", result.SavedCount, result.UpdatedCount)
+	}
+
+	saved := readJSONResumes(t, output)
+	if len(saved) != 2 || saved[0].ID != "a1" || saved[1].ID != "c1" {
+		t.Fatalf("Повторно сохранены неверные резюме: %+v", saved)
+	}
+	var changes []string
+	for _, change := range saved[0].Changes {
+		changes = append(changes, change.String())
+	}
+	want := []string{"salary: 250000 RUR → 300000 RUR", "skills: +Kubernetes"}
+	if strings.Join(changes, "; ") != strings.Join(want, "; ") {
+		t.Errorf("Изменения резюме %v, ожидались %v", changes, want)
+	}
+
+	history := storage.NewResumeHistory(historyFile, log)
+	versions, err := history.Versions(context.Background(), "a1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 2 || versions[0].Resume.Salary.Amount != 250000 || versions[1].Version != 2 {
+		t.Errorf("Прежняя версия не сохранена в истории: %+v", versions)
+	}
+	versions, _ = history.Versions(context.Background(), "b1")
+	if len(versions) != 1 || !versions[0].LastUpdate.Equal(updated) {
+		t.Errorf("Резюме без изменений содержимого не должно получать новую версию: %+v", versions)
+	}
+
+	// Обновление уже учтено и повторно не сохраняется
+	if result := run(resume("a1", 300000, "Go", "Kubernetes"), resume("c1", 150000, "Go")); result.SavedCount != 0 {
+		t.Errorf("Повторный запуск сохранил %d резюме, ожидалось 0", result.SavedCount)
+	}
+
+	// Изменения доступны в отчете по истории
+	cfg := config.GetDefaultConfig()
+	cfg.Output.File = output
+	var report bytes.Buffer
+	if err := app.New(cfg, log).PrintResumeHistory(&report, []string{"a1"}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(report.String(), "v2") || !strings.Contains(report.String(), "skills: +Kubernetes") {
+		t.Errorf("В отчете нет изменений резюме:\n%s", report.String())
+	}
+}
+
+func TestContentHashIgnoresSkillDictionary(t *testing.T) {
+	original := entities.Resume{ID: "a1", Title: "Go Developer", Skills: []string{"golang", "k8s"}}
+	hash := entities.ContentHash(original)
+
+	// Те же навыки, приведенные к каноническим названиям разными словарями
+	normalized := original
+	normalized.Skills, normalized.SkillsOriginal = []string{"Go", "Kubernetes"}, original.Skills
+	if entities.ContentHash(normalized) != hash {
+		t.Error("Хэш резюме с каноническими навыками должен совпадать с хэшем написания соискателя")
+	}
+	normalized.Skills = []string{"Golang", "K8s"}
+	if entities.ContentHash(normalized) != hash {
+		t.Error("Изменение словаря навыков не должно менять хэш резюме")
+	}
+
+	// Изменение навыков самим соискателем меняет хэш
+	normalized.SkillsOriginal = []string{"golang", "k8s", "kafka"}
+	if entities.ContentHash(normalized) == hash {
+		t.Error("Новый навык соискателя должен менять хэш резюме")
+	}
+}
@@ -0,0 +1,154 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"hh-resume-parser/internal/app"
+	"hh-resume-parser/internal/config"
+	"hh-resume-parser/internal/domain/entities"
+	"hh-resume-parser/internal/domain/repositories"
+	"hh-resume-parser/internal/infrastructure/logger"
+	hhrepo "hh-resume-parser/internal/infrastructure/repositories"
+)
+
+// htmlFixtures - каталог сохраненных страниц резюме hh.ru
+const htmlFixtures = "testdata/html"
+
+func TestHTMLRepositoryParsesSavedPage(t *testing.T) {
+	repo := hhrepo.NewHTMLRepository(htmlFixtures, logger.NewConsole())
+
+	resume, err := repo.GetResumeByID(context.Background(), "a1b2c3d4e5f6")
+	if err != nil {
+		t.Fatalf("Ошибка чтения резюме: %v", err)
+	}
+
+	// Из двух сохраненных версий страницы берется самая свежая
+	msk := time.FixedZone("MSK", 3*60*60)
+	if !resume.LastUpdate.Equal(time.Date(2024, 3, 12, 10, 15, 0, 0, msk)) || resume.Title != "Go Developer" {
+		t.Errorf("Взята не последняя версия страницы: %s, %q", resume.LastUpdate, resume.Title)
+	}
+
+	checks := []struct {
+		field     string
+		got, want interface{}
+	}{
+		{"name", resume.Name, "Иванов Иван Петрович"},
+		{"url", resume.URL, "https://hh.ru/resume/a1b2c3d4e5f6"},
+		{"gender", resume.Gender, "Мужчина"},
+		{"age", resume.Age, 34},
+		{"location", resume.Location, "Москва"},
+		{"salary", *resume.Salary, entities.Salary{Amount: 250000, Currency: "RUR"}},
+		{"skills", resume.Skills, []string{"Go", "PostgreSQL", "Kafka"}},
+		{"about", resume.About, "Люблю распределенные системы.\nПишу тесты."},
+		{"roles", resume.ProfessionalRoles, []string{"Программист, разработчик", "Технический директор (CTO)"}},
+		{"employment", resume.Employment, []string{"полная занятость", "частичная занятость"}},
+		{"schedule", resume.Schedule, []string{"полный день", "удаленная работа"}},
+		{"total_experience", resume.TotalExperience, 76},
+		{"experience", resume.Experience, []entities.Job{
+			{
+				Company:     "Tech Corp",
+				Position:    "Senior Backend Developer",
+				StartDate:   "2021-01-01",
+				Description: "Разработка микросервисов на Go и gRPC.\nПеревод платежей на Kafka & PostgreSQL.",
+				Industry:    "Разработка программного обеспечения; Системная интеграция",
+			},
+			{
+				Company:     "ООО «Ромашка»",
+				Position:    "Backend Developer",
+				StartDate:   "2017-09-01",
+				EndDate:     "2020-12-01",
+				Description: "Поддержка биллинга на PHP\nМиграция на Go",
+			},
+		}},
+		{"education_level", resume.EducationLevel, "Высшее"},
+		{"education", resume.Education, []entities.Edu{
+			{Institution: "МГТУ им. Н.Э. Баумана", Faculty: "Информатика и системы управления", Specialty: "Программная инженерия", Year: "2012", Level: "Высшее"},
+			{Institution: "Яндекс Практикум", Faculty: "Продвинутый Go", Year: "2019", Level: "Повышение квалификации, курсы"},
+		}},
+		{"languages", resume.Languages, []entities.Language{{Name: "Русский", Level: "Родной"}, {Name: "Английский", Level: "B2 — Средне-продвинутый"}}},
+		{"phone", resume.Contact.Phone, "+7 (916) 123-45-67"},
+		{"email", resume.Contact.Email, "ivanov@example.com"},
+		{"telegram", resume.Contact.Telegram, "https://t.me/ivanov_dev"},
+		{"skype", resume.Contact.Skype, "ivanov.skype"},
+		{"social", resume.Contact.Social, []entities.Social{{Type: "github", URL: "https://github.com/ivanov"}}},
+	}
+	for _, c := range checks {
+		if !reflect.DeepEqual(c.got, c.want) {
+			t.Errorf("Поле %s: %#v, ожидалось %#v", c.field, c.got, c.want)
+		}
+	}
+
+	// Страница без ссылки canonical: идентификатор из og:url, зарплата в долларах до вычета налогов
+	other, err := repo.GetResumeByID(context.Background(), "ff00ee11dd22")
+	if err != nil {
+		t.Fatalf("Ошибка чтения резюме из вложенного каталога: %v", err)
+	}
+	if other.Salary == nil || *other.Salary != (entities.Salary{Amount: 3500, Currency: "USD", Gross: true}) {
+		t.Errorf("Неверная зарплата: %+v", other.Salary)
+	}
+	if other.Contact.Phone != "" || other.Age != 29 || other.TotalExperience != 24 {
+		t.Errorf("Неверные данные резюме: %+v", other)
+	}
+
+	if _, err := repo.GetResumeByID(context.Background(), "not_a_resume"); !errors.Is(err, repositories.ErrNotFound) {
+		t.Errorf("Страница без резюме должна пропускаться, получено %v", err)
+	}
+}
+
+func TestHTMLRepositorySearch(t *testing.T) {
+	repo := hhrepo.NewHTMLRepository(htmlFixtures, logger.NewConsole())
+	ctx := context.Background()
+
+	cases := []struct {
+		name     string
+		criteria repositories.SearchCriteria
+		want     []string
+	}{
+		{"все", repositories.SearchCriteria{}, []string{"ff00ee11dd22", "a1b2c3d4e5f6"}},
+		{"ключевые слова", repositories.SearchCriteria{Keywords: []string{"kafka", "grpc"}}, []string{"a1b2c3d4e5f6"}},
+		{"любое слово", repositories.SearchCriteria{Keywords: []string{"airflow", "grpc"}, KeywordLogic: repositories.LogicAny}, []string{"ff00ee11dd22", "a1b2c3d4e5f6"}},
+		{"город", repositories.SearchCriteria{City: "Санкт-Петербург"}, []string{"ff00ee11dd22"}},
+		{"опыт", repositories.SearchCriteria{Experience: "moreThan6"}, []string{"a1b2c3d4e5f6"}},
+		{"вторая страница", repositories.SearchCriteria{Page: 1, PerPage: 1}, []string{"a1b2c3d4e5f6"}},
+	}
+	for _, c := range cases {
+		resumes, err := repo.SearchResumes(ctx, c.criteria)
+		if err != nil {
+			t.Fatalf("%s: ошибка поиска: %v", c.name, err)
+		}
+		var ids []string
+		for _, resume := range resumes {
+			ids = append(ids, resume.ID)
+		}
+		if !reflect.DeepEqual(ids, c.want) {
+			t.Errorf("%s: найдены %v, ожидалось %v", c.name, ids, c.want)
+		}
+	}
+}
+
+func TestRunFromHTMLPages(t *testing.T) {
+	dir := t.TempDir()
+	cfg := config.GetDefaultConfig()
+	cfg.Search.HTMLDir = htmlFixtures
+	cfg.Search.Keywords = []string{"Go"}
+	cfg.Search.City = ""
+	cfg.Search.UpdateDays = 0
+	cfg.Search.FetchDetails = true
+	cfg.Cache.Dir = dir
+	cfg.Output.File = dir + "/resumes.json"
+	cfg.Contacts.LedgerFile = dir + "/contacts.json"
+
+	// Токен для сохраненных страниц не нужен
+	if err := app.New(cfg, logger.NewConsole()).Run(); err != nil {
+		t.Fatalf("Ошибка выполнения: %v", err)
+	}
+
+	resumes := readJSONResumes(t, cfg.Output.File)
+	if len(resumes) != 1 || resumes[0].ID != "a1b2c3d4e5f6" || resumes[0].Contact.Email == "" {
+		t.Fatalf("Сохранены неверные резюме: %+v", resumes)
+	}
+}
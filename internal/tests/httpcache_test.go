@@ -0,0 +1,115 @@
+package tests
+
+import (
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"hh-resume-parser/internal/app"
+	"hh-resume-parser/internal/infrastructure/httpcache"
+	"hh-resume-parser/internal/infrastructure/logger"
+)
+
+func TestDetailsRevalidatedWithETag(t *testing.T) {
+	fake := NewFakeHHServer(5)
+	defer fake.Close()
+
+	dir := t.TempDir()
+	cfg := fake.Config(dir)
+	cfg.Cache.HTTP = true
+	cfg.Search.City = ""
+	cfg.Search.FetchDetails = true
+
+	if err := app.New(cfg, logger.NewConsole()).Run(); err != nil {
+		t.Fatalf("Ошибка выполнения: %v", err)
+	}
+
+	// Повторный запуск в новый файл: полные резюме перепроверяются условными запросами
+	cfg.Output.File = filepath.Join(dir, "second.json")
+	if err := app.New(cfg, logger.NewConsole()).Run(); err != nil {
+		t.Fatalf("Ошибка повторного выполнения: %v", err)
+	}
+
+	if fake.NotModified() != 5 {
+		t.Errorf("Ожидалось 5 ответов 304, получено %d", fake.NotModified())
+	}
+	for _, resume := range readJSONResumes(t, cfg.Output.File) {
+		if resume.Gender == "" {
+			t.Errorf("Резюме %s из кэша сохранено без данных полной версии", resume.ID)
+		}
+	}
+}
+
+func TestFreshResponsesServedFromCache(t *testing.T) {
+	fake := NewFakeHHServer(5)
+	defer fake.Close()
+	fake.MaxAge = 3600
+
+	dir := t.TempDir()
+	cfg := fake.Config(dir)
+	cfg.Cache.HTTP = true
+	cfg.Search.City = ""
+	cfg.Search.FetchDetails = true
+
+	if err := app.New(cfg, logger.NewConsole()).Run(); err != nil {
+		t.Fatalf("Ошибка выполнения: %v", err)
+	}
+
+	cfg.Output.File = filepath.Join(dir, "second.json")
+	if err := app.New(cfg, logger.NewConsole()).Run(); err != nil {
+		t.Fatalf("Ошибка повторного выполнения: %v", err)
+	}
+
+	if got := fake.PathRequests("/resumes/fake00000"); got != 1 {
+		t.Errorf("Свежее резюме запрошено %d раз, ожидался 1", got)
+	}
+	if got := len(readJSONResumes(t, cfg.Output.File)); got != 5 {
+		t.Errorf("Сохранено %d резюме, ожидалось 5", got)
+	}
+}
+
+func TestHTTPCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := httpcache.New(t.TempDir(), 1500, nil, logger.NewConsole())
+	header := http.Header{"Etag": {`"v1"`}}
+	body := make([]byte, 400)
+
+	for _, url := range []string{"https://api/a", "https://api/b", "https://api/c"} {
+		cache.Store(url, header, body)
+		time.Sleep(10 * time.Millisecond)
+	}
+	if stats := cache.Stats(); stats.Stored != 3 || stats.Evicted != 1 || stats.Size > 1500 {
+		t.Fatalf("Неверная статистика кэша: %+v", stats)
+	}
+	if entry, _ := cache.Lookup("https://api/a"); entry != nil {
+		t.Errorf("Самая старая запись должна быть вытеснена")
+	}
+	if entry, _ := cache.Lookup("https://api/c"); entry == nil {
+		t.Errorf("Последняя запись не должна вытесняться")
+	}
+}
+
+func TestHTTPCacheFreshness(t *testing.T) {
+	ttls := map[string]time.Duration{"/resumes/": time.Hour}
+	cache := httpcache.New(t.TempDir(), 0, ttls, logger.NewConsole())
+
+	cache.Store("https://api/resumes/1", http.Header{}, []byte("{}"))
+	if _, fresh := cache.Lookup("https://api/resumes/1"); !fresh {
+		t.Errorf("Срок для префикса пути должен делать ответ свежим")
+	}
+
+	cache.Store("https://api/areas", http.Header{"Cache-Control": {"max-age=60"}}, []byte("[]"))
+	if _, fresh := cache.Lookup("https://api/areas"); !fresh {
+		t.Errorf("max-age должен делать ответ свежим")
+	}
+
+	cache.Store("https://api/me", http.Header{"Cache-Control": {"no-store"}, "Etag": {`"x"`}}, []byte("{}"))
+	if entry, _ := cache.Lookup("https://api/me"); entry != nil {
+		t.Errorf("Ответ с no-store не должен сохраняться")
+	}
+
+	cache.Store("https://api/vacancies", http.Header{}, []byte("{}"))
+	if entry, _ := cache.Lookup("https://api/vacancies"); entry != nil {
+		t.Errorf("Ответ без срока и валидаторов не должен сохраняться")
+	}
+}
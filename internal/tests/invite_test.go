@@ -0,0 +1,183 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"hh-resume-parser/internal/app"
+	"hh-resume-parser/internal/domain/entities"
+	"hh-resume-parser/internal/domain/usecases"
+	"hh-resume-parser/internal/infrastructure/logger"
+)
+
+// readInvitations читает журнал приглашений
+func readInvitations(t *testing.T, path string) []entities.Invitation {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Не удалось прочитать журнал приглашений: %v", err)
+	}
+
+	var invitations []entities.Invitation
+	if err := json.Unmarshal(data, &invitations); err != nil {
+		t.Fatalf("Некорректный журнал приглашений: %v", err)
+	}
+	return invitations
+}
+
+func TestInviteFromOutputFile(t *testing.T) {
+	fake := NewFakeHHServer(10)
+	defer fake.Close()
+
+	dir := t.TempDir()
+	cfg := fake.Config(dir)
+	cfg.Search.City = "Москва, Санкт-Петербург"
+	if err := app.New(cfg, logger.NewConsole()).Run(); err != nil {
+		t.Fatalf("Ошибка выполнения: %v", err)
+	}
+
+	cfg.Invite.VacancyID = "vac00001"
+	cfg.Invite.FromFile = cfg.Output.File
+	cfg.Invite.Filter = "skills=kafka; age<=40"
+	cfg.Invite.Message = "Здравствуйте, {{.Name}}! Приглашаем на вакансию {{.VacancyID}}."
+	cfg.Invite.LedgerFile = filepath.Join(dir, "invitations.json")
+
+	var out bytes.Buffer
+	if err := app.New(cfg, logger.NewConsole()).Invite(&out); err != nil {
+		t.Fatalf("Ошибка приглашения: %v", err)
+	}
+
+	// Kafka есть у резюме с нечетными номерами, возраст 25 + i
+	invitations := fake.Invitations()
+	if len(invitations) != 5 {
+		t.Fatalf("Отправлено %d приглашений, ожидалось 5:\n%s", len(invitations), out.String())
+	}
+	if got := invitations[0].Get("message"); got != "Здравствуйте, Иван Тестов-1! Приглашаем на вакансию vac00001." {
+		t.Errorf("Сообщение не заполнено по шаблону: %q", got)
+	}
+	if got := invitations[0].Get("resume_id"); got != "fake00001" {
+		t.Errorf("Первым приглашено резюме %s, ожидалось fake00001", got)
+	}
+
+	ledger := readInvitations(t, cfg.Invite.LedgerFile)
+	if len(ledger) != 5 || ledger[0].NegotiationID != "1" || ledger[0].InvitedAt.IsZero() {
+		t.Errorf("Журнал приглашений заполнен неверно: %+v", ledger)
+	}
+
+	// Повторный запуск никого не приглашает второй раз
+	out.Reset()
+	if err := app.New(cfg, logger.NewConsole()).Invite(&out); err != nil {
+		t.Fatalf("Ошибка повторного приглашения: %v", err)
+	}
+	if len(fake.Invitations()) != 5 {
+		t.Errorf("Повторный запуск отправил приглашения: всего %d", len(fake.Invitations()))
+	}
+	if !strings.Contains(out.String(), "пропущено 5") {
+		t.Errorf("Уже приглашенные не отмечены в итогах:\n%s", out.String())
+	}
+}
+
+func TestInviteDryRunAndRejections(t *testing.T) {
+	fake := NewFakeHHServer(5)
+	defer fake.Close()
+
+	dir := t.TempDir()
+	cfg := fake.Config(dir)
+	cfg.Invite.VacancyID = "vac00002"
+	cfg.Invite.ResumeIDs = []string{"fake00001", "missing", "fake00003"}
+	cfg.Invite.Message = "Приглашаем на собеседование"
+	cfg.Invite.LedgerFile = filepath.Join(dir, "invitations.json")
+	cfg.Invite.DryRun = true
+
+	var out bytes.Buffer
+	if err := app.New(cfg, logger.NewConsole()).Invite(&out); err != nil {
+		t.Fatalf("Ошибка пробного запуска: %v", err)
+	}
+	if fake.PathRequests("/negotiations/phone_interview") != 0 {
+		t.Errorf("Пробный запуск не должен отправлять приглашения")
+	}
+	if _, err := os.Stat(cfg.Invite.LedgerFile); !os.IsNotExist(err) {
+		t.Errorf("Пробный запуск не должен создавать журнал")
+	}
+	if !strings.Contains(out.String(), "будет приглашено 3") {
+		t.Errorf("Итоги пробного запуска неверны:\n%s", out.String())
+	}
+
+	// Приглашение, отправленное в обход журнала, сервер отклоняет как повторное
+	cfg.Invite.DryRun = false
+	cfg.Invite.ResumeIDs = []string{"fake00003"}
+	if err := app.New(cfg, logger.NewConsole()).Invite(&out); err != nil {
+		t.Fatalf("Ошибка приглашения: %v", err)
+	}
+	os.Remove(cfg.Invite.LedgerFile)
+
+	// Отказ по одному резюме не прерывает остальные
+	out.Reset()
+	cfg.Invite.ResumeIDs = []string{"fake00001", "missing", "fake00003"}
+	if err := app.New(cfg, logger.NewConsole()).Invite(&out); err != nil {
+		t.Fatalf("Ошибка приглашения: %v", err)
+	}
+	if !strings.Contains(out.String(), "приглашено 1, будет приглашено 0, пропущено 1, отклонено 1") {
+		t.Errorf("Итоги приглашения неверны:\n%s", out.String())
+	}
+	if len(fake.Invitations()) != 2 {
+		t.Errorf("Принято %d приглашений, ожидалось 2", len(fake.Invitations()))
+	}
+
+	// Отклоненное сервером как повторное приглашение записывается в журнал
+	ledger := readInvitations(t, cfg.Invite.LedgerFile)
+	if len(ledger) != 2 {
+		t.Errorf("В журнале %d приглашений, ожидалось 2: %+v", len(ledger), ledger)
+	}
+}
+
+func TestResumeFilterRules(t *testing.T) {
+	resume := entities.Resume{
+		ID:              "r1",
+		Location:        "Москва",
+		Skills:          []string{"Go", "Kafka"},
+		Age:             30,
+		TotalExperience: 50,
+	}
+
+	cases := []struct {
+		rule  string
+		match bool
+	}{
+		{"", true},
+		{"skills=go", true},
+		{"skills!=Kafka", false},
+		{"skills~kaf; location=москва", true},
+		{"experience>=4", true},
+		{"experience>4", false},
+		{"age<30", false},
+		{"salary<=100000", false},
+		{"salary!=100000", true},
+		{"title!~junior", true},
+		{"skills!~kaf", false},
+		{"email!=", false},
+		{"email=", true},
+		{"salary>0 | skills=kafka", true},
+		{"salary>0 | jobs>0", false},
+	}
+	for _, c := range cases {
+		filter, err := usecases.ParseResumeFilter(c.rule)
+		if err != nil {
+			t.Fatalf("Ошибка разбора правила %q: %v", c.rule, err)
+		}
+		if got := filter.Match(&resume); got != c.match {
+			t.Errorf("Правило %q: совпадение %v, ожидалось %v", c.rule, got, c.match)
+		}
+	}
+
+	for _, rule := range []string{"skills", "unknown=1", "age~3", "age=много", "skills>3"} {
+		if _, err := usecases.ParseResumeFilter(rule); err == nil {
+			t.Errorf("Правило %q должно быть отклонено", rule)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"hh-resume-parser/internal/config"
+	"hh-resume-parser/internal/infrastructure/auth"
+	"hh-resume-parser/internal/infrastructure/logger"
+)
+
+// fakeTokenServer имитирует сервер авторизации hh.ru
+type fakeTokenServer struct {
+	server    *httptest.Server
+	expiresIn int64        // Срок жизни выдаваемых токенов в секундах
+	refreshes atomic.Int32 // Количество обновлений по refresh token
+	issued    atomic.Int32 // Счетчик выданных токенов
+}
+
+func newFakeTokenServer(t *testing.T, expiresIn int64) *fakeTokenServer {
+	fake := &fakeTokenServer{expiresIn: expiresIn}
+
+	fake.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.Form.Get("client_id") != "client" || r.Form.Get("client_secret") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_client"})
+			return
+		}
+
+		switch r.Form.Get("grant_type") {
+		case "authorization_code":
+			if r.Form.Get("code") != "good-code" {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+				return
+			}
+		case "refresh_token":
+			if r.Form.Get("refresh_token") == "" {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+				return
+			}
+			fake.refreshes.Add(1)
+			// Имитация медленного сервера, чтобы параллельные запросы пересеклись
+			time.Sleep(20 * time.Millisecond)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		n := fake.issued.Add(1)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token":  fmt.Sprintf("access-%d", n),
+			"refresh_token": fmt.Sprintf("refresh-%d", n),
+			"token_type":    "bearer",
+			"expires_in":    fake.expiresIn,
+		})
+	}))
+	t.Cleanup(fake.server.Close)
+
+	return fake
+}
+
+func newTestTokenSource(tokenURL, tokenFile string) *auth.OAuthTokenSource {
+	cfg := config.GetDefaultConfig().API.OAuth
+	cfg.ClientID = "client"
+	cfg.ClientSecret = "secret"
+	cfg.TokenURL = tokenURL
+	cfg.TokenFile = tokenFile
+
+	return auth.NewOAuthTokenSource(cfg, auth.NewFileTokenStore(tokenFile), http.DefaultClient, logger.NewConsole())
+}
+
+func TestOAuthExchangeStoresTokenSecurely(t *testing.T) {
+	fake := newFakeTokenServer(t, 3600)
+	tokenFile := filepath.Join(t.TempDir(), "token.json")
+	source := newTestTokenSource(fake.server.URL, tokenFile)
+
+	if _, err := source.Exchange(context.Background(), "bad-code"); err == nil {
+		t.Fatal("Ожидалась ошибка для неверного кода авторизации")
+	}
+
+	if _, err := source.Exchange(context.Background(), "good-code"); err != nil {
+		t.Fatalf("Ошибка обмена кода: %v", err)
+	}
+
+	info, err := os.Stat(tokenFile)
+	if err != nil {
+		t.Fatalf("Файл токенов не создан: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("Права на файл токенов %o, ожидались 600", perm)
+	}
+
+	// Новый источник читает токен из файла
+	restored := newTestTokenSource(fake.server.URL, tokenFile)
+	token, err := restored.Token(context.Background())
+	if err != nil {
+		t.Fatalf("Ошибка получения токена: %v", err)
+	}
+	if token != "access-1" {
+		t.Errorf("Получен токен %q, ожидался access-1", token)
+	}
+	if fake.refreshes.Load() != 0 {
+		t.Errorf("Действующий токен не должен обновляться")
+	}
+}
+
+func TestOAuthRefreshesBeforeExpiry(t *testing.T) {
+	// Токен живет меньше, чем запас RefreshBefore, поэтому обновляется при каждом обращении
+	fake := newFakeTokenServer(t, 60)
+	source := newTestTokenSource(fake.server.URL, filepath.Join(t.TempDir(), "token.json"))
+
+	if _, err := source.Exchange(context.Background(), "good-code"); err != nil {
+		t.Fatalf("Ошибка обмена кода: %v", err)
+	}
+
+	token, err := source.Token(context.Background())
+	if err != nil {
+		t.Fatalf("Ошибка получения токена: %v", err)
+	}
+	if token != "access-2" || fake.refreshes.Load() != 1 {
+		t.Errorf("Ожидалось обновление истекающего токена, получен %q, обновлений %d", token, fake.refreshes.Load())
+	}
+}
+
+func TestOAuthConcurrentRefreshHappensOnce(t *testing.T) {
+	fake := newFakeTokenServer(t, 3600)
+	source := newTestTokenSource(fake.server.URL, filepath.Join(t.TempDir(), "token.json"))
+
+	if _, err := source.Exchange(context.Background(), "good-code"); err != nil {
+		t.Fatalf("Ошибка обмена кода: %v", err)
+	}
+
+	// Все запросы получили 401 с одним и тем же токеном
+	var wg sync.WaitGroup
+	tokens := make(chan string, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := source.Refresh(context.Background(), "access-1")
+			if err != nil {
+				t.Errorf("Ошибка обновления токена: %v", err)
+				return
+			}
+			tokens <- token
+		}()
+	}
+	wg.Wait()
+	close(tokens)
+
+	if n := fake.refreshes.Load(); n != 1 {
+		t.Errorf("Токен обновлен %d раз, ожидалось 1", n)
+	}
+	for token := range tokens {
+		if token != "access-2" {
+			t.Errorf("Получен токен %q, ожидался access-2", token)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package tests
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"hh-resume-parser/internal/domain/repositories"
+	"hh-resume-parser/internal/infrastructure/logger"
+	hhrepo "hh-resume-parser/internal/infrastructure/repositories"
+)
+
+// planSearch строит план поиска резюме "Go" на тестовом сервере с лимитом глубины depthLimit
+func planSearch(t *testing.T, fake *FakeHHServer, depthLimit int) (repositories.ResumeRepository, *repositories.SearchPlan) {
+	t.Helper()
+
+	fake.DepthLimit = depthLimit
+	cfg := fake.Config(t.TempDir())
+	cfg.API.DepthLimit = depthLimit
+	cfg.Search.City = ""
+
+	repo := hhrepo.NewHHRepository(cfg, logger.NewConsole(), nil)
+	plan, err := repo.(repositories.SearchPlanner).PlanSearch(context.Background(), repositories.SearchCriteria{
+		Keywords: []string{"Go"},
+		PerPage:  100,
+	})
+	if err != nil {
+		t.Fatalf("Ошибка построения плана: %v", err)
+	}
+	return repo, plan
+}
+
+func TestPlanSearchSplitsDeepQuery(t *testing.T) {
+	const total = 9000
+	fake := NewFakeHHServer(total)
+	defer fake.Close()
+
+	repo, plan := planSearch(t, fake, 2000)
+	if plan.TotalFound != total || plan.Reachable != total {
+		t.Fatalf("Найдено %d, доступно %d, ожидалось %d", plan.TotalFound, plan.Reachable, total)
+	}
+
+	// Каждая часть укладывается в лимит и относится к одному уровню опыта
+	byExperience := make(map[string][]repositories.QuerySlice)
+	for _, slice := range plan.Slices {
+		if slice.Found > plan.DepthLimit || slice.Truncated {
+			t.Errorf("Часть %+v превышает лимит глубины", slice.Criteria)
+		}
+		if slice.Criteria.Experience == "" || slice.Criteria.DateFrom.IsZero() || slice.Criteria.DateTo.IsZero() {
+			t.Errorf("Часть не разбита по опыту и дате обновления: %+v", slice.Criteria)
+		}
+		byExperience[slice.Criteria.Experience] = append(byExperience[slice.Criteria.Experience], slice)
+	}
+	if len(byExperience) != 4 {
+		t.Errorf("Запрос разбит на %d уровней опыта, ожидалось 4", len(byExperience))
+	}
+
+	// Окна дат одного уровня опыта идут подряд с шагом в секунду, без пересечений и пропусков
+	var boundary time.Time
+	for experience, slices := range byExperience {
+		sort.Slice(slices, func(i, j int) bool { return slices[i].Criteria.DateFrom.Before(slices[j].Criteria.DateFrom) })
+		for i := 1; i < len(slices); i++ {
+			prev, next := slices[i-1].Criteria, slices[i].Criteria
+			if !next.DateFrom.Equal(prev.DateTo.Add(time.Second)) {
+				t.Errorf("Опыт %s: окно %s - %s не продолжает окно, заканчивающееся %s",
+					experience, next.DateFrom, next.DateTo, prev.DateTo)
+			}
+			if experience == "noExperience" && boundary.IsZero() {
+				boundary = prev.DateTo
+			}
+		}
+	}
+
+	// Резюме, обновленное ровно на границе окон, попадает только в одну часть
+	if boundary.IsZero() {
+		t.Fatal("Окна дат уровня noExperience не разбиты")
+	}
+	fake.SetUpdated("fake00000", boundary)
+
+	seen := make(map[string]int)
+	for _, slice := range plan.Slices {
+		for page := 0; page < slice.Pages(plan.DepthLimit); page++ {
+			criteria := slice.Criteria
+			criteria.Page = page
+			resumes, err := repo.SearchResumes(context.Background(), criteria)
+			if err != nil {
+				t.Fatalf("Ошибка получения страницы: %v", err)
+			}
+			for _, resume := range resumes {
+				seen[resume.ID]++
+			}
+		}
+	}
+	if len(seen) != total {
+		t.Errorf("По частям получено %d разных резюме, ожидалось %d", len(seen), total)
+	}
+	for id, count := range seen {
+		if count != 1 {
+			t.Errorf("Резюме %s получено %d раз", id, count)
+		}
+	}
+}
+
+func TestPlanSearchStopsAtMinimalWindow(t *testing.T) {
+	fake := NewFakeHHServer(40)
+	defer fake.Close()
+
+	// При лимите в одно резюме окно делится, пока не станет не длиннее часа
+	_, plan := planSearch(t, fake, 1)
+
+	truncated := 0
+	for _, slice := range plan.Slices {
+		if !slice.Truncated {
+			continue
+		}
+		truncated++
+		if window := slice.Criteria.DateTo.Sub(slice.Criteria.DateFrom); window > time.Hour {
+			t.Errorf("Часть с окном %s отмечена как неделимая", window)
+		}
+	}
+	if truncated == 0 || plan.Reachable >= plan.TotalFound {
+		t.Errorf("Ожидались неделимые части и неполное покрытие: доступно %d из %d", plan.Reachable, plan.TotalFound)
+	}
+}
@@ -0,0 +1,162 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"hh-resume-parser/internal/config"
+	"hh-resume-parser/internal/domain/repositories"
+	"hh-resume-parser/internal/infrastructure/ratelimit"
+)
+
+func TestLimiterBurstAndInterval(t *testing.T) {
+	limiter := ratelimit.New(config.APIConfig{RateLimit: 50 * time.Millisecond, RateBurst: 3})
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if _, err := limiter.Wait(ctx, "/resumes"); err != nil {
+			t.Fatalf("Ошибка ожидания: %v", err)
+		}
+	}
+	elapsed := time.Since(start)
+
+	// Три запроса без ожидания, еще два - через интервал
+	if elapsed < 90*time.Millisecond {
+		t.Errorf("Ограничение не соблюдено: 5 запросов за %s", elapsed)
+	}
+
+	stats := limiter.Stats()
+	if stats.Requests != 5 || stats.Waits != 2 {
+		t.Errorf("Неверная статистика: запросов %d, ожиданий %d", stats.Requests, stats.Waits)
+	}
+	if stats.ByEndpoint["/resumes"] != 5 {
+		t.Errorf("Неверный учет по конечной точке: %v", stats.ByEndpoint)
+	}
+}
+
+func TestLimiterConcurrentWaiters(t *testing.T) {
+	limiter := ratelimit.New(config.APIConfig{RateLimit: 20 * time.Millisecond, RateBurst: 1})
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var times []time.Time
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := limiter.Wait(ctx, "/resumes"); err != nil {
+				t.Errorf("Ошибка ожидания: %v", err)
+				return
+			}
+			mu.Lock()
+			times = append(times, time.Now())
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	first, last := times[0], times[0]
+	for _, at := range times {
+		if at.Before(first) {
+			first = at
+		}
+		if at.After(last) {
+			last = at
+		}
+	}
+
+	// 10 запросов с интервалом 20мс занимают не меньше 9 интервалов
+	if spread := last.Sub(first); spread < 170*time.Millisecond {
+		t.Errorf("Параллельные запросы не разнесены во времени: %s", spread)
+	}
+}
+
+func TestLimiterEndpointBudget(t *testing.T) {
+	limiter := ratelimit.New(config.APIConfig{
+		RateBurst:          1,
+		EndpointRateLimits: map[string]time.Duration{"/resumes": 100 * time.Millisecond},
+	})
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if _, err := limiter.Wait(ctx, "/areas"); err != nil {
+			t.Fatalf("Ошибка ожидания: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("Конечная точка без бюджета не должна ждать: %s", elapsed)
+	}
+
+	limiter.Wait(ctx, "/resumes")
+	if waited, _ := limiter.Wait(ctx, "/resumes"); waited < 50*time.Millisecond {
+		t.Errorf("Бюджет конечной точки не соблюден: ожидание %s", waited)
+	}
+}
+
+func TestLimiterCancellation(t *testing.T) {
+	limiter := ratelimit.New(config.APIConfig{RateLimit: time.Hour, RateBurst: 1})
+
+	if _, err := limiter.Wait(context.Background(), "/resumes"); err != nil {
+		t.Fatalf("Первый запрос не должен ждать: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := limiter.Wait(ctx, "/resumes")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Ожидалась ошибка отмены контекста, получено: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Ожидание не прервано отменой контекста: %s", elapsed)
+	}
+	if stats := limiter.Stats(); stats.Cancelled != 1 || stats.Requests != 1 {
+		t.Errorf("Неверная статистика после отмены: %+v", stats)
+	}
+}
+
+func TestLimiterDailyQuota(t *testing.T) {
+	limiter := ratelimit.New(config.APIConfig{DailyQuota: 3})
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if _, err := limiter.Wait(ctx, "/resumes"); err != nil {
+			t.Fatalf("Запрос %d в пределах квоты отклонен: %v", i+1, err)
+		}
+	}
+
+	_, err := limiter.Wait(ctx, "/resumes")
+	if !errors.Is(err, repositories.ErrRateLimited) {
+		t.Fatalf("Ожидалась ошибка исчерпания квоты, получено: %v", err)
+	}
+	if stats := limiter.Stats(); stats.DailyUsed != 3 || stats.Rejected != 1 {
+		t.Errorf("Неверная статистика квоты: %+v", stats)
+	}
+}
+
+func TestLimiterRefund(t *testing.T) {
+	limiter := ratelimit.New(config.APIConfig{RateLimit: time.Hour, RateBurst: 1, DailyQuota: 1})
+	ctx := context.Background()
+
+	if _, err := limiter.Wait(ctx, "/resumes"); err != nil {
+		t.Fatalf("Первый запрос не должен ждать: %v", err)
+	}
+	limiter.Refund("/resumes")
+
+	// Возвращенный бюджет позволяет выполнить запрос сразу и в пределах квоты
+	waited, err := limiter.Wait(ctx, "/resumes")
+	if err != nil || waited > 0 {
+		t.Fatalf("Бюджет не возвращен: ожидание %s, ошибка %v", waited, err)
+	}
+	if stats := limiter.Stats(); stats.Refunded != 1 || stats.DailyUsed != 1 {
+		t.Errorf("Неверная статистика возврата: %+v", stats)
+	}
+}
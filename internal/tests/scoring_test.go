@@ -0,0 +1,206 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"hh-resume-parser/internal/adapters/storage"
+	"hh-resume-parser/internal/app"
+	"hh-resume-parser/internal/config"
+	"hh-resume-parser/internal/domain/entities"
+	"hh-resume-parser/internal/domain/repositories"
+	"hh-resume-parser/internal/domain/usecases"
+	"hh-resume-parser/internal/infrastructure/logger"
+)
+
+// testHiringProfile - профиль вакансии Go разработчика для тестов оценки
+func testHiringProfile() usecases.HiringProfile {
+	return usecases.HiringProfile{
+		Name:              "go-backend",
+		RequiredSkills:    map[string]float64{"Go": 2, "Kafka": 1, "Kubernetes": 1},
+		NiceToHaveSkills:  map[string]float64{"Docker": 1},
+		MinExperience:     4,
+		SalaryCeiling:     200000,
+		Currency:          "RUR",
+		Locations:         []string{"Москва"},
+		FreshnessHalfLife: 30,
+	}
+}
+
+func TestResumeScoreBreakdown(t *testing.T) {
+	scorer, err := usecases.NewResumeScorer(testHiringProfile(), usecases.NewSkillDictionary(usecases.DefaultSkillAliases()))
+	if err != nil {
+		t.Fatalf("Ошибка создания оценки: %v", err)
+	}
+
+	resume := entities.Resume{
+		ID:              "a1",
+		Skills:          []string{"Golang", "Docker"},
+		InferredSkills:  []entities.InferredSkill{{Name: "Kafka", Source: entities.SkillSourceDescription, Confidence: 0.7}},
+		TotalExperience: 24,
+		Salary:          &entities.Salary{Amount: 250000, Currency: "RUR"},
+		Location:        "Казань",
+		Relocation:      &entities.Relocation{Type: "relocation_possible", Areas: []string{"Москва"}},
+		LastUpdate:      time.Now(),
+	}
+	score := scorer.Score(&resume)
+
+	// Обязательные навыки: Go (2) и Kafka с уверенностью 0.7 (1) из 4; стаж 2 года из 4;
+	// зарплата на четверть выше потолка; переезд в подходящий регион
+	want := map[string]float64{
+		usecases.ScoreRequiredSkills:   27,
+		usecases.ScoreNiceToHaveSkills: 15,
+		usecases.ScoreExperience:       7.5,
+		usecases.ScoreSalary:           7.5,
+		usecases.ScoreLocation:         5,
+		usecases.ScoreFreshness:        10,
+	}
+	if len(score.Components) != len(want) {
+		t.Fatalf("Составляющие оценки: %s", score.Breakdown())
+	}
+	for _, component := range score.Components {
+		if component.Points != want[component.Name] {
+			t.Errorf("Составляющая %s: %.1f баллов, ожидалось %.1f", component.Name, component.Points, want[component.Name])
+		}
+	}
+	if score.Total != 72 || score.Profile != "go-backend" {
+		t.Errorf("Итоговая оценка %.1f по профилю %q, ожидалось 72.0 по go-backend", score.Total, score.Profile)
+	}
+	if !strings.Contains(score.Breakdown(), "required_skills 27.0/40.0 (есть: Go, Kafka (0.70); нет: Kubernetes)") {
+		t.Errorf("Пояснение оценки: %s", score.Breakdown())
+	}
+
+	// Без требования к региону его вес распределяется между остальными составляющими
+	profile := testHiringProfile()
+	profile.Locations = nil
+	scorer, _ = usecases.NewResumeScorer(profile, nil)
+	if score := scorer.Score(&resume); len(score.Components) != 5 || score.Components[0].MaxPoints != 44.4 {
+		t.Errorf("Оценка без региона: %s", score.Breakdown())
+	}
+
+	if _, err := usecases.NewResumeScorer(usecases.HiringProfile{Weights: map[string]float64{"age": 1}}, nil); err == nil {
+		t.Error("Профиль с неизвестной составляющей должен отклоняться")
+	}
+	if _, err := usecases.NewResumeScorer(usecases.HiringProfile{Name: "empty"}, nil); err == nil {
+		t.Error("Профиль без требований должен отклоняться")
+	}
+}
+
+func TestMinScoreSkipsResumes(t *testing.T) {
+	log := logger.NewConsole()
+	scorer, err := usecases.NewResumeScorer(testHiringProfile(), usecases.NewSkillDictionary(usecases.DefaultSkillAliases()))
+	if err != nil {
+		t.Fatalf("Ошибка создания оценки: %v", err)
+	}
+
+	resumes := []entities.Resume{
+		{ID: "weak", Title: "Go Developer", Skills: []string{"PHP"}, Location: "Казань"},
+		{ID: "middle", Title: "Go Developer", Skills: []string{"go", "kafka"}, TotalExperience: 60, Location: "Москва"},
+		{ID: "strong", Title: "Go Developer", Skills: []string{"go", "kafka", "k8s", "docker"}, TotalExperience: 60, Location: "Москва", LastUpdate: time.Now()},
+	}
+	output := filepath.Join(t.TempDir(), "resumes.json")
+	useCase := usecases.NewResumeUseCase(&listRepository{resumes: resumes}, storage.NewFileStorage("json", output, log), nil, log,
+		usecases.WithResumeScorer(scorer, 50))
+	result, err := useCase.ParseResumesByCriteria(context.Background(), repositories.SearchCriteria{
+		Keywords: []string{"Go"},
+		PerPage:  repositories.DefaultPerPage,
+	})
+	if err != nil {
+		t.Fatalf("Ошибка парсинга: %v", err)
+	}
+
+	if result.SkippedByRule[usecases.RuleMinScore] != 1 {
+		t.Errorf("Пропущено по порогу оценки %d, ожидалось 1", result.SkippedByRule[usecases.RuleMinScore])
+	}
+	// Резюме сохранены по убыванию оценки, а не в порядке выдачи
+	saved := readJSONResumes(t, output)
+	if len(saved) != 2 || saved[0].ID != "strong" || saved[1].ID != "middle" || saved[0].Score == nil || saved[0].Score.Total < 95 {
+		t.Fatalf("Сохранены резюме %+v", saved)
+	}
+}
+
+func TestRankSavedResumes(t *testing.T) {
+	dir := t.TempDir()
+	log := logger.NewConsole()
+
+	resumes := []entities.Resume{
+		{ID: "r1", Title: "PHP Developer", Skills: []string{"PHP"}, LastUpdate: time.Now()},
+		{ID: "r2", Title: "Go Developer", Skills: []string{"Go", "Kafka"}, TotalExperience: 60, LastUpdate: time.Now()},
+		{ID: "r3", Title: "Go Developer", Skills: []string{"Go", "Kafka", "Kubernetes", "Docker"}, TotalExperience: 60, LastUpdate: time.Now()},
+	}
+	from := filepath.Join(dir, "resumes.json")
+	if err := storage.NewFileStorage("json", from, log).SaveResumes(context.Background(), resumes); err != nil {
+		t.Fatal(err)
+	}
+
+	profile, err := json.Marshal(testHiringProfile())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.GetDefaultConfig()
+	cfg.Output.File = from
+	cfg.Scoring.Profile = filepath.Join(dir, "profile.json")
+	cfg.Scoring.Top = 2
+	cfg.Scoring.Output = filepath.Join(dir, "ranked.csv")
+	if err := os.WriteFile(cfg.Scoring.Profile, profile, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := app.New(cfg, log).RankResumes(&out); err != nil {
+		t.Fatalf("Ошибка ранжирования: %v", err)
+	}
+
+	report := out.String()
+	if first, second := strings.Index(report, "r3"), strings.Index(report, "r2"); first < 0 || second < first || strings.Contains(report, "r1") {
+		t.Errorf("Неверный порядок резюме:\n%s", report)
+	}
+	if !strings.Contains(report, "оценено 3") || !strings.Contains(report, "выведено 2") {
+		t.Errorf("Неверные итоги ранжирования:\n%s", report)
+	}
+
+	data, err := os.ReadFile(cfg.Scoring.Output)
+	if err != nil {
+		t.Fatalf("Ранжированные резюме не сохранены: %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(string(data)), "\n"); len(lines) != 3 || !strings.HasPrefix(lines[1], "r3") {
+		t.Errorf("Сохранены ранжированные резюме:\n%s", data)
+	}
+
+	// Исходный файл не перезаписывается ранжированными резюме
+	cfg.Scoring.Output = from
+	if err := app.New(cfg, log).RankResumes(&out); err == nil {
+		t.Error("Сохранение в исходный файл должно отклоняться")
+	}
+}
+
+func TestProfileRefusesResume(t *testing.T) {
+	dir := t.TempDir()
+	profile, err := json.Marshal(testHiringProfile())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.GetDefaultConfig()
+	cfg.API.Token = "token"
+	cfg.Search.Keywords = []string{"Go"}
+	cfg.Scoring.Profile = filepath.Join(dir, "profile.json")
+	if err := os.WriteFile(cfg.Scoring.Profile, profile, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Ошибка проверки конфигурации: %v", err)
+	}
+
+	// Оцененные резюме сохраняются в конце запуска, контрольной точки для продолжения нет
+	cfg.Output.Resume = true
+	if err := cfg.Validate(); err == nil {
+		t.Error("Продолжение запуска с профилем вакансии должно отклоняться")
+	}
+}
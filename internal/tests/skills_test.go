@@ -0,0 +1,165 @@
+package tests
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"hh-resume-parser/internal/adapters/storage"
+	"hh-resume-parser/internal/domain/entities"
+	"hh-resume-parser/internal/domain/repositories"
+	"hh-resume-parser/internal/domain/usecases"
+	"hh-resume-parser/internal/infrastructure/logger"
+)
+
+func TestSkillDictionaryCanonicalization(t *testing.T) {
+	dict := usecases.NewSkillDictionary(usecases.DefaultSkillAliases(), map[string][]string{
+		"Spring Boot": {"SpringBoot", "Спринг бут"},
+	})
+
+	skills := []string{
+		"Golang", "GoLang", "go lang", "k8s", "Postgres", "1С", // 1С - с кириллической С
+		"Kubernetes", "  Spring   boot ", "Спринг Бут", "Unknown  Tool", "unknown tool", "",
+	}
+	want := []string{"Go", "Kubernetes", "PostgreSQL", "1C", "Spring Boot", "Unknown Tool"}
+	if got := dict.Canonicalize(skills); !reflect.DeepEqual(got, want) {
+		t.Errorf("Канонические навыки %q, ожидались %q", got, want)
+	}
+
+	// Навык из встроенного словаря переопределяется пользовательским
+	custom := usecases.NewSkillDictionary(usecases.DefaultSkillAliases(), map[string][]string{"Golang": {"Go"}})
+	if name, ok := custom.Canonical("go"); !ok || name != "Golang" {
+		t.Errorf("Навык go приведен к %q, ожидалось Golang", name)
+	}
+}
+
+func TestSkillsKeptInOutputs(t *testing.T) {
+	log := logger.NewConsole()
+	resume := entities.Resume{
+		ID:         "a1",
+		Title:      "Backend Developer",
+		Skills:     []string{"Golang", "k8s", "Go"},
+		Experience: []entities.Job{{Description: "Обмен сообщениями через Kafka"}},
+	}
+	dict := usecases.NewSkillDictionary(usecases.DefaultSkillAliases())
+	wantInferred := "Kafka (description, 0.70)"
+	wantSkills := []string{"Go", "Kubernetes"}
+	wantOriginal := []string{"Golang", "k8s", "Go"}
+
+	for _, format := range []string{"json", "csv", "sql"} {
+		t.Run(format, func(t *testing.T) {
+			output := filepath.Join(t.TempDir(), "resumes."+format)
+			var store repositories.StorageRepository
+			switch format {
+			case "csv":
+				store = storage.NewCSVStorage(output, log)
+			case "sql":
+				store = storage.NewSQLStorage(output, log)
+			default:
+				store = storage.NewFileStorage(format, output, log)
+			}
+
+			useCase := usecases.NewResumeUseCase(&listRepository{resumes: []entities.Resume{resume}}, store, nil, log,
+				usecases.WithSkillDictionary(dict), usecases.WithSkillExtractor(usecases.NewSkillExtractor(dict, 0)))
+			if _, err := useCase.ParseResumesByCriteria(context.Background(), repositories.SearchCriteria{
+				Keywords: []string{"Go"},
+				PerPage:  repositories.DefaultPerPage,
+			}); err != nil {
+				t.Fatalf("Ошибка парсинга: %v", err)
+			}
+
+			if format == "json" {
+				saved := readJSONResumes(t, output)
+				if len(saved) != 1 || !reflect.DeepEqual(saved[0].Skills, wantSkills) || !reflect.DeepEqual(saved[0].SkillsOriginal, wantOriginal) {
+					t.Fatalf("Сохранены навыки %+v", saved)
+				}
+				if len(saved[0].InferredSkills) != 1 || saved[0].InferredSkills[0].String() != wantInferred {
+					t.Errorf("Найденные навыки %v, ожидался %s", saved[0].InferredSkills, wantInferred)
+				}
+				return
+			}
+
+			data, err := os.ReadFile(output)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, value := range []string{strings.Join(wantSkills, "; "), strings.Join(wantOriginal, "; "), wantInferred} {
+				if !strings.Contains(string(data), value) {
+					t.Errorf("В файле %s нет навыков %q", format, value)
+				}
+			}
+		})
+	}
+}
+
+func TestSkillExtractionFromExperience(t *testing.T) {
+	extractor := usecases.NewSkillExtractor(usecases.NewSkillDictionary(usecases.DefaultSkillAliases()), usecases.DefaultSkillMinConfidence)
+	resume := entities.Resume{
+		Title:  "Golang-разработчик",
+		Skills: []string{"Docker", "golang"},
+		Experience: []entities.Job{
+			{
+				Position:    "Backend developer (gRPC, микросервисы)",
+				Description: "Сервисы на Go с обменом через Apache Kafka и PostgreSQL. Kafka consumer groups, Google Cloud. Развернули в k8s, докер-образы.",
+			},
+			{
+				Position:    "Разработчик",
+				Description: "Поддержка сервисов на Kafka, отчеты для Google Analytics, ML-модели, the rest of the team.",
+			},
+		},
+	}
+
+	got := make(map[string]entities.InferredSkill)
+	for _, skill := range extractor.Extract(&resume) {
+		got[skill.Name] = skill
+	}
+
+	want := map[string]string{
+		"gRPC":          entities.SkillSourcePosition,
+		"Microservices": entities.SkillSourcePosition,
+		"Kafka":         entities.SkillSourceDescription,
+		"PostgreSQL":    entities.SkillSourceDescription,
+		"GCP":           entities.SkillSourceDescription,
+		"Kubernetes":    entities.SkillSourceDescription,
+	}
+	for name, source := range want {
+		skill, ok := got[name]
+		if !ok {
+			t.Errorf("Навык %s не найден: %v", name, got)
+			continue
+		}
+		if skill.Source != source {
+			t.Errorf("Навык %s найден в %s, ожидалось %s", name, skill.Source, source)
+		}
+	}
+
+	// Указанные соискателем навыки не дублируются, случайные совпадения не считаются навыками
+	for _, name := range []string{"Go", "Docker", "REST API"} {
+		if _, ok := got[name]; ok {
+			t.Errorf("Навык %s не должен попасть в найденные: %v", name, got[name])
+		}
+	}
+
+	// Три упоминания Kafka надежнее одного упоминания PostgreSQL
+	if got["Kafka"].Confidence <= got["PostgreSQL"].Confidence || got["Kafka"].Confidence > 0.95 {
+		t.Errorf("Уверенность Kafka %.2f, PostgreSQL %.2f", got["Kafka"].Confidence, got["PostgreSQL"].Confidence)
+	}
+
+	// Написания, совпадающие с обычными английскими словами, ищутся с учетом регистра
+	plain := entities.Resume{
+		Title: "Product manager",
+		Experience: []entities.Job{{
+			Description: "Led the go-to-market plan, helped teams go live, kept the process elastic; every node of the funnel.",
+		}},
+	}
+	for _, skill := range extractor.Extract(&plain) {
+		t.Errorf("Навык %s найден в обычных словах", skill.Name)
+	}
+	plain.Experience[0].Description = "Сервисы на Go и Node, поиск на Elastic."
+	if skills := extractor.Extract(&plain); len(skills) != 3 {
+		t.Errorf("Найдены навыки %v, ожидались Go, Node.js и Elasticsearch", skills)
+	}
+}
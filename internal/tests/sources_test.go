@@ -0,0 +1,171 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"hh-resume-parser/internal/adapters/storage"
+	"hh-resume-parser/internal/app"
+	"hh-resume-parser/internal/config"
+	"hh-resume-parser/internal/domain/entities"
+	"hh-resume-parser/internal/domain/repositories"
+	"hh-resume-parser/internal/domain/usecases"
+	"hh-resume-parser/internal/infrastructure/logger"
+	hhrepo "hh-resume-parser/internal/infrastructure/repositories"
+)
+
+// writeResumeExport - JSON выгрузка резюме в формате вывода парсера
+func writeResumeExport(t *testing.T, file string, resumes []entities.Resume) {
+	t.Helper()
+
+	data, err := json.Marshal(resumes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// exportResume - резюме из выгрузки, которое находится по ключевому слову Go
+func exportResume(id, email string) entities.Resume {
+	return entities.Resume{
+		ID:         id,
+		Name:       "Соискатель " + id,
+		Title:      "Go Developer",
+		Skills:     []string{"Go"},
+		LastUpdate: time.Now().Add(-time.Hour),
+		Contact:    entities.Contact{Email: email},
+		Source:     "old-run",
+	}
+}
+
+func TestRunFromMultipleSources(t *testing.T) {
+	fake := NewFakeHHServer(5)
+	defer fake.Close()
+
+	dir := t.TempDir()
+	export := filepath.Join(dir, "export.json")
+	writeResumeExport(t, export, []entities.Resume{
+		exportResume("fake00001", ""),                 // То же резюме, что и в API
+		exportResume("copy00001", "IVAN@example.com"), // Тот же соискатель по контактам из API
+		exportResume("json00001", "petr@example.com"),
+	})
+
+	cfg := fake.Config(dir)
+	cfg.Search.City = ""
+	cfg.Search.UpdateDays = 0
+	cfg.Search.FetchDetails = true
+	cfg.Contacts.Open = true
+	cfg.Search.Sources = []config.SourceConfig{
+		{Type: config.SourceHH, RateBurst: 10},
+		{Name: "saved", Type: config.SourceHTML, Path: htmlFixtures},
+		{Name: "import", Type: config.SourceJSON, Path: export},
+		{Name: "broken", Type: config.SourceJSON, Path: filepath.Join(dir, "missing")},
+	}
+
+	log, err := logger.NewWithLevel(filepath.Join(dir, "sources.log"), logger.INFO)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.New(cfg, log).Run(); err != nil {
+		t.Fatalf("Недоступный источник не должен прерывать запуск: %v", err)
+	}
+	log.Close()
+
+	// Каждое резюме помечено своим источником, дубликаты между источниками не сохранены
+	bySource := make(map[string][]string)
+	for _, resume := range readJSONResumes(t, cfg.Output.File) {
+		bySource[resume.Source] = append(bySource[resume.Source], resume.ID)
+	}
+	if len(bySource["hh"]) != 5 {
+		t.Errorf("Из API сохранено %v, ожидалось 5 резюме", bySource["hh"])
+	}
+	if strings.Join(bySource["saved"], ",") != "a1b2c3d4e5f6" {
+		t.Errorf("Из сохраненных страниц сохранено %v", bySource["saved"])
+	}
+	if strings.Join(bySource["import"], ",") != "json00001" {
+		t.Errorf("Из выгрузки сохранено %v, ожидалось только json00001", bySource["import"])
+	}
+	if len(bySource) != 3 {
+		t.Errorf("Неожиданные источники резюме: %v", bySource)
+	}
+
+	// Бюджет контактов расходуют только резюме из API
+	if fake.ContactOpenings() != 5 {
+		t.Errorf("Открыто %d контактов, ожидалось 5", fake.ContactOpenings())
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "sources.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(string(data), "Итоги источника") != 4 {
+		t.Errorf("В логе нет итогов по каждому источнику")
+	}
+}
+
+func TestSourcesMergeAndDedupe(t *testing.T) {
+	dir := t.TempDir()
+	log := logger.NewConsole()
+
+	export := filepath.Join(dir, "export.json")
+	writeResumeExport(t, export, []entities.Resume{
+		exportResume("a1b2c3d4e5f6", ""),                 // Та же страница, что и в каталоге
+		exportResume("copy00001", " ivanov@example.com"), // Тот же соискатель под другим ID
+		exportResume("json00001", "petr@example.com"),
+	})
+
+	output := filepath.Join(dir, "resumes.json")
+	useCase := usecases.NewResumeUseCase(nil, storage.NewFileStorage("json", output, log), nil, log,
+		usecases.WithSources(
+			usecases.Source{Name: "saved", Repository: hhrepo.NewHTMLRepository(htmlFixtures, log)},
+			usecases.Source{Name: "broken", Repository: hhrepo.NewJSONRepository(filepath.Join(dir, "missing.json"), log)},
+			usecases.Source{Name: "import", Repository: hhrepo.NewJSONRepository(export, log)},
+		),
+	)
+
+	result, err := useCase.ParseResumesByCriteria(context.Background(), repositories.SearchCriteria{
+		Keywords: []string{"Go"},
+		PerPage:  repositories.DefaultPerPage,
+	})
+	if err != nil {
+		t.Fatalf("Ошибка парсинга: %v", err)
+	}
+
+	if len(result.Sources) != 3 {
+		t.Fatalf("Итоги по %d источникам, ожидалось 3", len(result.Sources))
+	}
+	saved, broken, imported := result.Sources[0], result.Sources[1], result.Sources[2]
+	if saved.Saved != 1 || saved.Duplicates != 0 || len(saved.Errors) != 0 {
+		t.Errorf("Неверные итоги каталога страниц: %+v", saved)
+	}
+	if broken.Saved != 0 || len(broken.Errors) == 0 || broken.Aborted != nil {
+		t.Errorf("Ошибки недоступного источника должны учитываться отдельно, не прерывая сбор: %+v", broken)
+	}
+	if imported.Found != 3 || imported.Saved != 1 || imported.Duplicates != 2 || imported.Skipped != 2 {
+		t.Errorf("Неверные итоги выгрузки: %+v", imported)
+	}
+	if result.SavedCount != 2 || len(result.Errors) != len(broken.Errors) {
+		t.Errorf("Общие итоги не сходятся с итогами источников: %+v", result)
+	}
+
+	resumes := readJSONResumes(t, output)
+	if len(resumes) != 2 || resumes[0].Source != "saved" || resumes[1].ID != "json00001" || resumes[1].Source != "import" {
+		t.Errorf("Сохранены неверные резюме: %+v", resumes)
+	}
+
+	// Тип источника проверяется по зарегистрированным фабрикам
+	cfg := config.GetDefaultConfig()
+	cfg.Search.Keywords = []string{"Go"}
+	cfg.Search.Sources = []config.SourceConfig{{Type: "superjob"}}
+	cfg.Output.File = filepath.Join(dir, "other.json")
+	if err := app.New(cfg, log).Run(); err == nil || !strings.Contains(err.Error(), "неизвестный тип источника") {
+		t.Errorf("Неизвестный тип источника должен отклоняться, получено %v", err)
+	}
+}
@@ -0,0 +1,198 @@
+package tests
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"hh-resume-parser/internal/adapters/storage"
+	"hh-resume-parser/internal/domain/entities"
+	"hh-resume-parser/internal/domain/repositories"
+	"hh-resume-parser/internal/domain/usecases"
+	"hh-resume-parser/internal/infrastructure/logger"
+)
+
+// pagedRepository - выдача из pages страниц, после которых источник отвечает ошибкой авторизации
+// beforePage вызывается перед отдачей каждой страницы
+type pagedRepository struct {
+	pages      int
+	beforePage func(page int)
+}
+
+func (r *pagedRepository) SearchResumes(ctx context.Context, criteria repositories.SearchCriteria) ([]entities.Resume, error) {
+	r.beforePage(criteria.Page)
+	if criteria.Page >= r.pages {
+		return nil, fmt.Errorf("%w: токен отозван", repositories.ErrUnauthorized)
+	}
+
+	resumes := make([]entities.Resume, 0, criteria.PerPage)
+	for i := 0; i < criteria.PerPage; i++ {
+		id := fmt.Sprintf("page%d-%d", criteria.Page, i)
+		resumes = append(resumes, entities.Resume{
+			ID:         id,
+			Title:      "Go Developer",
+			Skills:     []string{"Go"},
+			LastUpdate: time.Now(),
+		})
+	}
+	return resumes, nil
+}
+
+func (r *pagedRepository) GetResumeByID(ctx context.Context, id string) (*entities.Resume, error) {
+	return nil, repositories.ErrNotFound
+}
+
+// countSaved - количество резюме в файле вывода; файл должен быть корректным в своем формате
+func countSaved(t *testing.T, format, file string) int {
+	t.Helper()
+
+	switch format {
+	case "json":
+		return len(readJSONResumes(t, file))
+	case "csv":
+		f, err := os.Open(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		records, err := csv.NewReader(f).ReadAll()
+		if err != nil {
+			t.Fatalf("Некорректный CSV в файле вывода: %v", err)
+		}
+		return len(records) - 1
+	default:
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return strings.Count(string(data), "INSERT INTO resumes")
+	}
+}
+
+func TestStreamingWritesEachPage(t *testing.T) {
+	const perPage = 5
+
+	for _, format := range []string{"json", "csv", "sql"} {
+		t.Run(format, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "resumes."+format)
+			log := logger.NewConsole()
+
+			var fileStorage repositories.StorageRepository
+			switch format {
+			case "csv":
+				fileStorage = storage.NewCSVStorage(file, log)
+			case "sql":
+				fileStorage = storage.NewSQLStorage(file, log)
+			default:
+				fileStorage = storage.NewFileStorage(format, file, log)
+			}
+
+			// Перед запросом каждой страницы все предыдущие уже записаны на диск
+			repo := &pagedRepository{pages: 3}
+			repo.beforePage = func(page int) {
+				if page == 0 {
+					return
+				}
+				if saved := countSaved(t, format, file); saved != page*perPage {
+					t.Errorf("Перед страницей %d записано %d резюме, ожидалось %d", page, saved, page*perPage)
+				}
+			}
+
+			useCase := usecases.NewResumeUseCase(repo, fileStorage, nil, log)
+			result, err := useCase.ParseResumesByCriteria(context.Background(), repositories.SearchCriteria{
+				Keywords: []string{"Go"},
+				PerPage:  perPage,
+			})
+			if err == nil || !errors.Is(err, repositories.ErrUnauthorized) {
+				t.Fatalf("Ожидалось прерывание парсинга, получено %v", err)
+			}
+
+			// Прерванный запуск сохраняет все страницы, полученные до ошибки
+			if saved := countSaved(t, format, file); saved != 3*perPage || result.SavedCount != 3*perPage {
+				t.Errorf("Сохранено %d резюме (по итогам %d), ожидалось %d", saved, result.SavedCount, 3*perPage)
+			}
+		})
+	}
+}
+
+func TestCSVAppendKeepsColumns(t *testing.T) {
+	log := logger.NewConsole()
+	file := filepath.Join(t.TempDir(), "resumes.csv")
+	csvStorage := storage.NewCSVStorage(file, log)
+	resume := entities.Resume{ID: "a1", Name: "Иван", Skills: []string{"Go"}, SkillsOriginal: []string{"golang"}}
+	if err := csvStorage.SaveResumes(context.Background(), []entities.Resume{resume}); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(f).ReadAll()
+	f.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Столбцы исходного формата не сдвигаются, новые добавлены в конец
+	header := records[0]
+	if strings.Join(header[:5], ",") != "ID,Name,Title,Skills,Experience" || header[len(header)-2] != "Skills Original" {
+		t.Errorf("Неверный порядок столбцов: %v", header)
+	}
+
+	// Файл с тем же набором столбцов дописывается, с другим - нет
+	appender := csvStorage.(repositories.ResumeAppendStorage)
+	writer, err := appender.AppendResumes(context.Background())
+	if err != nil {
+		t.Fatalf("Ошибка дозаписи: %v", err)
+	}
+	writer.Close()
+
+	old := strings.Join(header[:len(header)-2], ",") + "\na0,Старое резюме\n"
+	if err := os.WriteFile(file, []byte(old), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := appender.AppendResumes(context.Background()); err == nil {
+		t.Error("Дозапись в файл с другими столбцами должна отклоняться")
+	}
+}
+
+func TestSQLSchemaAddsNewColumns(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "resumes.sql")
+	if err := storage.NewSQLStorage(file, logger.NewConsole()).SaveResumes(context.Background(),
+		[]entities.Resume{{ID: "a1", Name: "Иван"}}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	script := string(data)
+
+	// Каждый столбец, которого не было в первой версии таблицы, добавляется в существующую таблицу
+	baseline := map[string]bool{
+		"id": true, "name": true, "title": true, "skills": true, "last_update": true, "contact_phone": true,
+		"contact_email": true, "url": true, "location": true, "age": true, "gender": true, "created_at": true,
+	}
+	start := strings.Index(script, "CREATE TABLE IF NOT EXISTS resumes (")
+	end := strings.Index(script[start:], ");")
+	lines := strings.Split(script[start:start+end], "\n")[1:]
+	for _, line := range lines {
+		column := strings.TrimSuffix(strings.TrimSpace(line), ",")
+		if column == "" || baseline[strings.Fields(column)[0]] {
+			continue
+		}
+		if !strings.Contains(script, "ALTER TABLE resumes ADD COLUMN IF NOT EXISTS "+column+";") {
+			t.Errorf("Столбец %s не добавляется в таблицу, созданную прежней версией", column)
+		}
+	}
+	if !strings.Contains(script, "score NUMERIC(4, 1)") {
+		t.Errorf("В схеме нет столбца оценки:\n%s", script)
+	}
+}
@@ -36,15 +36,20 @@ func TestStressParsingWithDifferentFormats(t *testing.T) {
 	// Создаем временную директорию для тестовых файлов
 	tempDir := t.TempDir()
 
+	// Запросы выполняются к локальной имитации API hh.ru
+	fake := NewFakeHHServer(40)
+	defer fake.Close()
+
 	// Инициализируем логгер для тестов
 	testLogger := logger.NewConsoleWithLevel(logger.DEBUG)
 
-	var wg sync.WaitGroup
-	errorChan := make(chan error, testCfg.NumGoroutines*testCfg.RequestsPerGo)
-
 	// Запускаем тесты для каждого формата
 	for _, format := range testCfg.OutputFormats {
+		format := format
 		t.Run(fmt.Sprintf("Format_%s", format), func(t *testing.T) {
+			var wg sync.WaitGroup
+			errorChan := make(chan error, testCfg.NumGoroutines*testCfg.RequestsPerGo)
+
 			wg.Add(testCfg.NumGoroutines)
 
 			for i := 0; i < testCfg.NumGoroutines; i++ {
@@ -53,7 +58,7 @@ func TestStressParsingWithDifferentFormats(t *testing.T) {
 
 					for j := 0; j < testCfg.RequestsPerGo; j++ {
 						// Создаем конфигурацию для каждого запроса
-						cfg := config.GetDefaultConfig()
+						cfg := fake.Config(tempDir)
 						cfg.API.RateLimit = testCfg.RateLimit
 						cfg.Search.Keywords = testCfg.Keywords
 						cfg.Output.Format = format
@@ -101,13 +106,17 @@ func TestRateLimitCompliance(t *testing.T) {
 		1 * time.Second,
 	}
 
+	fake := NewFakeHHServer(100)
+	defer fake.Close()
+
 	for _, rateLimit := range rateLimits {
 		t.Run(fmt.Sprintf("RateLimit_%s", rateLimit), func(t *testing.T) {
-			cfg := config.GetDefaultConfig()
+			dir := t.TempDir()
+			cfg := fake.Config(dir)
 			cfg.API.RateLimit = rateLimit
 			cfg.Search.Keywords = []string{"Go"}
 			cfg.Output.Format = "json"
-			cfg.Output.File = fmt.Sprintf("test_rate_%s.json", rateLimit)
+			cfg.Output.File = fmt.Sprintf("%s/test_rate_%s.json", dir, rateLimit)
 
 			testLogger := logger.NewConsoleWithLevel(logger.DEBUG)
 			application := app.New(cfg, testLogger)
@@ -131,15 +140,18 @@ func TestRateLimitCompliance(t *testing.T) {
 }
 
 func TestErrorHandling(t *testing.T) {
+	fake := NewFakeHHServer(20)
+	defer fake.Close()
+
 	testCases := []struct {
 		name          string
-		invalidConfig func() *config.Config
+		invalidConfig func(dir string) *config.Config
 		expectedErr   bool
 	}{
 		{
 			name: "InvalidToken",
-			invalidConfig: func() *config.Config {
-				cfg := config.GetDefaultConfig()
+			invalidConfig: func(dir string) *config.Config {
+				cfg := fake.Config(dir)
 				cfg.API.Token = "invalid_token"
 				return cfg
 			},
@@ -147,8 +159,8 @@ func TestErrorHandling(t *testing.T) {
 		},
 		{
 			name: "EmptyKeywords",
-			invalidConfig: func() *config.Config {
-				cfg := config.GetDefaultConfig()
+			invalidConfig: func(dir string) *config.Config {
+				cfg := fake.Config(dir)
 				cfg.Search.Keywords = []string{}
 				return cfg
 			},
@@ -156,8 +168,8 @@ func TestErrorHandling(t *testing.T) {
 		},
 		{
 			name: "InvalidOutputFormat",
-			invalidConfig: func() *config.Config {
-				cfg := config.GetDefaultConfig()
+			invalidConfig: func(dir string) *config.Config {
+				cfg := fake.Config(dir)
 				cfg.Output.Format = "invalid_format"
 				return cfg
 			},
@@ -167,7 +179,7 @@ func TestErrorHandling(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			cfg := tc.invalidConfig()
+			cfg := tc.invalidConfig(t.TempDir())
 			testLogger := logger.NewConsoleWithLevel(logger.DEBUG)
 			application := app.New(cfg, testLogger)
 
@@ -184,7 +196,11 @@ func TestErrorHandling(t *testing.T) {
 }
 
 func TestConcurrentSearchRequests(t *testing.T) {
-	cfg := config.GetDefaultConfig()
+	fake := NewFakeHHServer(40)
+	defer fake.Close()
+
+	tempDir := t.TempDir()
+	cfg := fake.Config(tempDir)
 	cfg.API.RateLimit = 500 * time.Millisecond
 
 	testLogger := logger.NewConsoleWithLevel(logger.DEBUG)
@@ -201,13 +217,14 @@ func TestConcurrentSearchRequests(t *testing.T) {
 	results := make(chan int, len(keywords))
 	errors := make(chan error, len(keywords))
 
-	for _, keyword := range keywords {
+	for i, keyword := range keywords {
 		wg.Add(1)
-		go func(kw string) {
+		go func(i int, kw string) {
 			defer wg.Done()
 
 			// Создаем конфигурацию для каждого запроса
-			cfg := config.GetDefaultConfig()
+			cfg := fake.Config(tempDir)
+			cfg.Output.File = fmt.Sprintf("%s/concurrent_%d.json", tempDir, i)
 			cfg.Search.Keywords = []string{kw}
 			cfg.Search.City = "Moscow"
 
@@ -221,7 +238,7 @@ func TestConcurrentSearchRequests(t *testing.T) {
 
 			// Note: В реальном приложении здесь нужно добавить подсчет найденных резюме
 			results <- 1 // Временное решение
-		}(keyword)
+		}(i, keyword)
 
 		// Соблюдаем rate limit между запусками горутин
 		time.Sleep(cfg.API.RateLimit)
@@ -0,0 +1,229 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"hh-resume-parser/internal/app"
+	"hh-resume-parser/internal/config"
+	"hh-resume-parser/internal/domain/repositories"
+	"hh-resume-parser/internal/infrastructure/auth"
+	"hh-resume-parser/internal/infrastructure/logger"
+)
+
+// Секреты токенов пула достаточно длинные, чтобы их нельзя было случайно найти в логе
+const (
+	poolTokenMain    = "secret-main-0123456789"
+	poolTokenBackup  = "secret-backup-0123456789"
+	poolTokenRevoked = "secret-revoked-0123456789"
+)
+
+func TestTokenPoolRotation(t *testing.T) {
+	fake := NewFakeHHServer(20)
+	defer fake.Close()
+
+	// У резервного аккаунта на сервере осталось три запроса, третий токен отозван
+	fake.AddToken(poolTokenMain, 0)
+	fake.AddToken(poolTokenBackup, 3)
+	fake.AddToken(poolTokenRevoked, 0)
+	fake.RevokeToken(poolTokenRevoked)
+
+	dir := t.TempDir()
+	cfg := fake.Config(dir)
+	cfg.API.Token = ""
+	cfg.API.Tokens = []config.TokenConfig{
+		{Name: "main", Token: poolTokenMain},
+		{Name: "backup", Token: poolTokenBackup},
+		{Name: "revoked", Token: poolTokenRevoked},
+	}
+	cfg.Search.City = ""
+	cfg.Search.FetchDetails = true
+	cfg.Search.DetailWorkers = 4
+
+	log, err := logger.NewWithLevel(filepath.Join(dir, "pool.log"), logger.DEBUG)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.New(cfg, log).Run(); err != nil {
+		t.Fatalf("Ошибка выполнения: %v", err)
+	}
+	log.Close()
+
+	resumes := readJSONResumes(t, cfg.Output.File)
+	if len(resumes) != 20 {
+		t.Fatalf("Сохранено %d резюме, ожидалось 20", len(resumes))
+	}
+	for _, resume := range resumes {
+		if resume.Gender == "" {
+			t.Errorf("Резюме %s сохранено без данных полной версии", resume.ID)
+		}
+	}
+
+	// Исчерпанный и отозванный токены исключены, остальные запросы выполнены основным
+	if fake.TokenRequests(poolTokenBackup) != 3 {
+		t.Errorf("С резервным токеном выполнено %d запросов, ожидалось 3", fake.TokenRequests(poolTokenBackup))
+	}
+	if fake.TokenRequests(poolTokenRevoked) != 0 {
+		t.Errorf("Отозванный токен принят %d раз", fake.TokenRequests(poolTokenRevoked))
+	}
+	if served := fake.TokenRequests(poolTokenMain) + fake.TokenRequests(poolTokenBackup); served < 21 {
+		t.Errorf("Выполнено %d запросов, ожидалось не меньше 21 (поиск и 20 полных резюме)", served)
+	}
+
+	// В логе видно, каким токеном выполнен запрос, но не сами токены
+	data, err := os.ReadFile(filepath.Join(dir, "pool.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	logText := string(data)
+	for _, secret := range []string{poolTokenMain, poolTokenBackup, poolTokenRevoked} {
+		if strings.Contains(logText, secret) {
+			t.Errorf("Токен %s попал в лог", secret)
+		}
+	}
+	if !strings.Contains(logText, "main#"+auth.Fingerprint(poolTokenMain)) {
+		t.Errorf("В логе нет запросов с отметкой основного токена")
+	}
+	if !strings.Contains(logText, "Токен исключен из ротации") || !strings.Contains(logText, "Квота токена исчерпана") {
+		t.Errorf("Исключение токенов из ротации не записано в лог")
+	}
+}
+
+func TestTokenPoolLocalQuota(t *testing.T) {
+	fake := NewFakeHHServer(5)
+	defer fake.Close()
+
+	fake.AddToken(poolTokenMain, 0)
+	fake.AddToken(poolTokenBackup, 0)
+
+	pool := auth.NewStaticTokenPool([]config.TokenConfig{
+		{Name: "main", Token: poolTokenMain, DailyQuota: 2},
+		{Token: poolTokenBackup, DailyQuota: 1},
+	}, logger.NewConsole())
+
+	// Токены выдаются по очереди, пока не исчерпаны их собственные квоты
+	ctx := context.Background()
+	var served []string
+	for i := 0; i < 3; i++ {
+		lease, err := pool.Acquire(ctx, "/resumes")
+		if err != nil {
+			t.Fatalf("Ошибка получения токена %d: %v", i+1, err)
+		}
+		if strings.Contains(lease.Label(), lease.Token) {
+			t.Errorf("Метка токена %q раскрывает сам токен", lease.Label())
+		}
+		served = append(served, strings.Split(lease.Label(), "#")[0])
+	}
+	if strings.Join(served, ",") != "main,token-2,main" {
+		t.Errorf("Порядок ротации %v, ожидалось main, token-2, main", served)
+	}
+
+	if _, err := pool.Acquire(ctx, "/resumes"); !errors.Is(err, repositories.ErrRateLimited) {
+		t.Errorf("После исчерпания всех квот ожидалась ErrRateLimited, получено %v", err)
+	}
+	for _, stats := range pool.Stats() {
+		if stats.Status != auth.TokenExhausted || stats.DailyUsed != stats.DailyQuota {
+			t.Errorf("Неверная статистика токена: %+v", stats)
+		}
+	}
+
+	// Проверка пула токенов в конфигурации
+	cfg := fake.Config(t.TempDir())
+	cfg.API.Token = ""
+	cfg.API.Tokens = []config.TokenConfig{{Name: "a", Token: "x"}, {Name: "a", Token: "y"}}
+	if err := cfg.Validate(); err == nil {
+		t.Errorf("Повторяющиеся имена токенов должны быть отклонены")
+	}
+	cfg.API.Tokens = []config.TokenConfig{{Name: "a"}}
+	if err := cfg.Validate(); err == nil {
+		t.Errorf("Пустой токен в пуле должен быть отклонен")
+	}
+}
+
+// flakyTokenSource - источник токенов, обновление которого завершается заданными ошибками
+type flakyTokenSource struct {
+	mu     sync.Mutex
+	token  string
+	errors []error // Ошибки очередных обновлений; когда закончатся, обновление успешно
+}
+
+func (s *flakyTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.token, nil
+}
+
+func (s *flakyTokenSource) Refresh(ctx context.Context, stale string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.errors) > 0 {
+		err := s.errors[0]
+		s.errors = s.errors[1:]
+		return "", err
+	}
+	s.token = stale + "-refreshed"
+	return s.token, nil
+}
+
+func TestTokenPoolTransientRefreshError(t *testing.T) {
+	ctx := context.Background()
+	source := &flakyTokenSource{
+		token:  poolTokenMain,
+		errors: []error{fmt.Errorf("%w: сервер авторизации недоступен", repositories.ErrServer)},
+	}
+	pool := auth.NewTokenPool([]auth.PoolToken{
+		{Name: "oauth", Source: source},
+		{Name: "static", Source: auth.NewStaticTokenSource(poolTokenBackup)},
+	}, logger.NewConsole())
+
+	lease, err := pool.Acquire(ctx, "/resumes")
+	if err != nil || lease.Token != poolTokenMain {
+		t.Fatalf("Выдан токен %v, ошибка %v", lease, err)
+	}
+
+	// Сбой сервера авторизации исключает токен только на время
+	if !pool.Unauthorized(ctx, lease) {
+		t.Error("Запрос должен повторяться с другим токеном пула")
+	}
+	if stats := pool.Stats()[0]; stats.Status != auth.TokenUnavailable {
+		t.Errorf("Токен после сбоя обновления в состоянии %s, ожидалось %s", stats.Status, auth.TokenUnavailable)
+	}
+	if lease, err := pool.Acquire(ctx, "/resumes"); err != nil || lease.Token != poolTokenBackup {
+		t.Errorf("Во время исключения выдан токен %v, ошибка %v", lease, err)
+	}
+
+	// После задержки токен возвращается в ротацию и обновляется
+	time.Sleep(1100 * time.Millisecond)
+	if pool.Active() != 2 {
+		t.Fatalf("В ротации %d токенов, ожидалось 2", pool.Active())
+	}
+	lease, err = pool.Acquire(ctx, "/resumes")
+	if err != nil || lease.Token != poolTokenMain {
+		t.Fatalf("Выдан токен %v, ошибка %v", lease, err)
+	}
+	if !pool.Unauthorized(ctx, lease) || pool.Stats()[0].Status != auth.TokenActive {
+		t.Errorf("Токен не обновлен после восстановления сервера авторизации: %+v", pool.Stats()[0])
+	}
+
+	// Отказ сервера авторизации исключает токен до конца работы
+	revoked := auth.NewTokenPool([]auth.PoolToken{{Name: "oauth", Source: &flakyTokenSource{
+		token:  poolTokenRevoked,
+		errors: []error{fmt.Errorf("%w: invalid_grant", repositories.ErrUnauthorized)},
+	}}}, logger.NewConsole())
+	if lease, err = revoked.Acquire(ctx, "/resumes"); err != nil {
+		t.Fatal(err)
+	}
+	if revoked.Unauthorized(ctx, lease) {
+		t.Error("Запрос с отозванным последним токеном не должен повторяться")
+	}
+	if stats := revoked.Stats()[0]; stats.Status != auth.TokenRevoked {
+		t.Errorf("Токен после invalid_grant в состоянии %s, ожидалось %s", stats.Status, auth.TokenRevoked)
+	}
+}
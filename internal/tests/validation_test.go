@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"hh-resume-parser/internal/adapters/storage"
+	"hh-resume-parser/internal/app"
+	"hh-resume-parser/internal/config"
+	"hh-resume-parser/internal/domain/entities"
+	"hh-resume-parser/internal/domain/repositories"
+	"hh-resume-parser/internal/domain/usecases"
+	"hh-resume-parser/internal/infrastructure/logger"
+)
+
+func TestValidationRulesDropAndWarn(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "resumes.json")
+	log := logger.NewConsole()
+
+	rules := make([]usecases.ValidationRule, 0, 2)
+	for _, rule := range []config.ValidationRuleConfig{
+		{Name: "has_email", Condition: "email!="},
+		{Name: "not_junior", Condition: "title!~junior", Severity: usecases.SeverityWarn},
+	} {
+		validationRule, err := usecases.NewValidationRule(rule.Name, rule.Condition, rule.Severity)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rules = append(rules, validationRule)
+	}
+
+	resumes := []entities.Resume{
+		{ID: "a1", Title: "Go Developer", Contact: entities.Contact{Email: "a@example.com"}},
+		{ID: "b1", Title: "Go Developer", Skills: []string{"Go"}},
+		{ID: "c1", Title: "Junior Go Developer", Contact: entities.Contact{Email: "c@example.com"}},
+		{ID: "d1", Contact: entities.Contact{Email: "d@example.com"}},
+	}
+	useCase := usecases.NewResumeUseCase(&listRepository{resumes: resumes}, storage.NewFileStorage("json", output, log), nil, log,
+		usecases.WithValidationRules(rules...))
+	result, err := useCase.ParseResumesByCriteria(context.Background(), repositories.SearchCriteria{
+		Keywords: []string{"Go"},
+		PerPage:  repositories.DefaultPerPage,
+	})
+	if err != nil {
+		t.Fatalf("Ошибка парсинга: %v", err)
+	}
+
+	// Правило skills_or_experience заменено заданными правилами, обязательные поля проверяются всегда
+	if result.SavedCount != 2 || result.SkippedCount != 2 {
+		t.Errorf("Сохранено %d, пропущено %d резюме; ожидалось 2 и 2", result.SavedCount, result.SkippedCount)
+	}
+	wantSkipped := map[string]int{usecases.RuleRequiredFields: 1, "has_email": 1}
+	if !reflect.DeepEqual(result.SkippedByRule, wantSkipped) {
+		t.Errorf("Пропуски по правилам %v, ожидались %v", result.SkippedByRule, wantSkipped)
+	}
+	if !reflect.DeepEqual(result.WarningsByRule, map[string]int{"not_junior": 1}) {
+		t.Errorf("Предупреждения по правилам %v, ожидалось not_junior: 1", result.WarningsByRule)
+	}
+
+	saved := readJSONResumes(t, output)
+	if len(saved) != 2 || saved[0].ID != "a1" || saved[1].ID != "c1" {
+		t.Errorf("Сохранены неверные резюме: %+v", saved)
+	}
+}
+
+func TestValidationRulesConfig(t *testing.T) {
+	log := logger.NewConsole()
+	newConfig := func(rules ...config.ValidationRuleConfig) *config.Config {
+		cfg := config.GetDefaultConfig()
+		cfg.API.Token = "token"
+		cfg.Search.Keywords = []string{"Go"}
+		cfg.Output.File = filepath.Join(t.TempDir(), "resumes.json")
+		cfg.Validation = rules
+		return cfg
+	}
+
+	if err := newConfig(config.ValidationRuleConfig{Name: "fresh", Condition: "updated_days<=14", Severity: "block"}).Validate(); err == nil {
+		t.Error("Неизвестное действие правила должно быть отклонено")
+	}
+	rule := config.ValidationRuleConfig{Name: "fresh", Condition: "updated_days<=14"}
+	if err := newConfig(rule, rule).Validate(); err == nil {
+		t.Error("Повторное имя правила должно быть отклонено")
+	}
+
+	// Условие разбирается при создании приложения, ошибка возвращается из Run
+	err := app.New(newConfig(config.ValidationRuleConfig{Name: "fresh", Condition: "updated<=14"}), log).Run()
+	if err == nil || !strings.Contains(err.Error(), "fresh") {
+		t.Errorf("Ошибка в условии правила не обнаружена: %v", err)
+	}
+}